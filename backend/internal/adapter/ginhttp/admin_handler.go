@@ -0,0 +1,46 @@
+package ginhttp
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/ginhttp/dto"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+// AdminHandler handles the routes of admin tools. Its routes must be registered behind middleware.RequireRole.
+type AdminHandler struct {
+	RetentionJob port.RetentionJob
+}
+
+func NewAdminHandler(retentionJob port.RetentionJob) *AdminHandler {
+	return &AdminHandler{RetentionJob: retentionJob}
+}
+
+func (h *AdminHandler) RegisterRoutes(r *gin.RouterGroup) {
+	adminRoutes := r.Group("/admin")
+	retentionRoutes := adminRoutes.Group("/retention")
+	retentionRoutes.GET("/preview", h.GetRetentionPreview) // query params: limit
+	retentionRoutes.GET("/stats", h.GetRetentionStats)
+}
+
+func (h *AdminHandler) GetRetentionPreview(c *gin.Context) {
+	limit, err := validateLimitQueryParam(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "AdminHandler::GetRetentionPreview", err)))
+		return
+	}
+
+	preview, err := h.RetentionJob.Preview(c.Request.Context(), limit)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "AdminHandler::GetRetentionPreview", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetRetentionPreviewRes{Preview: preview})
+}
+
+func (h *AdminHandler) GetRetentionStats(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.GetRetentionStatsRes{Stats: h.RetentionJob.Stats()})
+}
@@ -0,0 +1,130 @@
+package ginhttp
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/ginhttp/dto"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+// CommentHandler handles the routes of comments on responses
+type CommentHandler struct {
+	CommentService port.CommentService
+}
+
+func NewCommentHandler(commentService port.CommentService) *CommentHandler {
+	return &CommentHandler{CommentService: commentService}
+}
+
+func (h *CommentHandler) RegisterRoutes(r *gin.RouterGroup) {
+	responseRoutes := r.Group("/responses/:response_id/comments")
+	responseRoutes.GET("", h.GetCommentsByResponseID) // query params: limit, offset
+	responseRoutes.POST("", h.CreateComment)
+
+	commentRoutes := r.Group("/comments")
+	commentRoutes.PUT("", h.EditComment)
+	commentRoutes.DELETE("/:comment_id", h.DeleteComment)
+}
+
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	rid, err := uuid.Parse(c.Param("response_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse response id", fmt.Errorf("%s: %w", "CommentHandler::CreateComment", err)))
+		return
+	}
+
+	var req dto.CreateCommentReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "CommentHandler::CreateComment", err)))
+		return
+	}
+
+	comment, err := h.CommentService.CreateComment(c.Request.Context(), userID, model.CreateCommentParams{
+		ResponseID: rid,
+		Body:       req.Body,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "CommentHandler::CreateComment", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreateCommentRes{Comment: comment})
+}
+
+func (h *CommentHandler) GetCommentsByResponseID(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	rid, err := uuid.Parse(c.Param("response_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse response id", fmt.Errorf("%s: %w", "CommentHandler::GetCommentsByResponseID", err)))
+		return
+	}
+
+	limit, err := validateLimitQueryParam(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "CommentHandler::GetCommentsByResponseID", err)))
+		return
+	}
+
+	offset, err := validateOffsetQueryParam(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "CommentHandler::GetCommentsByResponseID", err)))
+		return
+	}
+
+	comments, err := h.CommentService.GetCommentsByResponseID(c.Request.Context(), userID, rid, model.PageParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "CommentHandler::GetCommentsByResponseID", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetCommentsByResponseIDRes{Comments: comments})
+}
+
+func (h *CommentHandler) EditComment(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	var req dto.EditCommentReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "CommentHandler::EditComment", err)))
+		return
+	}
+
+	comment, err := h.CommentService.EditComment(c.Request.Context(), userID, model.EditCommentParams{
+		CommentID: req.CommentID,
+		Body:      req.Body,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "CommentHandler::EditComment", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.EditCommentRes{Comment: comment})
+}
+
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	cid, err := uuid.Parse(c.Param("comment_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse comment id", fmt.Errorf("%s: %w", "CommentHandler::DeleteComment", err)))
+		return
+	}
+
+	err = h.CommentService.DeleteComment(c.Request.Context(), userID, cid)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "CommentHandler::DeleteComment", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
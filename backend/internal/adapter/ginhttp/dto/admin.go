@@ -0,0 +1,15 @@
+package dto
+
+import "github.com/ksha23/CS407-FactSnap/internal/core/model"
+
+// GET RETENTION PREVIEW
+
+type GetRetentionPreviewRes struct {
+	Preview model.RetentionPreview `json:"preview"`
+}
+
+// GET RETENTION STATS
+
+type GetRetentionStatsRes struct {
+	Stats model.RetentionStats `json:"stats"`
+}
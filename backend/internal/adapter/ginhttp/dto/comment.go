@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/validate"
+)
+
+// CREATE COMMENT
+
+type CreateCommentReq struct {
+	Body string `json:"body" binding:"required"`
+}
+
+func (r *CreateCommentReq) Validate() error {
+	errsMap := make(ValidationErrs)
+
+	// validate body
+	if err := validate.Body(r.Body); err != nil {
+		errsMap["body"] = err
+	}
+
+	if len(errsMap) > 0 {
+		return errsMap
+	}
+	return nil
+}
+
+type CreateCommentRes struct {
+	Comment model.Comment `json:"comment"`
+}
+
+// EDIT COMMENT
+
+type EditCommentReq struct {
+	CommentID uuid.UUID `json:"comment_id" binding:"required"`
+	Body      string    `json:"body" binding:"required"`
+}
+
+func (r *EditCommentReq) Validate() error {
+	errsMap := make(ValidationErrs)
+
+	// validate body
+	if err := validate.Body(r.Body); err != nil {
+		errsMap["body"] = err
+	}
+
+	if len(errsMap) > 0 {
+		return errsMap
+	}
+	return nil
+}
+
+type EditCommentRes struct {
+	Comment model.Comment `json:"comment"`
+}
+
+// GET COMMENTS BY RESPONSE ID
+
+type GetCommentsByResponseIDRes struct {
+	Comments []model.Comment `json:"comments"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/ksha23/CS407-FactSnap/internal/core/model"
+
+// GET QUESTION POLICY
+
+// GetQuestionPolicyRes has its durations formatted like the duration accepted by the question routes, e.g. "1h30m0s"
+type GetQuestionPolicyRes struct {
+	Durations     []QuestionDurationLimitsRes `json:"durations"`
+	MaxExtension  string                      `json:"max_extension"`
+	MaxExtensions int                         `json:"max_extensions"`
+	MaxLifetime   string                      `json:"max_lifetime"`
+}
+
+type QuestionDurationLimitsRes struct {
+	Category model.Category `json:"category"`
+	Min      string         `json:"min"`
+	Max      string         `json:"max"`
+	Default  string         `json:"default"`
+}
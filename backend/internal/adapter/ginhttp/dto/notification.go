@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+)
+
+// GET NOTIFICATIONS
+
+type GetNotificationsRes struct {
+	Notifications []model.Notification `json:"notifications"`
+	UnreadCount   int                  `json:"unread_count"`
+}
+
+// MARK NOTIFICATIONS READ
+
+type MarkNotificationsReadReq struct {
+	// NotificationID is the notification to mark as read. All notifications are marked as read if it is omitted.
+	NotificationID *uuid.UUID `json:"notification_id" binding:"omitempty"`
+}
+
+func (r *MarkNotificationsReadReq) Validate() error {
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 	"github.com/ksha23/CS407-FactSnap/internal/obj"
+	"github.com/ksha23/CS407-FactSnap/internal/ptr"
 	"github.com/ksha23/CS407-FactSnap/internal/validate"
 	"time"
 )
@@ -17,10 +18,10 @@ type CreateQuestionReq struct {
 	Category  model.Category `json:"category" binding:"required"`
 	Location  model.Location `json:"location" binding:"required"`
 	ImageURLs []string       `json:"image_urls" binding:"omitempty"`
-	Duration  string         `json:"duration" binding:"required"`
+	Duration  *string        `json:"duration" binding:"omitempty"` // defaults to the category's default duration
 
 	// This is set post-validation here, not sent by frontend
-	ExpiresAt time.Time
+	ParsedDuration *time.Duration
 }
 
 func (r *CreateQuestionReq) Validate() error {
@@ -61,20 +62,15 @@ func (r *CreateQuestionReq) Validate() error {
 	}
 
 	// validate duration
-	// must be between 1-24 hours (inclusive)
-	duration, err := time.ParseDuration(r.Duration)
-	if err != nil {
-		errsMap["duration"] = fmt.Errorf("duration is in bad format")
-	} else {
-		if duration.Hours() < 0.25 || duration.Hours() > 1 {
-			errsMap["duration"] = fmt.Errorf("duration must be between 15 minutes and 1 hour")
-		} else {
-			expiresAt := time.Now().Add(duration)
-			if err := validate.ExpiresAt(expiresAt); err != nil {
-				errsMap["duration"] = err
-			}
-			r.ExpiresAt = expiresAt
+	// the per-category limits are enforced by the question policy
+	if r.Duration != nil {
+		duration, err := time.ParseDuration(*r.Duration)
+		if err != nil {
+			errsMap["duration"] = fmt.Errorf("duration is in bad format")
+		} else if duration <= 0 {
+			errsMap["duration"] = fmt.Errorf("duration must be positive")
 		}
+		r.ParsedDuration = &duration
 	}
 
 	if len(errsMap) > 0 {
@@ -95,6 +91,7 @@ type UpdateQuestionReq struct {
 	Body       *string        `json:"body" binding:"omitempty"`
 	Category   model.Category `json:"category" binding:"required"`
 	Location   model.Location `json:"location" binding:"required"`
+	ImageURLs  []string       `json:"image_urls" binding:"omitempty"` // omitted keeps the current images, empty removes them
 }
 
 func (r *UpdateQuestionReq) Validate() error {
@@ -128,6 +125,16 @@ func (r *UpdateQuestionReq) Validate() error {
 		errsMap["location"] = fmt.Errorf("location id cannot be empty")
 	}
 
+	// validate image urls
+	if len(r.ImageURLs) > 0 {
+		for _, url := range r.ImageURLs {
+			if err := validate.URL(url); err != nil {
+				errsMap["image_urls"] = err
+				break
+			}
+		}
+	}
+
 	if len(errsMap) > 0 {
 		return errsMap
 	}
@@ -138,11 +145,73 @@ type UpdateQuestionRes struct {
 	Question model.Question `json:"question"`
 }
 
+// RESTORE QUESTION
+
+type RestoreQuestionRes struct {
+	Question model.Question `json:"question"`
+}
+
+// GET QUESTION REVISIONS
+
+type GetQuestionRevisionsRes struct {
+	Revisions []model.QuestionRevision `json:"revisions"`
+}
+
+// EXTEND QUESTION
+
+type ExtendQuestionReq struct {
+	Duration string `json:"duration" binding:"required"`
+
+	// This is set post-validation here, not sent by frontend
+	ParsedDuration time.Duration
+}
+
+func (r *ExtendQuestionReq) Validate() error {
+	errsMap := make(ValidationErrs)
+
+	// validate duration
+	// the upper bound is enforced by the question policy
+	duration, err := time.ParseDuration(r.Duration)
+	if err != nil {
+		errsMap["duration"] = fmt.Errorf("duration is in bad format")
+	} else if duration <= 0 {
+		errsMap["duration"] = fmt.Errorf("duration must be positive")
+	}
+	r.ParsedDuration = duration
+
+	if len(errsMap) > 0 {
+		return errsMap
+	}
+	return nil
+}
+
+type ExtendQuestionRes struct {
+	Question model.Question `json:"question"`
+}
+
+// REOPEN QUESTION
+
+type ReopenQuestionReq = ExtendQuestionReq
+
+type ReopenQuestionRes struct {
+	Question model.Question `json:"question"`
+}
+
 // CREATE POLL
 
 type CreatePollReq struct {
-	QuestionID   uuid.UUID `json:"question_id" binding:"required"`
-	OptionLabels []string  `json:"option_labels" binding:"required"`
+	QuestionID   uuid.UUID      `json:"question_id" binding:"required"`
+	OptionLabels []string       `json:"option_labels" binding:"required"`
+	Type         model.PollType `json:"type" binding:"omitempty"`
+	// MaxSelections defaults to picking (MultiSelect) or ranking (RankedChoice) every option
+	MaxSelections *int `json:"max_selections" binding:"omitempty"`
+	AllowOther    bool `json:"allow_other" binding:"omitempty"`
+	// ResultsVisibility defaults to showing the results right away
+	ResultsVisibility model.PollResultsVisibility `json:"results_visibility" binding:"omitempty"`
+	// IsAnonymous defaults to true
+	IsAnonymous *bool `json:"is_anonymous" binding:"omitempty"`
+	// ClosesAt defaults to closing the poll together with its question
+	ClosesAt *time.Time `json:"closes_at" binding:"omitempty"`
 }
 
 func (r *CreatePollReq) Validate() error {
@@ -153,6 +222,43 @@ func (r *CreatePollReq) Validate() error {
 		errsMap["option_labels"] = err
 	}
 
+	// validate poll type
+	if r.Type == "" {
+		r.Type = model.PollTypeSingleChoice
+	}
+	pollType, err := model.ParsePollType(string(r.Type))
+	if err != nil {
+		errsMap["type"] = err
+	}
+	r.Type = pollType
+
+	// validate results visibility
+	if r.ResultsVisibility == "" {
+		r.ResultsVisibility = model.PollResultsVisibilityAlways
+	}
+	resultsVisibility, err := model.ParsePollResultsVisibility(string(r.ResultsVisibility))
+	if err != nil {
+		errsMap["results_visibility"] = err
+	}
+	r.ResultsVisibility = resultsVisibility
+
+	if r.IsAnonymous == nil {
+		r.IsAnonymous = ptr.To(true)
+	}
+
+	// a ranked "other" answer could not be compared with the ballots of other voters
+	if r.AllowOther && r.Type == model.PollTypeRankedChoice {
+		errsMap["allow_other"] = fmt.Errorf("%s polls cannot allow other answers", r.Type)
+	}
+
+	// validate max selections
+	maxSelections := model.MaxPollSelections(r.Type, len(r.OptionLabels), r.AllowOther)
+	if r.MaxSelections == nil {
+		r.MaxSelections = &maxSelections
+	} else if *r.MaxSelections < 1 || *r.MaxSelections > maxSelections {
+		errsMap["max_selections"] = fmt.Errorf("max selections %d must be between 1 and %d", *r.MaxSelections, maxSelections)
+	}
+
 	if len(errsMap) > 0 {
 		return errsMap
 	}
@@ -163,6 +269,145 @@ type CreatePollRes struct {
 	PollID uuid.UUID `json:"poll_id"`
 }
 
+// EDIT POLL
+
+type EditPollReq struct {
+	PollID uuid.UUID `json:"poll_id" binding:"required"`
+	// OptionLabels replace the options, which is only possible before the first vote
+	OptionLabels  []string   `json:"option_labels" binding:"omitempty"`
+	MaxSelections *int       `json:"max_selections" binding:"omitempty"`
+	ClosesAt      *time.Time `json:"closes_at" binding:"omitempty"`
+	// ClearClosesAt removes the closing time, an omitted closes_at keeps it
+	ClearClosesAt bool `json:"clear_closes_at" binding:"omitempty"`
+}
+
+func (r *EditPollReq) Validate() error {
+	errsMap := make(ValidationErrs)
+
+	if r.ClearClosesAt && r.ClosesAt != nil {
+		errsMap["closes_at"] = fmt.Errorf("closes_at cannot be set along with clear_closes_at")
+	}
+
+	// validate option labels
+	if r.OptionLabels != nil {
+		if err := validate.PollOptionLabels(r.OptionLabels); err != nil {
+			errsMap["option_labels"] = err
+		}
+	}
+
+	if len(errsMap) > 0 {
+		return errsMap
+	}
+	return nil
+}
+
+type EditPollRes struct {
+	Poll model.Poll `json:"poll"`
+}
+
+// CLOSE POLL
+
+type ClosePollRes struct {
+	Poll model.Poll `json:"poll"`
+}
+
+// CREATE RATING
+
+type CreateRatingReq struct {
+	QuestionID uuid.UUID `json:"question_id" binding:"required"`
+	// MinValue and MaxValue default to a scale of 1-5
+	MinValue *int    `json:"min_value" binding:"omitempty"`
+	MaxValue *int    `json:"max_value" binding:"omitempty"`
+	MinLabel *string `json:"min_label" binding:"omitempty"`
+	MaxLabel *string `json:"max_label" binding:"omitempty"`
+}
+
+func (r *CreateRatingReq) Validate() error {
+	errsMap := make(ValidationErrs)
+
+	if r.MinValue == nil {
+		r.MinValue = ptr.To(1)
+	}
+	if r.MaxValue == nil {
+		r.MaxValue = ptr.To(5)
+	}
+
+	// validate scale
+	if err := validate.RatingScale(*r.MinValue, *r.MaxValue); err != nil {
+		errsMap["max_value"] = err
+	}
+
+	// validate labels
+	if r.MinLabel != nil {
+		if err := validate.RatingLabel(*r.MinLabel); err != nil {
+			errsMap["min_label"] = err
+		}
+	}
+	if r.MaxLabel != nil {
+		if err := validate.RatingLabel(*r.MaxLabel); err != nil {
+			errsMap["max_label"] = err
+		}
+	}
+
+	if len(errsMap) > 0 {
+		return errsMap
+	}
+	return nil
+}
+
+type CreateRatingRes struct {
+	RatingID uuid.UUID `json:"rating_id"`
+}
+
+// ANSWER RATING
+
+type AnswerRatingReq struct {
+	RatingID uuid.UUID `json:"rating_id" binding:"required"`
+	// Value is null to remove the answer
+	Value *int `json:"value"`
+}
+
+func (r *AnswerRatingReq) Validate() error {
+	// the scale of the rating is checked by the service
+	return nil
+}
+
+type AnswerRatingRes struct {
+	Rating model.Rating `json:"rating"`
+}
+
+// CREATE CONFIRM
+
+type CreateConfirmReq struct {
+	QuestionID uuid.UUID `json:"question_id" binding:"required"`
+}
+
+func (r *CreateConfirmReq) Validate() error {
+	// binding already checks if the fields are passed or not
+	return nil
+}
+
+type CreateConfirmRes struct {
+	ConfirmID uuid.UUID `json:"confirm_id"`
+}
+
+// ANSWER CONFIRM
+
+type AnswerConfirmReq struct {
+	ConfirmID uuid.UUID `json:"confirm_id" binding:"required"`
+	// IsYes is null to remove the answer
+	IsYes *bool `json:"is_yes"`
+}
+
+func (r *AnswerConfirmReq) Validate() error {
+	// binding already checks if the fields are passed or not
+	return nil
+}
+
+type AnswerConfirmRes struct {
+	Confirm model.Confirm `json:"confirm"`
+}
+
 // GET QUESTION BY ID
 
 type GetQuestionByIDRes struct {
@@ -172,15 +417,43 @@ type GetQuestionByIDRes struct {
 // VOTE POLL
 
 type VotePollReq struct {
-	PollID   uuid.UUID  `json:"poll_id" binding:"required"`
+	PollID uuid.UUID `json:"poll_id" binding:"required"`
+	// OptionID picks a single option, it is kept for clients that only support single choice polls
 	OptionID *uuid.UUID `json:"option_id"`
+	// OptionIDs are in order of preference for ranked choice polls
+	OptionIDs []uuid.UUID `json:"option_ids"`
+	OtherText *string     `json:"other_text"`
 }
 
 func (r *VotePollReq) Validate() error {
-	// binding already checks if the fields are passed or not
+	errsMap := make(ValidationErrs)
+
+	if r.OptionID != nil {
+		if len(r.OptionIDs) > 0 {
+			errsMap["option_id"] = fmt.Errorf("option_id cannot be combined with option_ids")
+		}
+		r.OptionIDs = []uuid.UUID{*r.OptionID}
+	}
+
+	// validate other answer
+	if r.OtherText != nil {
+		if err := validate.PollOtherText(*r.OtherText); err != nil {
+			errsMap["other_text"] = err
+		}
+	}
+
+	if len(errsMap) > 0 {
+		return errsMap
+	}
 	return nil
 }
 
+// GET POLL OPTION VOTERS
+
+type GetPollOptionVotersRes struct {
+	Voters []model.PollVoter `json:"voters"`
+}
+
 // GET QUESTIONS IN RADIUS FEED
 
 type GetQuestionsInRadiusFeedReq struct {
@@ -284,3 +557,42 @@ type GetRespondedQuestionsRes struct {
 	Questions []model.Question `json:"questions"`
 }
 
+// GET QUESTIONS BY USER ID
+
+type GetUserQuestionsRes struct {
+	Questions []model.Question `json:"questions"`
+}
+
+// GET QUESTIONS FROM FOLLOWED USERS
+
+type GetFollowingQuestionsReq struct {
+	Limit  int `json:"limit" binding:"omitempty"`
+	Offset int `json:"offset" binding:"omitempty"`
+}
+
+func (r *GetFollowingQuestionsReq) Validate() error {
+	errsMap := make(ValidationErrs)
+
+	if err := validate.PageLimit(r.Limit); err != nil {
+		errsMap["limit"] = err
+	}
+	if err := validate.PageOffset(r.Offset); err != nil {
+		errsMap["offset"] = err
+	}
+
+	if len(errsMap) > 0 {
+		return errsMap
+	}
+	return nil
+}
+
+type GetFollowingQuestionsRes struct {
+	// Questions will not have the content data populated.
+	Questions []model.Question `json:"questions"`
+}
+
+// GET BOOKMARKED QUESTIONS
+
+type GetBookmarkedQuestionsRes struct {
+	Questions []model.Question `json:"questions"`
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/validate"
+)
+
+const (
+	RealtimeActionSubscribe   = "subscribe"
+	RealtimeActionUnsubscribe = "unsubscribe"
+
+	maxRealtimeRadiusMiles = 50
+)
+
+// RealtimeClientMessage is a message sent by the client over the realtime WebSocket.
+// Exactly one of QuestionID or Area must be set.
+type RealtimeClientMessage struct {
+	Action     string        `json:"action"`
+	QuestionID *uuid.UUID    `json:"question_id"`
+	Area       *RealtimeArea `json:"area"`
+}
+
+type RealtimeArea struct {
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	RadiusMiles float64 `json:"radius_miles"`
+}
+
+func (m *RealtimeClientMessage) Validate() error {
+	errsMap := make(ValidationErrs)
+
+	// validate action
+	if m.Action != RealtimeActionSubscribe && m.Action != RealtimeActionUnsubscribe {
+		errsMap["action"] = fmt.Errorf("action must be either %s or %s", RealtimeActionSubscribe, RealtimeActionUnsubscribe)
+	}
+
+	// validate target
+	if (m.QuestionID == nil) == (m.Area == nil) {
+		errsMap["question_id"] = errors.New("exactly one of question_id or area must be given")
+	}
+
+	// validate area
+	if m.Area != nil {
+		if err := validate.Location(m.Area.Latitude, m.Area.Longitude); err != nil {
+			errsMap["area"] = err
+		} else if m.Area.RadiusMiles <= 0 || m.Area.RadiusMiles > maxRealtimeRadiusMiles {
+			errsMap["area"] = fmt.Errorf("radius_miles must be greater than 0 and at most %d", maxRealtimeRadiusMiles)
+		}
+	}
+
+	if len(errsMap) > 0 {
+		return errsMap
+	}
+	return nil
+}
+
+// RealtimeErrorMessage is sent to the client if one of its messages could not be handled
+type RealtimeErrorMessage struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
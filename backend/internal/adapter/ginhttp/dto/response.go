@@ -53,6 +53,7 @@ type GetResponseByIDRes struct {
 type EditResponseReq struct {
 	ResponseID uuid.UUID `json:"response_id" binding:"required"`
 	Body       string    `json:"body" binding:"required"`
+	ImageURLs  []string  `json:"image_urls" binding:"omitempty"` // omitted keeps the current images, empty removes them
 }
 
 func (r *EditResponseReq) Validate() error {
@@ -63,6 +64,16 @@ func (r *EditResponseReq) Validate() error {
 		errsMap["body"] = err
 	}
 
+	// validate image urls
+	if len(r.ImageURLs) > 0 {
+		for _, url := range r.ImageURLs {
+			if err := validate.URL(url); err != nil {
+				errsMap["image_urls"] = err
+				break
+			}
+		}
+	}
+
 	if len(errsMap) > 0 {
 		return errsMap
 	}
@@ -73,12 +84,30 @@ type EditResponseRes struct {
 	Response model.Response `json:"response"`
 }
 
+// RESTORE RESPONSE
+
+type RestoreResponseRes struct {
+	Response model.Response `json:"response"`
+}
+
+// GET RESPONSE REVISIONS
+
+type GetResponseRevisionsRes struct {
+	Revisions []model.ResponseRevision `json:"revisions"`
+}
+
 // GET RESPONSES BY QUESTION ID
 
 type GetResponsesByQuestionIDRes struct {
 	Responses []model.Response `json:"responses"`
 }
 
+// GET RESPONSES BY USER ID
+
+type GetUserResponsesRes struct {
+	Responses []model.Response `json:"responses"`
+}
+
 // QUESTION SUMMARY
 
 type GetQuestionSummaryRes struct {
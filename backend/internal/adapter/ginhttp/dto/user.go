@@ -1,6 +1,11 @@
 package dto
 
-import "github.com/ksha23/CS407-FactSnap/internal/core/model"
+import (
+	"strings"
+
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/validate"
+)
 
 type SyncClerkUserRes struct {
 	AuthUser model.AuthUser `json:"auth_user"`
@@ -11,15 +16,71 @@ type GetAuthUserRes struct {
 }
 
 type GetUserStatisticsRes struct {
-	QuestionCount int `json:"question_count"`
-	ResponseCount int `json:"response_count"`
+	QuestionCount  int `json:"question_count"`
+	ResponseCount  int `json:"response_count"`
+	FollowerCount  int `json:"follower_count"`
+	FollowingCount int `json:"following_count"`
 }
 
 
+// UpdateProfileReq leaves omitted fields unchanged, an empty about_me or avatar_url clears it.
+// The avatar must first be uploaded through the media upload endpoint.
 type UpdateProfileReq struct {
-    DisplayName string `json:"display_name" binding:"required,min=2,max=50"`
+	DisplayName *string `json:"display_name" binding:"omitempty"`
+	Username    *string `json:"username" binding:"omitempty"`
+	AboutMe     *string `json:"about_me" binding:"omitempty"`
+	AvatarURL   *string `json:"avatar_url" binding:"omitempty"`
 }
 
 func (r *UpdateProfileReq) Validate() error {
-    return nil
-}
\ No newline at end of file
+	errsMap := make(ValidationErrs)
+
+	if r.DisplayName != nil {
+		if err := validate.DisplayName(strings.TrimSpace(*r.DisplayName)); err != nil {
+			errsMap["display_name"] = err
+		}
+	}
+
+	if r.Username != nil {
+		if err := validate.Username(strings.TrimSpace(*r.Username)); err != nil {
+			errsMap["username"] = err
+		}
+	}
+
+	if r.AboutMe != nil {
+		if err := validate.AboutMe(strings.TrimSpace(*r.AboutMe)); err != nil {
+			errsMap["about_me"] = err
+		}
+	}
+
+	if r.AvatarURL != nil && *r.AvatarURL != "" {
+		if err := validate.URL(*r.AvatarURL); err != nil {
+			errsMap["avatar_url"] = err
+		}
+	}
+
+	if len(errsMap) > 0 {
+		return errsMap
+	}
+	return nil
+}
+type GetBlockedUsersRes struct {
+	Users []model.User `json:"users"`
+}
+
+type GetMutedUsersRes struct {
+	Users []model.User `json:"users"`
+}
+
+type GetUserProfileRes struct {
+	User           model.User `json:"user"`
+	QuestionCount  int        `json:"question_count"`
+	ResponseCount  int        `json:"response_count"`
+	FollowerCount  int        `json:"follower_count"`
+	FollowingCount int        `json:"following_count"`
+}
+
+// GetDataExportRes has a download url once the export is ready
+type GetDataExportRes struct {
+	Export model.DataExport `json:"export"`
+}
@@ -0,0 +1,44 @@
+package ginhttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/ginhttp/dto"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+// MetaHandler handles the routes that describe server-side rules, so the app can render valid choices
+type MetaHandler struct {
+	QuestionService port.QuestionService
+}
+
+func NewMetaHandler(questionService port.QuestionService) *MetaHandler {
+	return &MetaHandler{QuestionService: questionService}
+}
+
+func (h *MetaHandler) RegisterRoutes(r *gin.RouterGroup) {
+	metaRoutes := r.Group("/meta")
+	metaRoutes.GET("/question-policy", h.GetQuestionPolicy)
+}
+
+func (h *MetaHandler) GetQuestionPolicy(c *gin.Context) {
+	policy := h.QuestionService.GetQuestionPolicy(c.Request.Context())
+
+	durations := make([]dto.QuestionDurationLimitsRes, len(policy.Durations))
+	for i, limits := range policy.Durations {
+		durations[i] = dto.QuestionDurationLimitsRes{
+			Category: limits.Category,
+			Min:      limits.Min.String(),
+			Max:      limits.Max.String(),
+			Default:  limits.Default.String(),
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.GetQuestionPolicyRes{
+		Durations:     durations,
+		MaxExtension:  policy.MaxExtension.String(),
+		MaxExtensions: policy.MaxExtensions,
+		MaxLifetime:   policy.MaxLifetime.String(),
+	})
+}
@@ -10,10 +10,12 @@ import (
 	"github.com/ksha23/CS407-FactSnap/internal/core/port"
 )
 
-func ClerkAuth(authService port.AuthService) gin.HandlerFunc {
+// ClerkAuth authenticates the request with the Clerk token of the Authorization header.
+// Only the query token routes (full paths) also accept the token as a "token" query param.
+func ClerkAuth(authService port.AuthService, queryTokenRoutes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// check if bearer header exists or not
-		token, err := getBearerToken(c)
+		token, err := getBearerToken(c, isRoute(c, queryTokenRoutes))
 		if err != nil {
 			c.Error(ginhttp.Unauthenticated(c, "Invalid Authorization header", fmt.Errorf("ClerkAuth: %w", err)))
 			c.Abort()
@@ -37,9 +39,13 @@ func ClerkAuth(authService port.AuthService) gin.HandlerFunc {
 	}
 }
 
-func getBearerToken(c *gin.Context) (string, error) {
+func getBearerToken(c *gin.Context, allowQueryToken bool) (string, error) {
 	authHeader := c.GetHeader("Authorization")
 	if authHeader == "" {
+		// WebSocket clients (e.g. browsers) cannot set headers on the upgrade request, so they pass the token as a query param
+		if token := c.Query("token"); token != "" && allowQueryToken {
+			return token, nil
+		}
 		return "", fmt.Errorf("missing Authorization header")
 	}
 
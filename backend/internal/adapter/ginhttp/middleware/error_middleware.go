@@ -28,7 +28,7 @@ func Error() gin.HandlerFunc {
 			slog.Any("msg", apiError.Message),
 		}
 
-		attrs := append([]slog.Attr{
+		attrs := []slog.Attr{
 			{
 				Key:   "internal",
 				Value: slog.GroupValue(internalErrAttrs...),
@@ -37,7 +37,7 @@ func Error() gin.HandlerFunc {
 				Key:   "external",
 				Value: slog.GroupValue(externalErrAttrs...),
 			},
-		})
+		}
 
 		slog.LogAttrs(c.Request.Context(), slog.LevelError, "an error has occurred", attrs...)
 
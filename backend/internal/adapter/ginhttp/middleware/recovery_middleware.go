@@ -8,14 +8,14 @@ import (
 
 func Recovery() gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, err any) {
-		attrs := append([]slog.Attr{
+		attrs := []slog.Attr{
 			{
 				Key: "internal",
 				Value: slog.GroupValue([]slog.Attr{
 					slog.Any("msg", err),
 				}...),
 			},
-		})
+		}
 		slog.LogAttrs(c.Request.Context(), slog.LevelError, "a panic has occurred", attrs...)
 
 		apiError := ginhttp.InternalServerError(c, "", nil)
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/ginhttp"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+// RequireRole only lets users with the given role through. It must run after ClerkAuth.
+func RequireRole(authService port.AuthService, role model.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Request.Context().Value(ginhttp.RequestUserIDKey).(string)
+
+		authUser, err := authService.GetAuthUser(c.Request.Context(), userID)
+		if err != nil {
+			ginhttp.HandleErr(c, fmt.Errorf("RequireRole: %w", err))
+			c.Abort()
+			return
+		}
+
+		if authUser.Role != role {
+			err := fmt.Errorf("user id %s has role %s, but %s is required", userID, authUser.Role, role)
+			c.Error(ginhttp.Unauthorized(c, "", fmt.Errorf("RequireRole: %w", err)))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
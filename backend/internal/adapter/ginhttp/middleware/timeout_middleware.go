@@ -12,10 +12,11 @@ import (
 // Timeout is a middleware that sets the request timeout duration through context.WithTimeout.
 // If the request timed out, then it will write the timed out error response (if not written already).
 // NOTE: If the given duration is 0, then it will not set the request timeout.
-func Timeout(timeoutDuration time.Duration) gin.HandlerFunc {
+// The exempt routes (full paths, e.g. WebSocket and Server-Sent Events endpoints) are long-lived, so they are never timed out.
+func Timeout(timeoutDuration time.Duration, exemptRoutes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// only set timeout if the duration is not 0
-		if timeoutDuration > 0 {
+		if timeoutDuration > 0 && !isRoute(c, exemptRoutes) {
 			timeoutCtx, cancel := context.WithTimeoutCause(c.Request.Context(), timeoutDuration, http.ErrHandlerTimeout)
 			defer func() {
 				cancel()
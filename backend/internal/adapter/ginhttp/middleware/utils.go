@@ -0,0 +1,13 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// isRoute returns true if the route matched by the request is one of the routes.
+// Unlike headers, the matched route cannot be chosen by the client for another endpoint.
+func isRoute(c *gin.Context, routes []string) bool {
+	return slices.Contains(routes, c.FullPath())
+}
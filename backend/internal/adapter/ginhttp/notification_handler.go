@@ -0,0 +1,101 @@
+package ginhttp
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/ginhttp/dto"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+// NotificationHandler handles the routes of the in-app notification inbox
+type NotificationHandler struct {
+	InboxService port.InboxService
+}
+
+func NewNotificationHandler(inboxService port.InboxService) *NotificationHandler {
+	return &NotificationHandler{InboxService: inboxService}
+}
+
+func (h *NotificationHandler) RegisterRoutes(r *gin.RouterGroup) {
+	notificationRoutes := r.Group("/notifications")
+	notificationRoutes.GET("", h.GetNotifications) // query params: limit, offset
+	notificationRoutes.POST("/read", h.MarkNotificationsRead)
+	notificationRoutes.DELETE("/:notification_id", h.DeleteNotification)
+}
+
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	limit, err := validateLimitQueryParam(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "NotificationHandler::GetNotifications", err)))
+		return
+	}
+
+	offset, err := validateOffsetQueryParam(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "NotificationHandler::GetNotifications", err)))
+		return
+	}
+
+	page := model.PageParams{
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	notifications, unreadCount, err := h.InboxService.GetNotifications(c.Request.Context(), userID, page)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "NotificationHandler::GetNotifications", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetNotificationsRes{
+		Notifications: notifications,
+		UnreadCount:   unreadCount,
+	})
+}
+
+func (h *NotificationHandler) MarkNotificationsRead(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	var req dto.MarkNotificationsReadReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "NotificationHandler::MarkNotificationsRead", err)))
+		return
+	}
+
+	var err error
+	if req.NotificationID != nil {
+		err = h.InboxService.MarkNotificationRead(c.Request.Context(), userID, *req.NotificationID)
+	} else {
+		err = h.InboxService.MarkAllNotificationsRead(c.Request.Context(), userID)
+	}
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "NotificationHandler::MarkNotificationsRead", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	notificationID, err := uuid.Parse(c.Param("notification_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse notification id", fmt.Errorf("%s: %w", "NotificationHandler::DeleteNotification", err)))
+		return
+	}
+
+	err = h.InboxService.DeleteNotification(c.Request.Context(), userID, notificationID)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "NotificationHandler::DeleteNotification", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/ksha23/CS407-FactSnap/internal/adapter/ginhttp/dto"
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+	"log/slog"
 	"net/http"
+	"strconv"
 
 	"bytes"
     "encoding/json"
@@ -18,10 +20,14 @@ import (
 
 type QuestionHandler struct {
 	QuestionService port.QuestionService
+	EventBroker     port.EventBroker
 }
 
-func NewQuestionHandler(questionService port.QuestionService) *QuestionHandler {
-	return &QuestionHandler{QuestionService: questionService}
+func NewQuestionHandler(questionService port.QuestionService, eventBroker port.EventBroker) *QuestionHandler {
+	return &QuestionHandler{
+		QuestionService: questionService,
+		EventBroker:     eventBroker,
+	}
 }
 
 func (h *QuestionHandler) RegisterRoutes(r *gin.RouterGroup) {
@@ -31,13 +37,34 @@ func (h *QuestionHandler) RegisterRoutes(r *gin.RouterGroup) {
 	questionRoutes.POST("/feed", h.GetQuestionsInRadiusFeed)
 	questionRoutes.PUT("", h.UpdateQuestion)
 	questionRoutes.DELETE("/:question_id", h.DeleteQuestion)
+	questionRoutes.POST("/:question_id/restore", h.RestoreQuestion)
+	questionRoutes.GET("/:question_id/revisions", h.GetQuestionRevisions) // query params: limit, offset
+	questionRoutes.POST("/:question_id/extend", h.ExtendQuestion)
+	questionRoutes.POST("/:question_id/reopen", h.ReopenQuestion)
 	questionRoutes.POST("/:question_id/summary", h.GenerateSummaryTest)
 	questionRoutes.POST("/mine", h.GetMyQuestions)
 	questionRoutes.POST("/responded", h.GetRespondedQuestions)
+	questionRoutes.POST("/following", h.GetFollowingQuestions)
+	questionRoutes.GET("/users/:user_id", h.GetUserQuestions) // query params: limit, offset
+	questionRoutes.GET("/bookmarked", h.GetBookmarkedQuestions) // query params: limit, offset
+	questionRoutes.POST("/:question_id/bookmark", h.BookmarkQuestion) // query params: notify
+	questionRoutes.DELETE("/:question_id/bookmark", h.UnbookmarkQuestion)
 
 	pollRoutes := questionRoutes.Group("/poll")
 	pollRoutes.POST("", h.CreatePoll)
+	pollRoutes.PUT("", h.EditPoll)
 	pollRoutes.POST("/vote", h.VotePoll)
+	pollRoutes.POST("/:poll_id/close", h.ClosePoll)
+	r.GET(PollTallyStreamRoute, h.StreamPollTally) // Server-Sent Events
+	pollRoutes.GET("/:poll_id/options/:option_id/voters", h.GetPollOptionVoters)
+
+	ratingRoutes := questionRoutes.Group("/rating")
+	ratingRoutes.POST("", h.CreateRating)
+	ratingRoutes.POST("/answer", h.AnswerRating)
+
+	confirmRoutes := questionRoutes.Group("/confirm")
+	confirmRoutes.POST("", h.CreateConfirm)
+	confirmRoutes.POST("/answer", h.AnswerConfirm)
 }
 
 func (h *QuestionHandler) CreateQuestion(c *gin.Context) {
@@ -55,7 +82,7 @@ func (h *QuestionHandler) CreateQuestion(c *gin.Context) {
 		Category:  req.Category,
 		Location:  req.Location,
 		ImageURLs: req.ImageURLs,
-		ExpiresAt: req.ExpiresAt,
+		Duration:  req.ParsedDuration,
 	})
 	if err != nil {
 		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::CreateQuestion", err))
@@ -75,8 +102,14 @@ func (h *QuestionHandler) CreatePoll(c *gin.Context) {
 	}
 
 	pollID, err := h.QuestionService.CreatePoll(c.Request.Context(), userID, model.CreatePollParams{
-		QuestionID:   req.QuestionID,
-		OptionLabels: req.OptionLabels,
+		QuestionID:        req.QuestionID,
+		OptionLabels:      req.OptionLabels,
+		Type:              req.Type,
+		MaxSelections:     *req.MaxSelections,
+		AllowOther:        req.AllowOther,
+		ResultsVisibility: req.ResultsVisibility,
+		IsAnonymous:       *req.IsAnonymous,
+		ClosesAt:          req.ClosesAt,
 	})
 	if err != nil {
 		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::CreatePoll", err))
@@ -95,7 +128,11 @@ func (h *QuestionHandler) VotePoll(c *gin.Context) {
 		return
 	}
 
-	err := h.QuestionService.VotePoll(c.Request.Context(), userID, req.PollID, req.OptionID)
+	err := h.QuestionService.VotePoll(c.Request.Context(), userID, model.VotePollParams{
+		PollID:    req.PollID,
+		OptionIDs: req.OptionIDs,
+		OtherText: req.OtherText,
+	})
 	if err != nil {
 		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::VotePoll", err))
 		return
@@ -104,6 +141,291 @@ func (h *QuestionHandler) VotePoll(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+func (h *QuestionHandler) EditPoll(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	var req dto.EditPollReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "QuestionHandler::EditPoll", err)))
+		return
+	}
+
+	poll, err := h.QuestionService.EditPoll(c.Request.Context(), userID, model.EditPollParams{
+		PollID:        req.PollID,
+		OptionLabels:  req.OptionLabels,
+		MaxSelections: req.MaxSelections,
+		ClosesAt:      req.ClosesAt,
+		ClearClosesAt: req.ClearClosesAt,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::EditPoll", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.EditPollRes{Poll: poll})
+}
+
+func (h *QuestionHandler) ClosePoll(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	pollID, err := uuid.Parse(c.Param("poll_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse poll id", fmt.Errorf("%s: %w", "QuestionHandler::ClosePoll", err)))
+		return
+	}
+
+	poll, err := h.QuestionService.ClosePoll(c.Request.Context(), userID, pollID)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::ClosePoll", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ClosePollRes{Poll: poll})
+}
+
+func (h *QuestionHandler) GetPollOptionVoters(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	pollID, err := uuid.Parse(c.Param("poll_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse poll id", fmt.Errorf("%s: %w", "QuestionHandler::GetPollOptionVoters", err)))
+		return
+	}
+
+	optionID, err := uuid.Parse(c.Param("option_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse option id", fmt.Errorf("%s: %w", "QuestionHandler::GetPollOptionVoters", err)))
+		return
+	}
+
+	limit, err := validateLimitQueryParam(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "QuestionHandler::GetPollOptionVoters", err)))
+		return
+	}
+
+	offset, err := validateOffsetQueryParam(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "QuestionHandler::GetPollOptionVoters", err)))
+		return
+	}
+
+	voters, err := h.QuestionService.GetPollOptionVoters(c.Request.Context(), userID, pollID, optionID, model.PageParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::GetPollOptionVoters", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetPollOptionVotersRes{Voters: voters})
+}
+
+func (h *QuestionHandler) CreateRating(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	var req dto.CreateRatingReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "QuestionHandler::CreateRating", err)))
+		return
+	}
+
+	ratingID, err := h.QuestionService.CreateRating(c.Request.Context(), userID, model.CreateRatingParams{
+		QuestionID: req.QuestionID,
+		MinValue:   *req.MinValue,
+		MaxValue:   *req.MaxValue,
+		MinLabel:   req.MinLabel,
+		MaxLabel:   req.MaxLabel,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::CreateRating", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreateRatingRes{RatingID: ratingID})
+}
+
+func (h *QuestionHandler) AnswerRating(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	var req dto.AnswerRatingReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "QuestionHandler::AnswerRating", err)))
+		return
+	}
+
+	rating, err := h.QuestionService.AnswerRating(c.Request.Context(), userID, model.AnswerRatingParams{
+		RatingID: req.RatingID,
+		Value:    req.Value,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::AnswerRating", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AnswerRatingRes{Rating: rating})
+}
+
+func (h *QuestionHandler) CreateConfirm(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	var req dto.CreateConfirmReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "QuestionHandler::CreateConfirm", err)))
+		return
+	}
+
+	confirmID, err := h.QuestionService.CreateConfirm(c.Request.Context(), userID, model.CreateConfirmParams{
+		QuestionID: req.QuestionID,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::CreateConfirm", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreateConfirmRes{ConfirmID: confirmID})
+}
+
+func (h *QuestionHandler) AnswerConfirm(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	var req dto.AnswerConfirmReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "QuestionHandler::AnswerConfirm", err)))
+		return
+	}
+
+	confirm, err := h.QuestionService.AnswerConfirm(c.Request.Context(), userID, model.AnswerConfirmParams{
+		ConfirmID: req.ConfirmID,
+		IsYes:     req.IsYes,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::AnswerConfirm", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AnswerConfirmRes{Confirm: confirm})
+}
+
+// PollTallyStreamRoute is the route of the poll tally stream, relative to the base url. The stream is long-lived.
+const PollTallyStreamRoute = "/questions/poll/:poll_id/stream"
+
+const (
+	// a burst of votes within this interval is sent as a single update
+	pollTallyThrottleInterval = time.Second
+	sseKeepAliveInterval      = 15 * time.Second
+)
+
+// StreamPollTally streams the vote counts of the poll as Server-Sent Events. It sends a "tally" event with the
+// current counts right away and then whenever votes change, and an "expired" event once the poll can no longer change.
+func (h *QuestionHandler) StreamPollTally(c *gin.Context) {
+	userID := getAuthUserID(c)
+	ctx := c.Request.Context()
+
+	pollID, err := uuid.Parse(c.Param("poll_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse poll id", fmt.Errorf("%s: %w", "QuestionHandler::StreamPollTally", err)))
+		return
+	}
+
+	// subscribe before taking the snapshot, so that no vote in between is missed
+	events, unsubscribe := h.EventBroker.Subscribe()
+	defer unsubscribe()
+
+	poll, err := h.QuestionService.GetPollTally(ctx, userID, pollID)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::StreamPollTally", err))
+		return
+	}
+
+	// the stream outlives the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		HandleErr(c, fmt.Errorf("%s: could not clear write deadline: %w", "QuestionHandler::StreamPollTally", err))
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	sendTally := func(poll model.Poll) {
+		c.SSEvent("tally", model.PollTallyEventData{
+			PollID:        poll.ID,
+			Options:       poll.Options,
+			OtherVotes:    poll.OtherVotes,
+			NumTotalVotes: poll.NumTotalVotes,
+			NumVoters:     poll.NumVoters,
+			Runoff:        poll.Runoff,
+			ResultsHidden: poll.ResultsHidden,
+		})
+		c.Writer.Flush()
+	}
+	sendTally(poll)
+
+	throttle := time.NewTicker(pollTallyThrottleInterval)
+	defer throttle.Stop()
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+	expired := time.NewTimer(time.Until(poll.ExpiredAt))
+	defer expired.Stop()
+
+	changed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			if event.QuestionID != poll.QuestionID {
+				continue
+			}
+			switch event.Type {
+			case model.EventTypePollTallyUpdated:
+				changed = true
+			case model.EventTypePollClosed:
+				// closed early by its owner, which the expiry timer doesn't know about
+				expired.Reset(0)
+			}
+		case <-throttle.C:
+			if !changed {
+				continue
+			}
+			changed = false
+
+			// re-fetch instead of relaying the event, so the counts include the user's own selection
+			poll, err = h.QuestionService.GetPollTally(ctx, userID, pollID)
+			if err != nil {
+				// headers are already written, so the stream can only be ended
+				slog.ErrorContext(ctx, "QuestionHandler::StreamPollTally: could not fetch poll tally", "error", err, "poll_id", pollID)
+				return
+			}
+			sendTally(poll)
+			// the question may have been extended or reopened meanwhile
+			expired.Reset(time.Until(poll.ExpiredAt))
+		case <-keepAlive.C:
+			if _, err := c.Writer.WriteString(": keepalive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-expired.C:
+			// catch any votes placed right before expiry
+			if poll, err = h.QuestionService.GetPollTally(ctx, userID, pollID); err == nil {
+				sendTally(poll)
+				// the question was extended or reopened since the timer was set
+				if !poll.IsClosed() {
+					expired.Reset(time.Until(poll.ExpiredAt))
+					continue
+				}
+			}
+			c.SSEvent("expired", gin.H{"poll_id": pollID})
+			c.Writer.Flush()
+			return
+		}
+	}
+}
+
 func (h *QuestionHandler) GetQuestionByID(c *gin.Context) {
 	userID := getAuthUserID(c)
 
@@ -137,6 +459,7 @@ func (h *QuestionHandler) UpdateQuestion(c *gin.Context) {
 		Body:       req.Body,
 		Category:   req.Category,
 		Location:   req.Location,
+		ImageURLs:  req.ImageURLs,
 	})
 	if err != nil {
 		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::UpdateQuestion", err))
@@ -146,6 +469,54 @@ func (h *QuestionHandler) UpdateQuestion(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.UpdateQuestionRes{Question: updatedQuestion})
 }
 
+func (h *QuestionHandler) ExtendQuestion(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	questionID, err := uuid.Parse(c.Param("question_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse question id", fmt.Errorf("QuestionHandler::ExtendQuestion: %w", err)))
+		return
+	}
+
+	var req dto.ExtendQuestionReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "QuestionHandler::ExtendQuestion", err)))
+		return
+	}
+
+	question, err := h.QuestionService.ExtendQuestion(c.Request.Context(), userID, questionID, req.ParsedDuration)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::ExtendQuestion", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ExtendQuestionRes{Question: question})
+}
+
+func (h *QuestionHandler) ReopenQuestion(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	questionID, err := uuid.Parse(c.Param("question_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse question id", fmt.Errorf("QuestionHandler::ReopenQuestion: %w", err)))
+		return
+	}
+
+	var req dto.ReopenQuestionReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "QuestionHandler::ReopenQuestion", err)))
+		return
+	}
+
+	question, err := h.QuestionService.ReopenQuestion(c.Request.Context(), userID, questionID, req.ParsedDuration)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::ReopenQuestion", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ReopenQuestionRes{Question: question})
+}
+
 func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
 	userID := getAuthUserID(c)
 
@@ -163,6 +534,57 @@ func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+func (h *QuestionHandler) RestoreQuestion(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	questionID, err := uuid.Parse(c.Param("question_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse question id", fmt.Errorf("QuestionHandler::RestoreQuestion: %w", err)))
+		return
+	}
+
+	question, err := h.QuestionService.RestoreQuestion(c.Request.Context(), userID, questionID)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::RestoreQuestion", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RestoreQuestionRes{Question: question})
+}
+
+func (h *QuestionHandler) GetQuestionRevisions(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	questionID, err := uuid.Parse(c.Param("question_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse question id", fmt.Errorf("%s: %w", "QuestionHandler::GetQuestionRevisions", err)))
+		return
+	}
+
+	limit, err := validateLimitQueryParam(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "QuestionHandler::GetQuestionRevisions", err)))
+		return
+	}
+
+	offset, err := validateOffsetQueryParam(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "QuestionHandler::GetQuestionRevisions", err)))
+		return
+	}
+
+	revisions, err := h.QuestionService.GetQuestionRevisions(c.Request.Context(), userID, questionID, model.PageParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::GetQuestionRevisions", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetQuestionRevisionsRes{Revisions: revisions})
+}
+
 func (h *QuestionHandler) GetQuestionsInRadiusFeed(c *gin.Context) {
 	userID := getAuthUserID(c)
 
@@ -424,4 +846,122 @@ func (h *QuestionHandler) GetRespondedQuestions(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.GetRespondedQuestionsRes{
 		Questions: questions,
 	})
-}
\ No newline at end of file
+}
+
+func (h *QuestionHandler) GetFollowingQuestions(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	var req dto.GetFollowingQuestionsReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "QuestionHandler::GetFollowingQuestions", err)))
+		return
+	}
+
+	questions, err := h.QuestionService.GetQuestionsFromFollowedUsers(c.Request.Context(), userID, model.PageParams{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::GetFollowingQuestions", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetFollowingQuestionsRes{Questions: questions})
+}
+
+// BookmarkQuestion saves the question for the user.
+// If the "notify" query param is true, the user is pushed the new responses and the expiry of the question.
+func (h *QuestionHandler) BookmarkQuestion(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	questionID, err := uuid.Parse(c.Param("question_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse question id", fmt.Errorf("%s: %w", "QuestionHandler::BookmarkQuestion", err)))
+		return
+	}
+
+	notify, err := strconv.ParseBool(c.DefaultQuery("notify", "false"))
+	if err != nil {
+		c.Error(BadRequest(c, "notify must be a boolean", fmt.Errorf("%s: %w", "QuestionHandler::BookmarkQuestion", err)))
+		return
+	}
+
+	if err := h.QuestionService.BookmarkQuestion(c.Request.Context(), userID, questionID, notify); err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::BookmarkQuestion", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *QuestionHandler) UnbookmarkQuestion(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	questionID, err := uuid.Parse(c.Param("question_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse question id", fmt.Errorf("%s: %w", "QuestionHandler::UnbookmarkQuestion", err)))
+		return
+	}
+
+	if err := h.QuestionService.UnbookmarkQuestion(c.Request.Context(), userID, questionID); err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::UnbookmarkQuestion", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *QuestionHandler) GetBookmarkedQuestions(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	limit, err := validateLimitQueryParam(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "QuestionHandler::GetBookmarkedQuestions", err)))
+		return
+	}
+
+	offset, err := validateOffsetQueryParam(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "QuestionHandler::GetBookmarkedQuestions", err)))
+		return
+	}
+
+	questions, err := h.QuestionService.GetBookmarkedQuestions(c.Request.Context(), userID, model.PageParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::GetBookmarkedQuestions", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetBookmarkedQuestionsRes{Questions: questions})
+}
+
+func (h *QuestionHandler) GetUserQuestions(c *gin.Context) {
+	userID := getAuthUserID(c)
+	authorID := c.Param("user_id")
+
+	limit, err := validateLimitQueryParam(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "QuestionHandler::GetUserQuestions", err)))
+		return
+	}
+
+	offset, err := validateOffsetQueryParam(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "QuestionHandler::GetUserQuestions", err)))
+		return
+	}
+
+	questions, err := h.QuestionService.GetQuestionsByAuthorID(c.Request.Context(), userID, authorID, model.PageParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "QuestionHandler::GetUserQuestions", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetUserQuestionsRes{Questions: questions})
+}
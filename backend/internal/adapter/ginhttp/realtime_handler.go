@@ -0,0 +1,253 @@
+package ginhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/ginhttp/dto"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+	"github.com/ksha23/CS407-FactSnap/internal/errs"
+)
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsPingPeriod     = (wsPongWait * 9) / 10
+	wsMaxMessageSize = 4096
+
+	maxRealtimeSubscriptions = 50
+	metersPerMile            = 1609.344
+)
+
+// WebSocketRoute is the route of the WebSocket gateway, relative to the base url.
+// The connection is long-lived, and clients that cannot set headers on the upgrade request pass their token as a query param.
+const WebSocketRoute = "/realtime/ws"
+
+var (
+	errTooManySubscriptions     = fmt.Errorf("at most %d subscriptions are allowed per connection", maxRealtimeSubscriptions)
+	errRealtimeQuestionNotFound = errors.New("question not found")
+	errRealtimeSubscribeFailed  = errors.New("could not subscribe to question")
+)
+
+// RealtimeHandler handles the realtime WebSocket gateway
+type RealtimeHandler struct {
+	QuestionService port.QuestionService
+	EventBroker     port.EventBroker
+	upgrader        websocket.Upgrader
+}
+
+func NewRealtimeHandler(questionService port.QuestionService, eventBroker port.EventBroker) *RealtimeHandler {
+	return &RealtimeHandler{
+		QuestionService: questionService,
+		EventBroker:     eventBroker,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// clients are authenticated through their Clerk token, and the mobile app does not send an Origin header
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+func (h *RealtimeHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET(WebSocketRoute, h.Connect)
+}
+
+// Connect upgrades the request to a WebSocket connection. Once connected, the client sends dto.RealtimeClientMessage
+// messages to (un)subscribe to questions or areas, and receives every model.Event matching its subscriptions.
+func (h *RealtimeHandler) Connect(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the upgrader already wrote the error response
+		slog.WarnContext(c.Request.Context(), "RealtimeHandler::Connect: could not upgrade connection", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	events, unsubscribe := h.EventBroker.Subscribe()
+	defer unsubscribe()
+
+	client := &realtimeClient{
+		conn:            conn,
+		userID:          userID,
+		questionService: h.QuestionService,
+		questionIDs:     make(map[uuid.UUID]struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	slog.DebugContext(ctx, "Realtime client connected", "user_id", userID)
+	go func() {
+		defer cancel()
+		client.readLoop(ctx)
+	}()
+	client.writeLoop(ctx, events)
+	slog.DebugContext(ctx, "Realtime client disconnected", "user_id", userID)
+}
+
+type realtimeClient struct {
+	conn            *websocket.Conn
+	userID          string
+	questionService port.QuestionService
+	// writeMu serializes writes, as gorilla/websocket only supports one concurrent writer
+	writeMu sync.Mutex
+
+	mu          sync.RWMutex
+	questionIDs map[uuid.UUID]struct{}
+	areas       []dto.RealtimeArea
+}
+
+// readLoop handles the client's messages until the connection is closed
+func (rc *realtimeClient) readLoop(ctx context.Context) {
+	rc.conn.SetReadLimit(wsMaxMessageSize)
+	_ = rc.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	rc.conn.SetPongHandler(func(string) error {
+		return rc.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		var msg dto.RealtimeClientMessage
+		if err := rc.conn.ReadJSON(&msg); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				rc.writeError("invalid message")
+				continue
+			}
+			// the client went away, or the connection is otherwise unusable
+			return
+		}
+
+		if err := msg.Validate(); err != nil {
+			rc.writeError(err.Error())
+			continue
+		}
+		if msg.Action == dto.RealtimeActionSubscribe && msg.QuestionID != nil {
+			if err := rc.canViewQuestion(ctx, *msg.QuestionID); err != nil {
+				rc.writeError(err.Error())
+				continue
+			}
+		}
+		if err := rc.handleMessage(msg); err != nil {
+			rc.writeError(err.Error())
+		}
+	}
+}
+
+// canViewQuestion checks that the question exists and that its author did not block the user
+func (rc *realtimeClient) canViewQuestion(ctx context.Context, questionID uuid.UUID) error {
+	if _, err := rc.questionService.GetQuestionByID(ctx, rc.userID, questionID); err != nil {
+		if errs.ErrType(err) == errs.TypeNotFound {
+			return errRealtimeQuestionNotFound
+		}
+		slog.ErrorContext(ctx, "RealtimeHandler::canViewQuestion: could not fetch question", "error", err, "question_id", questionID)
+		return errRealtimeSubscribeFailed
+	}
+	return nil
+}
+
+func (rc *realtimeClient) handleMessage(msg dto.RealtimeClientMessage) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	switch msg.Action {
+	case dto.RealtimeActionSubscribe:
+		if len(rc.questionIDs)+len(rc.areas) >= maxRealtimeSubscriptions {
+			return errTooManySubscriptions
+		}
+		if msg.QuestionID != nil {
+			rc.questionIDs[*msg.QuestionID] = struct{}{}
+		} else {
+			rc.areas = append(rc.areas, *msg.Area)
+		}
+	case dto.RealtimeActionUnsubscribe:
+		if msg.QuestionID != nil {
+			delete(rc.questionIDs, *msg.QuestionID)
+		} else {
+			areas := rc.areas[:0]
+			for _, area := range rc.areas {
+				if area != *msg.Area {
+					areas = append(areas, area)
+				}
+			}
+			rc.areas = areas
+		}
+	}
+
+	return nil
+}
+
+// writeLoop forwards the matching events to the client and keeps the connection alive until the context is canceled
+func (rc *realtimeClient) writeLoop(ctx context.Context, events <-chan model.Event) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			_ = rc.write(func() error {
+				return rc.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			})
+			return
+		case event := <-events:
+			if !rc.isSubscribed(event) {
+				continue
+			}
+			if err := rc.write(func() error { return rc.conn.WriteJSON(event) }); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := rc.write(func() error { return rc.conn.WriteMessage(websocket.PingMessage, nil) }); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (rc *realtimeClient) isSubscribed(event model.Event) bool {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	if _, ok := rc.questionIDs[event.QuestionID]; ok {
+		return true
+	}
+
+	if event.Location == nil {
+		return false
+	}
+	for _, area := range rc.areas {
+		center := model.GeoPoint{Latitude: area.Latitude, Longitude: area.Longitude}
+		if center.DistanceMeters(*event.Location) <= area.RadiusMiles*metersPerMile {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rc *realtimeClient) writeError(message string) {
+	_ = rc.write(func() error {
+		return rc.conn.WriteJSON(dto.RealtimeErrorMessage{Type: "Error", Message: message})
+	})
+}
+
+func (rc *realtimeClient) write(fn func() error) error {
+	rc.writeMu.Lock()
+	defer rc.writeMu.Unlock()
+
+	_ = rc.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return fn()
+}
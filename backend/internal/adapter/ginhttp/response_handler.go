@@ -25,10 +25,14 @@ func (h *ResponseHandler) RegisterRoutes(r *gin.RouterGroup) {
 	responseRoutes.POST("", h.CreateResponse)
 	responseRoutes.PUT("", h.EditResponse)
 	responseRoutes.DELETE("/:response_id", h.DeleteResponse)
+	responseRoutes.POST("/:response_id/restore", h.RestoreResponse)
+	responseRoutes.GET("/:response_id/revisions", h.GetResponseRevisions) // query params: limit, offset
 
 	questionRoutes := responseRoutes.Group("/questions/:question_id")
 	questionRoutes.GET("", h.GetResponsesByQuestionID) // query params: limit, offset
 	questionRoutes.GET("/summary", h.GetQuestionSummary)
+
+	responseRoutes.GET("/users/:user_id", h.GetUserResponses) // query params: limit, offset
 }
 
 func (h *ResponseHandler) CreateResponse(c *gin.Context) {
@@ -83,6 +87,7 @@ func (h *ResponseHandler) EditResponse(c *gin.Context) {
 	resp, err := h.ResponseService.EditResponse(c.Request.Context(), userID, model.EditResponseParams{
 		ResponseID: req.ResponseID,
 		Body:       req.Body,
+		ImageURLs:  req.ImageURLs,
 	})
 	if err != nil {
 		HandleErr(c, fmt.Errorf("%s: %w", "ResponseHandler::EditResponse", err))
@@ -110,6 +115,57 @@ func (h *ResponseHandler) DeleteResponse(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+func (h *ResponseHandler) RestoreResponse(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	rid, err := uuid.Parse(c.Param("response_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse response id", fmt.Errorf("%s: %w", "ResponseHandler::RestoreResponse", err)))
+		return
+	}
+
+	response, err := h.ResponseService.RestoreResponse(c.Request.Context(), userID, rid)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "ResponseHandler::RestoreResponse", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RestoreResponseRes{Response: response})
+}
+
+func (h *ResponseHandler) GetResponseRevisions(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	rid, err := uuid.Parse(c.Param("response_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse response id", fmt.Errorf("%s: %w", "ResponseHandler::GetResponseRevisions", err)))
+		return
+	}
+
+	limit, err := validateLimitQueryParam(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "ResponseHandler::GetResponseRevisions", err)))
+		return
+	}
+
+	offset, err := validateOffsetQueryParam(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "ResponseHandler::GetResponseRevisions", err)))
+		return
+	}
+
+	revisions, err := h.ResponseService.GetResponseRevisions(c.Request.Context(), userID, rid, model.PageParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "ResponseHandler::GetResponseRevisions", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetResponseRevisionsRes{Revisions: revisions})
+}
+
 func (h *ResponseHandler) GetResponsesByQuestionID(c *gin.Context) {
 	userID := getAuthUserID(c)
 
@@ -162,3 +218,31 @@ func (h *ResponseHandler) GetQuestionSummary(c *gin.Context) {
 
 	c.JSON(http.StatusOK, dto.GetQuestionSummaryRes{Summary: summary})
 }
+
+func (h *ResponseHandler) GetUserResponses(c *gin.Context) {
+	userID := getAuthUserID(c)
+	authorID := c.Param("user_id")
+
+	limit, err := validateLimitQueryParam(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "ResponseHandler::GetUserResponses", err)))
+		return
+	}
+
+	offset, err := validateOffsetQueryParam(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "ResponseHandler::GetUserResponses", err)))
+		return
+	}
+
+	responses, err := h.ResponseService.GetResponsesByAuthorID(c.Request.Context(), userID, authorID, model.PageParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "ResponseHandler::GetUserResponses", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetUserResponsesRes{Responses: responses})
+}
@@ -7,7 +7,9 @@ import (
 	"log/slog"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/ksha23/CS407-FactSnap/internal/adapter/ginhttp/dto"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 	"github.com/ksha23/CS407-FactSnap/internal/core/port"
 )
 
@@ -26,6 +28,19 @@ func (h *UserHandler) RegisterRoutes(r *gin.RouterGroup) {
 	users.DELETE("/push-token", h.DeletePushToken)
 	users.GET("/stats", h.GetUserStatistics)
 	users.PUT("/me", h.UpdateProfile)
+	users.DELETE("/me", h.DeleteAccount)
+	users.POST("/me/export", h.RequestDataExport)
+	users.GET("/me/export/:export_id", h.GetDataExport)
+	users.GET("/:user_id", h.GetUserByID)
+	users.GET("/by-username/:username", h.GetUserByUsername)
+	users.POST("/:user_id/follow", h.FollowUser)
+	users.DELETE("/:user_id/follow", h.UnfollowUser)
+	users.GET("/blocked", h.GetBlockedUsers)
+	users.POST("/:user_id/block", h.BlockUser)
+	users.DELETE("/:user_id/block", h.UnblockUser)
+	users.GET("/muted", h.GetMutedUsers)
+	users.POST("/:user_id/mute", h.MuteUser)
+	users.DELETE("/:user_id/mute", h.UnmuteUser)
 }
 
 type UpdateLocationRequest struct {
@@ -34,7 +49,9 @@ type UpdateLocationRequest struct {
 }
 
 type UpdatePushTokenRequest struct {
-	Token string `json:"token" binding:"required"`
+	Token      string  `json:"token" binding:"required"`
+	Platform   *string `json:"platform" binding:"omitempty,max=20"`
+	AppVersion *string `json:"app_version" binding:"omitempty,max=50"`
 }
 
 func (h *UserHandler) UpdateLocation(c *gin.Context) {
@@ -61,7 +78,12 @@ func (h *UserHandler) UpdatePushToken(c *gin.Context) {
 		return
 	}
 
-	if err := h.UserService.UpdatePushToken(c.Request.Context(), userID, req.Token); err != nil {
+	params := model.RegisterDeviceParams{
+		Token:      req.Token,
+		Platform:   req.Platform,
+		AppVersion: req.AppVersion,
+	}
+	if err := h.UserService.UpdatePushToken(c.Request.Context(), userID, params); err != nil {
 		slog.Error("UpdatePushToken failed", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -71,10 +93,13 @@ func (h *UserHandler) UpdatePushToken(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+// DeletePushToken unregisters the device given by the "token" query param.
+// If no token is given, all of the user's devices are unregistered.
 func (h *UserHandler) DeletePushToken(c *gin.Context) {
 	userID := getAuthUserID(c)
+	token := c.Query("token")
 
-	if err := h.UserService.DeletePushToken(c.Request.Context(), userID); err != nil {
+	if err := h.UserService.DeletePushToken(c.Request.Context(), userID, token); err != nil {
 		slog.Error("DeletePushToken failed", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -93,9 +118,17 @@ func (h *UserHandler) GetUserStatistics(c *gin.Context) {
 		return
 	}
 
+	followerCount, followingCount, err := h.UserService.GetFollowCounts(c.Request.Context(), userID)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::GetUserStatistics", err))
+		return
+	}
+
 	res := dto.GetUserStatisticsRes{
-		QuestionCount: questionCount,
-		ResponseCount: responseCount,
+		QuestionCount:  questionCount,
+		ResponseCount:  responseCount,
+		FollowerCount:  followerCount,
+		FollowingCount: followingCount,
 	}
 	c.JSON(http.StatusOK, res)
 }
@@ -103,18 +136,241 @@ func (h *UserHandler) GetUserStatistics(c *gin.Context) {
 
 
 func (h *UserHandler) UpdateProfile(c *gin.Context) {
-    userID := getAuthUserID(c)
+	userID := getAuthUserID(c)
 
-    var req dto.UpdateProfileReq
-    if err := unmarshalAndValidateReq(c, &req); err != nil {
-        return
-    }
+	var req dto.UpdateProfileReq
+	if err := unmarshalAndValidateReq(c, &req); err != nil {
+		c.Error(BadRequestJSON(c, err, fmt.Errorf("%s: %w", "UserHandler::UpdateProfile", err)))
+		return
+	}
 
-    user, err := h.UserService.UpdateProfile(c.Request.Context(), userID, req.DisplayName)
-    if err != nil {
-        HandleErr(c, fmt.Errorf("UserHandler::UpdateProfile: %w", err))
-        return
-    }
+	user, err := h.UserService.EditUser(c.Request.Context(), model.EditUserParams{
+		UserID:      userID,
+		DisplayName: req.DisplayName,
+		Username:    req.Username,
+		AboutMe:     req.AboutMe,
+		AvatarURL:   req.AvatarURL,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::UpdateProfile", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetAuthUserRes{AuthUser: user})
+}
+
+// DeleteAccount deletes the user's account along with all of their content and uploads
+func (h *UserHandler) DeleteAccount(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	if err := h.UserService.DeleteAccount(c.Request.Context(), userID); err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::DeleteAccount", err))
+		return
+	}
 
-    c.JSON(http.StatusOK, dto.GetAuthUserRes{AuthUser: user})
-}
\ No newline at end of file
+	c.Status(http.StatusOK)
+}
+
+// RequestDataExport queues an export of the user's data, the user is notified once it can be downloaded
+func (h *UserHandler) RequestDataExport(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	export, err := h.UserService.RequestDataExport(c.Request.Context(), userID)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::RequestDataExport", err))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.GetDataExportRes{Export: export})
+}
+
+func (h *UserHandler) GetDataExport(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	exportID, err := uuid.Parse(c.Param("export_id"))
+	if err != nil {
+		c.Error(BadRequest(c, "could not parse export id", fmt.Errorf("%s: %w", "UserHandler::GetDataExport", err)))
+		return
+	}
+
+	export, err := h.UserService.GetDataExport(c.Request.Context(), userID, exportID)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::GetDataExport", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetDataExportRes{Export: export})
+}
+
+func (h *UserHandler) FollowUser(c *gin.Context) {
+	userID := getAuthUserID(c)
+	followeeID := c.Param("user_id")
+
+	if err := h.UserService.FollowUser(c.Request.Context(), userID, followeeID); err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::FollowUser", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *UserHandler) UnfollowUser(c *gin.Context) {
+	userID := getAuthUserID(c)
+	followeeID := c.Param("user_id")
+
+	if err := h.UserService.UnfollowUser(c.Request.Context(), userID, followeeID); err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::UnfollowUser", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *UserHandler) BlockUser(c *gin.Context) {
+	userID := getAuthUserID(c)
+	blockedID := c.Param("user_id")
+
+	if err := h.UserService.BlockUser(c.Request.Context(), userID, blockedID); err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::BlockUser", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *UserHandler) UnblockUser(c *gin.Context) {
+	userID := getAuthUserID(c)
+	blockedID := c.Param("user_id")
+
+	if err := h.UserService.UnblockUser(c.Request.Context(), userID, blockedID); err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::UnblockUser", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *UserHandler) GetBlockedUsers(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	limit, err := validateLimitQueryParam(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "UserHandler::GetBlockedUsers", err)))
+		return
+	}
+
+	offset, err := validateOffsetQueryParam(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "UserHandler::GetBlockedUsers", err)))
+		return
+	}
+
+	users, err := h.UserService.GetBlockedUsers(c.Request.Context(), userID, model.PageParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::GetBlockedUsers", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetBlockedUsersRes{Users: users})
+}
+
+func (h *UserHandler) MuteUser(c *gin.Context) {
+	userID := getAuthUserID(c)
+	mutedID := c.Param("user_id")
+
+	if err := h.UserService.MuteUser(c.Request.Context(), userID, mutedID); err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::MuteUser", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *UserHandler) UnmuteUser(c *gin.Context) {
+	userID := getAuthUserID(c)
+	mutedID := c.Param("user_id")
+
+	if err := h.UserService.UnmuteUser(c.Request.Context(), userID, mutedID); err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::UnmuteUser", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *UserHandler) GetMutedUsers(c *gin.Context) {
+	userID := getAuthUserID(c)
+
+	limit, err := validateLimitQueryParam(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "UserHandler::GetMutedUsers", err)))
+		return
+	}
+
+	offset, err := validateOffsetQueryParam(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(BadRequest(c, err.Error(), fmt.Errorf("%s: %w", "UserHandler::GetMutedUsers", err)))
+		return
+	}
+
+	users, err := h.UserService.GetMutedUsers(c.Request.Context(), userID, model.PageParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::GetMutedUsers", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetMutedUsersRes{Users: users})
+}
+
+func (h *UserHandler) GetUserByID(c *gin.Context) {
+	viewerID := getAuthUserID(c)
+
+	user, err := h.UserService.GetUserByID(c.Request.Context(), viewerID, c.Param("user_id"))
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::GetUserByID", err))
+		return
+	}
+
+	h.writeUserProfile(c, user, "UserHandler::GetUserByID")
+}
+
+func (h *UserHandler) GetUserByUsername(c *gin.Context) {
+	viewerID := getAuthUserID(c)
+
+	user, err := h.UserService.GetUserByUsername(c.Request.Context(), viewerID, c.Param("username"))
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", "UserHandler::GetUserByUsername", err))
+		return
+	}
+
+	h.writeUserProfile(c, user, "UserHandler::GetUserByUsername")
+}
+
+// writeUserProfile responds with the public profile of the user, along with their statistics
+func (h *UserHandler) writeUserProfile(c *gin.Context, user model.User, caller string) {
+	questionCount, responseCount, err := h.UserService.GetUserStatistics(c.Request.Context(), user.ID)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", caller, err))
+		return
+	}
+
+	followerCount, followingCount, err := h.UserService.GetFollowCounts(c.Request.Context(), user.ID)
+	if err != nil {
+		HandleErr(c, fmt.Errorf("%s: %w", caller, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.GetUserProfileRes{
+		User:           user,
+		QuestionCount:  questionCount,
+		ResponseCount:  responseCount,
+		FollowerCount:  followerCount,
+		FollowingCount: followingCount,
+	})
+}
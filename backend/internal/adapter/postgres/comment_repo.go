@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/postgres/sqlc"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+)
+
+type commentRepo struct {
+	query *sqlc.Queries
+	db    *pgxpool.Pool
+}
+
+func NewCommentRepo(db *pgxpool.Pool) *commentRepo {
+	return &commentRepo{
+		query: sqlc.New(db),
+		db:    db,
+	}
+}
+
+func (r *commentRepo) CreateComment(ctx context.Context, userID string, params model.CreateCommentParams) (model.Comment, error) {
+	var commentRow sqlc.CreateCommentRow
+	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		// create comment
+		row, err := query.CreateComment(ctx, userID, params.ResponseID, params.Body)
+		if err != nil {
+			return fmt.Errorf("CreateComment: %w", wrapError(err))
+		}
+
+		// increment reply count for the response
+		err = query.IncrementReplyCount(ctx, params.ResponseID)
+		if err != nil {
+			return fmt.Errorf("IncrementReplyCount: %w", wrapError(err))
+		}
+
+		commentRow = row
+		return nil
+	})
+	if err != nil {
+		return model.Comment{}, fmt.Errorf("CommentRepo::CreateComment: %w", err)
+	}
+
+	return commentRow.ToDomainModel(), nil
+}
+
+func (r *commentRepo) GetCommentsByResponseID(ctx context.Context, userID string, responseID uuid.UUID, page model.PageParams) ([]model.Comment, error) {
+	comments, err := r.query.GetCommentsByResponseID(ctx, userID, responseID, int32(page.Offset), int32(page.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("CommentRepo::GetCommentsByResponseID: %w", wrapError(err))
+	}
+
+	return convertRowsToDomain(comments), nil
+}
+
+func (r *commentRepo) GetCommentByID(ctx context.Context, userID string, commentID uuid.UUID) (model.Comment, error) {
+	row, err := r.query.GetCommentByID(ctx, userID, commentID)
+	if err != nil {
+		return model.Comment{}, fmt.Errorf("CommentRepo::GetCommentByID: %w", wrapError(err))
+	}
+
+	return row.ToDomainModel(), nil
+}
+
+func (r *commentRepo) EditComment(ctx context.Context, userID string, params model.EditCommentParams) (model.Comment, error) {
+	row, err := r.query.EditComment(ctx, params.Body, params.CommentID)
+	if err != nil {
+		return model.Comment{}, fmt.Errorf("CommentRepo::EditComment: %w", wrapError(err))
+	}
+
+	return row.ToDomainModel(), nil
+}
+
+func (r *commentRepo) DeleteComment(ctx context.Context, commentID uuid.UUID, responseID uuid.UUID) error {
+	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		numRows, err := query.DeleteComment(ctx, commentID)
+		if err != nil {
+			return fmt.Errorf("DeleteComment: %w", wrapError(err))
+		}
+		// already deleted
+		if numRows == 0 {
+			return nil
+		}
+
+		// decrement reply count for the response
+		err = query.DecrementReplyCount(ctx, responseID)
+		if err != nil {
+			return fmt.Errorf("DecrementReplyCount: %w", wrapError(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("CommentRepo::DeleteComment: %w", err)
+	}
+
+	return nil
+}
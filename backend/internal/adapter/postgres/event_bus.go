@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/postgres/sqlc"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+)
+
+const (
+	eventChannel = "factsnap_events"
+	// NOTIFY payloads must be shorter than 8000 bytes
+	maxEventPayloadBytes = 7500
+	// events are dropped for subscribers that fall this far behind
+	subscriberBufferSize = 64
+	listenRetryDelay     = 5 * time.Second
+)
+
+// EventBus broadcasts realtime events across all API instances through Postgres LISTEN/NOTIFY
+type EventBus struct {
+	query *sqlc.Queries
+	db    *pgxpool.Pool
+
+	mu          sync.RWMutex
+	subscribers map[chan model.Event]struct{}
+}
+
+func NewEventBus(db *pgxpool.Pool) *EventBus {
+	return &EventBus{
+		query:       sqlc.New(db),
+		db:          db,
+		subscribers: make(map[chan model.Event]struct{}),
+	}
+}
+
+func (b *EventBus) Publish(ctx context.Context, event model.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("EventBus::Publish: could not marshal event: %w", err)
+	}
+
+	// too large to be sent through NOTIFY, so only let subscribers know that something changed
+	if len(payload) > maxEventPayloadBytes {
+		event.Data = nil
+		event.Truncated = true
+		payload, err = json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("EventBus::Publish: could not marshal event: %w", err)
+		}
+	}
+
+	if err := b.query.PublishEvent(ctx, eventChannel, string(payload)); err != nil {
+		return fmt.Errorf("EventBus::Publish: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (b *EventBus) PublishLocal(event model.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			slog.Warn("Dropping realtime event for slow subscriber", "type", event.Type, "question_id", event.QuestionID)
+		}
+	}
+}
+
+func (b *EventBus) Subscribe() (<-chan model.Event, func()) {
+	ch := make(chan model.Event, subscriberBufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (b *EventBus) Run(ctx context.Context) error {
+	slog.Info("Starting realtime event listener...", "channel", eventChannel)
+
+	for {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			slog.Info("Shutting down realtime event listener...")
+			return nil
+		}
+
+		// the connection was lost, so try again after a while instead of taking the whole API down
+		slog.Error("Realtime event listener stopped, reconnecting...", "error", err)
+		select {
+		case <-ctx.Done():
+			slog.Info("Shutting down realtime event listener...")
+			return nil
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+// listen holds on to a dedicated connection that LISTENs to the event channel, and broadcasts every
+// notification to the local subscribers. It only returns once the connection fails or the context is canceled.
+func (b *EventBus) listen(ctx context.Context) error {
+	poolConn, err := b.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("EventBus::listen: could not acquire connection: %w", err)
+	}
+	// the connection will be in LISTEN mode, so take it out of the pool instead of handing it back later on
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, fmt.Sprintf("LISTEN %s", eventChannel)); err != nil {
+		return fmt.Errorf("EventBus::listen: %w", err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("EventBus::listen: %w", err)
+		}
+
+		var event model.Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			slog.Error("Could not unmarshal realtime event", "error", err)
+			continue
+		}
+		b.PublishLocal(event)
+	}
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/postgres/sqlc"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/errs"
+	"github.com/ksha23/CS407-FactSnap/internal/ptr"
+)
+
+type dataExportRepo struct {
+	query *sqlc.Queries
+	db    *pgxpool.Pool
+}
+
+func NewDataExportRepo(db *pgxpool.Pool) *dataExportRepo {
+	return &dataExportRepo{
+		query: sqlc.New(db),
+		db:    db,
+	}
+}
+
+func (r *dataExportRepo) CreateDataExport(ctx context.Context, userID string) (model.DataExport, error) {
+	// nothing is created if the user already has an export in progress
+	row, err := r.query.CreateDataExport(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.DataExport{}, fmt.Errorf("DataExportRepo::CreateDataExport: %w", errs.Error{
+				Type:     errs.TypeForbidden,
+				Message:  "An export of your data is already in progress",
+				Internal: fmt.Errorf("user id %s already has an export in progress", userID),
+			})
+		}
+		return model.DataExport{}, fmt.Errorf("DataExportRepo::CreateDataExport: %w", wrapError(err))
+	}
+
+	return row.ToDomainModel(), nil
+}
+
+func (r *dataExportRepo) GetDataExportByID(ctx context.Context, userID string, exportID uuid.UUID) (model.DataExport, error) {
+	row, err := r.query.GetDataExportByID(ctx, exportID, userID)
+	if err != nil {
+		return model.DataExport{}, fmt.Errorf("DataExportRepo::GetDataExportByID: %w", wrapError(err))
+	}
+
+	return row.ToDomainModel(), nil
+}
+
+func (r *dataExportRepo) ClaimDataExport(ctx context.Context, staleBefore time.Time) (model.DataExport, error) {
+	row, err := r.query.ClaimDataExport(ctx, staleBefore)
+	if err != nil {
+		return model.DataExport{}, fmt.Errorf("DataExportRepo::ClaimDataExport: %w", wrapError(err))
+	}
+
+	return row.ToDomainModel(), nil
+}
+
+func (r *dataExportRepo) CompleteDataExport(ctx context.Context, exportID uuid.UUID, objectKey string) (bool, error) {
+	numRows, err := r.query.CompleteDataExport(ctx, ptr.To(objectKey), exportID)
+	if err != nil {
+		return false, fmt.Errorf("DataExportRepo::CompleteDataExport: %w", wrapError(err))
+	}
+
+	return numRows > 0, nil
+}
+
+func (r *dataExportRepo) FailDataExport(ctx context.Context, exportID uuid.UUID, lastError string) error {
+	err := r.query.FailDataExport(ctx, ptr.To(lastError), exportID)
+	if err != nil {
+		return fmt.Errorf("DataExportRepo::FailDataExport: %w", wrapError(err))
+	}
+
+	return nil
+}
+
+func (r *dataExportRepo) GetVotesByUserID(ctx context.Context, userID string) ([]model.ExportedVote, error) {
+	pollVotes, err := r.query.GetPollVotesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportRepo::GetVotesByUserID: %w", wrapError(err))
+	}
+
+	ratingAnswers, err := r.query.GetRatingAnswersByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportRepo::GetVotesByUserID: %w", wrapError(err))
+	}
+
+	confirmAnswers, err := r.query.GetConfirmAnswersByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("DataExportRepo::GetVotesByUserID: %w", wrapError(err))
+	}
+
+	votes := make([]model.ExportedVote, 0, len(pollVotes)+len(ratingAnswers)+len(confirmAnswers))
+	votes = append(votes, convertRowsToDomain[model.ExportedVote](pollVotes)...)
+	votes = append(votes, convertRowsToDomain[model.ExportedVote](ratingAnswers)...)
+	votes = append(votes, convertRowsToDomain[model.ExportedVote](confirmAnswers)...)
+
+	// oldest first across all kinds of votes
+	sort.SliceStable(votes, func(i, j int) bool {
+		return votes[i].CreatedAt.Before(votes[j].CreatedAt)
+	})
+
+	return votes, nil
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/postgres/sqlc"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+)
+
+// resolveMentions parses the mentions of the body and keeps the ones of existing users that did not block the author,
+// along with their ids. It also returns the mentions marshalled for the mentions column.
+func resolveMentions(ctx context.Context, query *sqlc.Queries, authorID string, body string) ([]model.Mention, []byte, error) {
+	mentions := model.ParseMentions(body)
+
+	resolved := []model.Mention{}
+	if len(mentions) > 0 {
+		usernames := make([]string, 0, len(mentions))
+		for _, mention := range mentions {
+			usernames = append(usernames, strings.ToLower(mention.Username))
+		}
+
+		users, err := query.GetUsersByUsernames(ctx, usernames, authorID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolveMentions: GetUsersByUsernames: %w", wrapError(err))
+		}
+		// usernames are unique regardless of case, so "@Alice" mentions "alice"
+		userIDs := make(map[string]string, len(users))
+		for _, user := range users {
+			userIDs[strings.ToLower(user.Username)] = user.ID
+		}
+
+		// unknown usernames, and users that blocked the author, are left as plain text
+		for _, mention := range mentions {
+			userID, ok := userIDs[strings.ToLower(mention.Username)]
+			if !ok {
+				continue
+			}
+			mention.UserID = userID
+			resolved = append(resolved, mention)
+		}
+	}
+
+	data, err := json.Marshal(resolved)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolveMentions: could not marshal mentions: %w", err)
+	}
+
+	return resolved, data, nil
+}
+
+// enqueueMentionNotification records a notification for the users that are mentioned now but weren't mentioned before.
+// The author is never notified of their own mentions.
+func enqueueMentionNotification(ctx context.Context, query *sqlc.Queries, payload model.MentionPayload, mentions []model.Mention, previousMentions []model.Mention) error {
+	notified := map[string]struct{}{payload.AuthorID: {}}
+	for _, userID := range model.MentionedUserIDs(previousMentions) {
+		notified[userID] = struct{}{}
+	}
+
+	for _, userID := range model.MentionedUserIDs(mentions) {
+		if _, ok := notified[userID]; ok {
+			continue
+		}
+		payload.UserIDs = append(payload.UserIDs, userID)
+	}
+	if len(payload.UserIDs) == 0 {
+		return nil
+	}
+
+	return enqueueOutboxMessage(ctx, query, model.OutboxMessageTypeMention, payload)
+}
+
+func unmarshalMentions(data []byte) ([]model.Mention, error) {
+	var mentions []model.Mention
+	if err := json.Unmarshal(data, &mentions); err != nil {
+		return nil, fmt.Errorf("unmarshalMentions: %w", err)
+	}
+	return mentions, nil
+}
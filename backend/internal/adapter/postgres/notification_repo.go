@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/postgres/sqlc"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+)
+
+type notificationRepo struct {
+	query *sqlc.Queries
+	db    *pgxpool.Pool
+}
+
+func NewNotificationRepo(db *pgxpool.Pool) *notificationRepo {
+	return &notificationRepo{
+		query: sqlc.New(db),
+		db:    db,
+	}
+}
+
+func (r *notificationRepo) ClaimOutboxMessages(ctx context.Context, limit int, lockedUntil time.Time) ([]model.OutboxMessage, error) {
+	rows, err := r.query.ClaimOutboxMessages(ctx, lockedUntil, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("NotificationRepo::ClaimOutboxMessages: %w", wrapError(err))
+	}
+	return convertRowsToDomain(rows), nil
+}
+
+func (r *notificationRepo) MarkOutboxMessageDelivered(ctx context.Context, messageID uuid.UUID) error {
+	if err := r.query.MarkOutboxMessageDelivered(ctx, messageID); err != nil {
+		return fmt.Errorf("NotificationRepo::MarkOutboxMessageDelivered: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *notificationRepo) RetryOutboxMessage(ctx context.Context, messageID uuid.UUID, lastError string, availableAt time.Time) error {
+	if err := r.query.RetryOutboxMessage(ctx, lastError, availableAt, messageID); err != nil {
+		return fmt.Errorf("NotificationRepo::RetryOutboxMessage: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *notificationRepo) FailOutboxMessage(ctx context.Context, messageID uuid.UUID, lastError string) error {
+	if err := r.query.FailOutboxMessage(ctx, lastError, messageID); err != nil {
+		return fmt.Errorf("NotificationRepo::FailOutboxMessage: %w", wrapError(err))
+	}
+	return nil
+}
+
+// enqueueOutboxMessage records a notification in the outbox. It is meant to be called within execTx,
+// so that the notification is only recorded if the domain change that caused it is committed.
+func enqueueOutboxMessage(ctx context.Context, query *sqlc.Queries, msgType model.OutboxMessageType, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("enqueueOutboxMessage: could not marshal %s payload: %w", msgType, err)
+	}
+	if err := query.CreateOutboxMessage(ctx, string(msgType), data); err != nil {
+		return fmt.Errorf("enqueueOutboxMessage: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *notificationRepo) CreatePushTickets(ctx context.Context, tickets []model.PushTicket) error {
+	if len(tickets) == 0 {
+		return nil
+	}
+
+	params := make([]sqlc.CreatePushTicketsParams, 0, len(tickets))
+	for _, t := range tickets {
+		params = append(params, sqlc.CreatePushTicketsParams{
+			TicketID: t.TicketID,
+			UserID:   t.UserID,
+			Token:    t.Token,
+		})
+	}
+
+	if _, err := r.query.CreatePushTickets(ctx, params); err != nil {
+		return fmt.Errorf("NotificationRepo::CreatePushTickets: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *notificationRepo) GetPushTicketsCreatedBefore(ctx context.Context, createdBefore time.Time, limit int) ([]model.PushTicket, error) {
+	rows, err := r.query.GetPushTicketsCreatedBefore(ctx, createdBefore, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("NotificationRepo::GetPushTicketsCreatedBefore: %w", wrapError(err))
+	}
+	return convertRowsToDomain(rows), nil
+}
+
+func (r *notificationRepo) DeletePushTickets(ctx context.Context, ticketIDs []string) error {
+	if err := r.query.DeletePushTickets(ctx, ticketIDs); err != nil {
+		return fmt.Errorf("NotificationRepo::DeletePushTickets: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *notificationRepo) CreateNotifications(ctx context.Context, userIDs []string, params model.NotifyParams) ([]string, error) {
+	data := []byte("{}")
+	if params.Data != nil {
+		var err error
+		data, err = json.Marshal(params.Data)
+		if err != nil {
+			return nil, fmt.Errorf("NotificationRepo::CreateNotifications: could not marshal data: %w", err)
+		}
+	}
+
+	createdIDs, err := r.query.CreateNotifications(ctx, sqlc.CreateNotificationsParams{
+		UserIds:          userIDs,
+		NotificationType: string(params.Type),
+		Title:            params.Title,
+		Body:             params.Body,
+		Data:             data,
+		DedupeKey:        params.DedupeKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("NotificationRepo::CreateNotifications: %w", wrapError(err))
+	}
+	return createdIDs, nil
+}
+
+func (r *notificationRepo) GetNotificationsByUserID(ctx context.Context, userID string, page model.PageParams) ([]model.Notification, error) {
+	rows, err := r.query.GetNotificationsByUserID(ctx, userID, int32(page.Offset), int32(page.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("NotificationRepo::GetNotificationsByUserID: %w", wrapError(err))
+	}
+	return convertRowsToDomain(rows), nil
+}
+
+func (r *notificationRepo) GetUnreadNotificationCount(ctx context.Context, userID string) (int, error) {
+	count, err := r.query.GetUnreadNotificationCount(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("NotificationRepo::GetUnreadNotificationCount: %w", wrapError(err))
+	}
+	return count, nil
+}
+
+func (r *notificationRepo) MarkNotificationRead(ctx context.Context, userID string, notificationID uuid.UUID) error {
+	if _, err := r.query.MarkNotificationRead(ctx, userID, notificationID); err != nil {
+		return fmt.Errorf("NotificationRepo::MarkNotificationRead: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *notificationRepo) MarkAllNotificationsRead(ctx context.Context, userID string) error {
+	if err := r.query.MarkAllNotificationsRead(ctx, userID); err != nil {
+		return fmt.Errorf("NotificationRepo::MarkAllNotificationsRead: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *notificationRepo) DeleteNotification(ctx context.Context, userID string, notificationID uuid.UUID) error {
+	if _, err := r.query.DeleteNotification(ctx, userID, notificationID); err != nil {
+		return fmt.Errorf("NotificationRepo::DeleteNotification: %w", wrapError(err))
+	}
+	return nil
+}
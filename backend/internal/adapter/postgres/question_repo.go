@@ -2,12 +2,18 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/ksha23/CS407-FactSnap/internal/adapter/postgres/sqlc"
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/errs"
+	"github.com/ksha23/CS407-FactSnap/internal/ptr"
 	"golang.org/x/sync/errgroup"
+	"time"
 )
 
 type questionRepo struct {
@@ -22,7 +28,7 @@ func NewQuestionRepo(db *pgxpool.Pool) *questionRepo {
 	}
 }
 
-func (r *questionRepo) CreateQuestion(ctx context.Context, userID string, params model.CreateQuestionParams) (uuid.UUID, error) {
+func (r *questionRepo) CreateQuestion(ctx context.Context, userID string, params model.CreateQuestionParams, expiresAt time.Time) (uuid.UUID, error) {
 	var questionRow sqlc.CreateQuestionRow
 	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
 		// in a single transaction:
@@ -34,7 +40,7 @@ func (r *questionRepo) CreateQuestion(ctx context.Context, userID string, params
 			Body:        params.Body,
 			Category:    string(params.Category),
 			ImageUrls:   params.ImageURLs,
-			ExpiredAt:   params.ExpiresAt,
+			ExpiredAt:   expiresAt,
 		})
 		if err != nil {
 			return fmt.Errorf("CreateQuestion: %w", wrapError(err))
@@ -51,6 +57,35 @@ func (r *questionRepo) CreateQuestion(ctx context.Context, userID string, params
 			return fmt.Errorf("CreateLocation: %w", wrapError(err))
 		}
 
+		// - store the mentions of the body and notify the mentioned users
+		mentions, mentionsData, err := resolveMentions(ctx, query, userID, ptr.Deref(params.Body))
+		if err != nil {
+			return err
+		}
+		err = query.SetQuestionMentions(ctx, row.ID, mentionsData)
+		if err != nil {
+			return fmt.Errorf("SetQuestionMentions: %w", wrapError(err))
+		}
+		err = enqueueMentionNotification(ctx, query, model.MentionPayload{
+			QuestionID: row.ID,
+			AuthorID:   userID,
+		}, mentions, nil)
+		if err != nil {
+			return err
+		}
+
+		// - record the nearby-users notification, so it is only sent if the question is committed
+		err = enqueueOutboxMessage(ctx, query, model.OutboxMessageTypeNewQuestion, model.NewQuestionPayload{
+			QuestionID: row.ID,
+			AuthorID:   userID,
+			Title:      params.Title,
+			Latitude:   params.Location.Latitude,
+			Longitude:  params.Location.Longitude,
+		})
+		if err != nil {
+			return err
+		}
+
 		questionRow = row
 		return nil
 	})
@@ -67,40 +102,172 @@ func (r *questionRepo) CreatePoll(ctx context.Context, userID string, params mod
 	var pollID uuid.UUID
 	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
 		// in a single transaction:
-		// - first, insert poll
-		pollRow, err := query.CreatePoll(ctx, params.QuestionID)
+		// - first, set the question content type, which fails if the question already has content
+		if err := setQuestionContentType(ctx, query, params.QuestionID, model.ContentTypePoll); err != nil {
+			return err
+		}
+
+		// - then, insert poll
+		pollRow, err := query.CreatePoll(ctx, sqlc.CreatePollParams{
+			QuestionID:        params.QuestionID,
+			Type:              string(params.Type),
+			MaxSelections:     params.MaxSelections,
+			AllowOther:        params.AllowOther,
+			ResultsVisibility: string(params.ResultsVisibility),
+			IsAnonymous:       params.IsAnonymous,
+			ClosesAt:          params.ClosesAt,
+		})
 		if err != nil {
 			return fmt.Errorf("CreatePoll: %w", wrapError(err))
 		}
 		pollID = pollRow.ID
 
-		// - then, insert poll options
-		pollOptionParams := make([]sqlc.CreatePollOptionsParams, 0, len(params.OptionLabels))
-		for i, label := range params.OptionLabels {
-			pollOptionParams = append(pollOptionParams, sqlc.CreatePollOptionsParams{
-				PollID: pollID,
-				Label:  label,
-				Index:  i,
-			})
-		}
-		_, err = query.CreatePollOptions(ctx, pollOptionParams)
+		// - finally, insert poll options
+		return createPollOptions(ctx, query, pollID, params.OptionLabels)
+	})
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionRepo::CreatePoll: %w", err)
+	}
+
+	return pollID, nil
+}
+
+// setQuestionContentType claims the question for the content, it must run before the content is inserted.
+// It returns an unauthorized error if the question already has content.
+func setQuestionContentType(ctx context.Context, query *sqlc.Queries, questionID uuid.UUID, contentType model.ContentType) error {
+	numRows, err := query.SetQuestionContentType(ctx, string(contentType), questionID)
+	if err != nil {
+		return fmt.Errorf("SetQuestionContentType: %w", wrapError(err))
+	}
+	// another request added content meanwhile
+	if numRows == 0 {
+		return errs.UnauthorizedError("This question already has content", fmt.Errorf("question id %s already has content", questionID))
+	}
+	return nil
+}
+
+func createPollOptions(ctx context.Context, query *sqlc.Queries, pollID uuid.UUID, optionLabels []string) error {
+	pollOptionParams := make([]sqlc.CreatePollOptionsParams, 0, len(optionLabels))
+	for i, label := range optionLabels {
+		pollOptionParams = append(pollOptionParams, sqlc.CreatePollOptionsParams{
+			PollID: pollID,
+			Label:  label,
+			Index:  i,
+		})
+	}
+	if _, err := query.CreatePollOptions(ctx, pollOptionParams); err != nil {
+		return fmt.Errorf("CreatePollOptions: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *questionRepo) EditPoll(ctx context.Context, params model.EditPollParams) error {
+	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		// in a single transaction:
+		// - first, lock the poll, so that no vote is placed while its options are replaced
+		row, err := query.LockPollForEdit(ctx, params.PollID)
 		if err != nil {
-			return fmt.Errorf("CreatePollOptions: %w", wrapError(err))
+			return fmt.Errorf("LockPollForEdit: %w", wrapError(err))
+		}
+		if row.Poll.ClosedAt != nil {
+			return errs.UnauthorizedError("This poll has closed", fmt.Errorf("poll id %s has closed", params.PollID))
 		}
 
-		// - then set the question content type
-		err = query.SetQuestionContentType(ctx, params.QuestionID, string(model.ContentTypePoll))
-		if err != nil {
-			return fmt.Errorf("SetQuestionContentType: %w", wrapError(err))
+		// - then, replace the poll options (if applicable)
+		if params.OptionLabels != nil {
+			if row.NumVotes > 0 {
+				return errs.UnauthorizedError("Options can only be changed before the first vote", fmt.Errorf("poll id %s already has votes", params.PollID))
+			}
+			if err := query.DeletePollOptions(ctx, params.PollID); err != nil {
+				return fmt.Errorf("DeletePollOptions: %w", wrapError(err))
+			}
+			if err := createPollOptions(ctx, query, params.PollID, params.OptionLabels); err != nil {
+				return err
+			}
+		}
+
+		// - then, update the poll settings
+		maxSelections := row.Poll.MaxSelections
+		if params.MaxSelections != nil {
+			maxSelections = *params.MaxSelections
+		}
+		if maxSelections < row.Poll.MaxSelections && row.NumVotes > 0 {
+			// existing ballots must still be valid under the lowered limit
+			maxBallotSize, err := query.GetPollMaxBallotSize(ctx, params.PollID)
+			if err != nil {
+				return fmt.Errorf("GetPollMaxBallotSize: %w", wrapError(err))
+			}
+			if maxSelections < maxBallotSize {
+				return errs.BadRequestError(
+					fmt.Sprintf("Max selections cannot be lower than %d, the most selections a voter already made", maxBallotSize),
+					fmt.Errorf("poll id %s has a ballot with %d selections", params.PollID, maxBallotSize),
+				)
+			}
+		}
+		if err := query.UpdatePollSettings(ctx, maxSelections, params.ClosesAt, params.PollID); err != nil {
+			return fmt.Errorf("UpdatePollSettings: %w", wrapError(err))
 		}
 
 		return nil
 	})
 	if err != nil {
-		return uuid.UUID{}, fmt.Errorf("QuestionRepo::CreatePoll: %w", err)
+		return fmt.Errorf("QuestionRepo::EditPoll: %w", err)
 	}
+	return nil
+}
 
-	return pollID, nil
+func (r *questionRepo) ClosePoll(ctx context.Context, pollID uuid.UUID) error {
+	if err := r.query.ClosePoll(ctx, pollID); err != nil {
+		return fmt.Errorf("QuestionRepo::ClosePoll: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *questionRepo) GetPollIDsToFinalize(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	pollIDs, err := r.query.GetPollIDsToFinalize(ctx, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetPollIDsToFinalize: %w", wrapError(err))
+	}
+	return pollIDs, nil
+}
+
+func (r *questionRepo) FinalizePoll(ctx context.Context, question model.Question, poll model.Poll, notifyVoters bool) error {
+	finalResults, err := json.Marshal(poll.Results())
+	if err != nil {
+		return fmt.Errorf("QuestionRepo::FinalizePoll: could not marshal final results: %w", err)
+	}
+
+	err = execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		// in a single transaction:
+		// - first, store the final results
+		numRows, err := query.SetPollFinalResults(ctx, poll.ID, finalResults)
+		if err != nil {
+			return fmt.Errorf("SetPollFinalResults: %w", wrapError(err))
+		}
+		// another instance already finalized the poll
+		if numRows == 0 || !notifyVoters {
+			return nil
+		}
+
+		// - then, record the notification of the voters
+		return enqueueOutboxMessage(ctx, query, model.OutboxMessageTypePollClosed, model.PollClosedPayload{
+			PollID:     poll.ID,
+			QuestionID: question.ID,
+			Title:      question.Title,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("QuestionRepo::FinalizePoll: %w", err)
+	}
+	return nil
+}
+
+func (r *questionRepo) GetPollVoterIDs(ctx context.Context, pollID uuid.UUID) ([]string, error) {
+	voterIDs, err := r.query.GetPollVoterIDs(ctx, pollID)
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetPollVoterIDs: %w", wrapError(err))
+	}
+	return voterIDs, nil
 }
 
 func (r *questionRepo) IsPollExpired(ctx context.Context, pollID uuid.UUID) (bool, error) {
@@ -111,17 +278,36 @@ func (r *questionRepo) IsPollExpired(ctx context.Context, pollID uuid.UUID) (boo
 	return isExpired, nil
 }
 
-func (r *questionRepo) VotePoll(ctx context.Context, userID string, pollID uuid.UUID, optionID *uuid.UUID) error {
+func (r *questionRepo) VotePoll(ctx context.Context, userID string, pollType model.PollType, params model.VotePollParams) error {
 	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
 		// in a single transaction:
-		// - first, delete the previous poll vote (in case it exists)
-		if err := query.DeletePollVote(ctx, userID, pollID); err != nil {
+		// - first, ensure that the poll is still open and keep it from being edited or closed meanwhile
+		if _, err := query.LockOpenPoll(ctx, params.PollID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errs.UnauthorizedError("This poll has closed", fmt.Errorf("poll id %s has closed", params.PollID))
+			}
+			return fmt.Errorf("LockOpenPoll: %w", wrapError(err))
+		}
+
+		// - then, delete the previous poll votes (in case they exist)
+		if err := query.DeletePollVote(ctx, userID, params.PollID); err != nil {
 			return fmt.Errorf("DeletePollVote: %w", wrapError(err))
 		}
 
-		// - then, create new poll vote (if applicable)
-		if optionID != nil {
-			if err := query.CreatePollVote(ctx, pollID, *optionID, userID); err != nil {
+		// - then, create a vote for every picked option (if applicable)
+		for i, optionID := range params.OptionIDs {
+			var rank *int
+			if pollType == model.PollTypeRankedChoice {
+				rank = ptr.To(i + 1)
+			}
+			if err := query.CreatePollVote(ctx, params.PollID, &optionID, userID, rank, nil); err != nil {
+				return fmt.Errorf("CreatePollVote: %w", wrapError(err))
+			}
+		}
+
+		// - then, create the "other" vote (if applicable)
+		if params.OtherText != nil {
+			if err := query.CreatePollVote(ctx, params.PollID, nil, userID, nil, params.OtherText); err != nil {
 				return fmt.Errorf("CreatePollVote: %w", wrapError(err))
 			}
 		}
@@ -134,6 +320,14 @@ func (r *questionRepo) VotePoll(ctx context.Context, userID string, pollID uuid.
 	return nil
 }
 
+func (r *questionRepo) GetPollOptionVoters(ctx context.Context, optionID uuid.UUID, page model.PageParams) ([]model.PollVoter, error) {
+	rows, err := r.query.GetPollOptionVoters(ctx, &optionID, int32(page.Limit), int32(page.Offset))
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetPollOptionVoters: %w", wrapError(err))
+	}
+	return convertRowsToDomain(rows), nil
+}
+
 func (r *questionRepo) GetQuestionByID(ctx context.Context, userID string, questionID uuid.UUID) (model.Question, error) {
 	// get question
 	questionRow, err := r.query.GetQuestionByID(ctx, questionID, userID)
@@ -152,8 +346,296 @@ func (r *questionRepo) GetQuestionByID(ctx context.Context, userID string, quest
 	return question, nil
 }
 
-func (r *questionRepo) EditQuestion(ctx context.Context, userID string, params model.EditQuestionParams) (model.Question, error) {
+func (r *questionRepo) GetQuestionByPollID(ctx context.Context, userID string, pollID uuid.UUID) (model.Question, error) {
+	questionID, err := r.query.GetQuestionIDByPollID(ctx, pollID)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionRepo::GetQuestionByPollID: %w", wrapError(err))
+	}
+
+	question, err := r.GetQuestionByID(ctx, userID, questionID)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionRepo::GetQuestionByPollID: %w", err)
+	}
+
+	return question, nil
+}
+
+func (r *questionRepo) ExtendQuestion(ctx context.Context, questionID uuid.UUID, duration time.Duration, maxExtensions int, maxLifetime time.Duration) error {
+	numRows, err := r.query.ExtendQuestion(ctx, duration.Seconds(), questionID, maxExtensions, maxLifetime.Seconds())
+	if err != nil {
+		return fmt.Errorf("QuestionRepo::ExtendQuestion: %w", wrapError(err))
+	}
+	// the question expired, or reached its limits meanwhile
+	if numRows == 0 {
+		err := errs.UnauthorizedError("The question can no longer be extended", fmt.Errorf("question id %s is expired or reached its extension limits", questionID))
+		return fmt.Errorf("QuestionRepo::ExtendQuestion: %w", err)
+	}
+	return nil
+}
+
+func (r *questionRepo) ReopenQuestion(ctx context.Context, question model.Question, duration time.Duration) error {
+	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		// in a single transaction:
+		// - first, open the poll again if the expiry closed it, while the question still has its previous expiry
+		if err := query.ReopenExpiredPoll(ctx, question.ID); err != nil {
+			return fmt.Errorf("ReopenExpiredPoll: %w", wrapError(err))
+		}
+
+		// - then, reopen the question
+		numRows, err := query.ReopenQuestion(ctx, duration.Seconds(), question.ID)
+		if err != nil {
+			return fmt.Errorf("ReopenQuestion: %w", wrapError(err))
+		}
+		// the question was reopened (or archived) meanwhile
+		if numRows == 0 {
+			return errs.UnauthorizedError("The question can no longer be reopened", fmt.Errorf("question id %s was already reopened or is archived", question.ID))
+		}
+
+		// - finally, record the nearby-users notification, so it is only sent if the question is reopened
+		return enqueueOutboxMessage(ctx, query, model.OutboxMessageTypeQuestionReopened, model.NewQuestionPayload{
+			QuestionID: question.ID,
+			AuthorID:   question.Author.ID,
+			Title:      question.Title,
+			Latitude:   question.Location.Latitude,
+			Longitude:  question.Location.Longitude,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("QuestionRepo::ReopenQuestion: %w", err)
+	}
+	return nil
+}
+
+func (r *questionRepo) GetQuestionIDsToExpire(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	questionIDs, err := r.query.GetQuestionIDsToExpire(ctx, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetQuestionIDsToExpire: %w", wrapError(err))
+	}
+	return questionIDs, nil
+}
+
+func (r *questionRepo) ExpireQuestion(ctx context.Context, question model.Question, summary *string, notify bool) error {
+	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		// in a single transaction:
+		// - first, flip the status and store the summary
+		numRows, err := query.ExpireQuestion(ctx, question.ID, summary)
+		if err != nil {
+			return fmt.Errorf("ExpireQuestion: %w", wrapError(err))
+		}
+		// another instance already expired the question, or it is open again
+		if numRows == 0 || !notify {
+			return nil
+		}
+
+		// - then, record the notification of the author and responders
+		return enqueueOutboxMessage(ctx, query, model.OutboxMessageTypeQuestionExpired, model.QuestionExpiredPayload{
+			QuestionID:   question.ID,
+			AuthorID:     question.Author.ID,
+			Title:        question.Title,
+			NumResponses: question.ResponsesAmount,
+			HasSummary:   summary != nil,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("QuestionRepo::ExpireQuestion: %w", err)
+	}
+	return nil
+}
+
+func (r *questionRepo) GetQuestionResponderIDs(ctx context.Context, questionID uuid.UUID) ([]string, error) {
+	responderIDs, err := r.query.GetQuestionResponderIDs(ctx, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetQuestionResponderIDs: %w", wrapError(err))
+	}
+	return responderIDs, nil
+}
+
+func (r *questionRepo) GetQuestionsToArchive(ctx context.Context, expiredBefore time.Time, limit int) ([]model.RetentionCandidate, error) {
+	rows, err := r.query.GetQuestionsToArchive(ctx, expiredBefore, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetQuestionsToArchive: %w", wrapError(err))
+	}
+	return convertRowsToDomain(rows), nil
+}
+
+func (r *questionRepo) ArchiveQuestions(ctx context.Context, questionIDs []uuid.UUID, expiredBefore time.Time) (int, error) {
+	numRows, err := r.query.ArchiveQuestions(ctx, questionIDs, expiredBefore)
+	if err != nil {
+		return 0, fmt.Errorf("QuestionRepo::ArchiveQuestions: %w", wrapError(err))
+	}
+	return int(numRows), nil
+}
+
+func (r *questionRepo) GetQuestionsToPurge(ctx context.Context, expiredBefore time.Time, limit int) ([]model.RetentionCandidate, error) {
+	rows, err := r.query.GetQuestionsToPurge(ctx, expiredBefore, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetQuestionsToPurge: %w", wrapError(err))
+	}
+
+	candidates := convertRowsToDomain(rows)
+	for i, candidate := range candidates {
+		if candidate.NumResponses == 0 {
+			continue
+		}
+		imageURLs, err := r.query.GetQuestionResponseImageURLs(ctx, candidate.QuestionID)
+		if err != nil {
+			return nil, fmt.Errorf("QuestionRepo::GetQuestionsToPurge: %w", wrapError(err))
+		}
+		candidates[i].ImageURLs = append(candidates[i].ImageURLs, imageURLs...)
+	}
+
+	return candidates, nil
+}
+
+func (r *questionRepo) PurgeQuestion(ctx context.Context, questionID uuid.UUID, expiredBefore time.Time) (bool, error) {
+	numRows, err := r.query.PurgeQuestion(ctx, questionID, expiredBefore)
+	if err != nil {
+		return false, fmt.Errorf("QuestionRepo::PurgeQuestion: %w", wrapError(err))
+	}
+	return numRows > 0, nil
+}
+
+func (r *questionRepo) CreateRating(ctx context.Context, userID string, params model.CreateRatingParams) (uuid.UUID, error) {
+	var ratingID uuid.UUID
+	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		// in a single transaction:
+		// - first, set the question content type, which fails if the question already has content
+		if err := setQuestionContentType(ctx, query, params.QuestionID, model.ContentTypeRating); err != nil {
+			return err
+		}
+
+		// - then, insert rating
+		ratingRow, err := query.CreateRating(ctx, params.QuestionID, params.MinValue, params.MaxValue, params.MinLabel, params.MaxLabel)
+		if err != nil {
+			return fmt.Errorf("CreateRating: %w", wrapError(err))
+		}
+		ratingID = ratingRow.ID
+
+		return nil
+	})
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionRepo::CreateRating: %w", err)
+	}
+
+	return ratingID, nil
+}
+
+func (r *questionRepo) AnswerRating(ctx context.Context, userID string, params model.AnswerRatingParams) error {
+	if params.Value == nil {
+		if err := r.query.DeleteRatingAnswer(ctx, params.RatingID, userID); err != nil {
+			return fmt.Errorf("QuestionRepo::AnswerRating: %w", wrapError(err))
+		}
+		return nil
+	}
+
+	// nothing is upserted if the question's author blocked the user
+	numRows, err := r.query.UpsertRatingAnswer(ctx, params.RatingID, userID, *params.Value)
+	if err != nil {
+		return fmt.Errorf("QuestionRepo::AnswerRating: %w", wrapError(err))
+	}
+	if numRows == 0 {
+		err := errs.UnauthorizedError("You cannot answer this question", fmt.Errorf("user id %s is blocked by the author of rating id %s", userID, params.RatingID))
+		return fmt.Errorf("QuestionRepo::AnswerRating: %w", err)
+	}
+	return nil
+}
+
+func (r *questionRepo) GetQuestionByRatingID(ctx context.Context, userID string, ratingID uuid.UUID) (model.Question, error) {
+	questionID, err := r.query.GetQuestionIDByRatingID(ctx, ratingID)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionRepo::GetQuestionByRatingID: %w", wrapError(err))
+	}
+
+	question, err := r.GetQuestionByID(ctx, userID, questionID)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionRepo::GetQuestionByRatingID: %w", err)
+	}
+
+	return question, nil
+}
+
+func (r *questionRepo) CreateConfirm(ctx context.Context, userID string, params model.CreateConfirmParams) (uuid.UUID, error) {
+	var confirmID uuid.UUID
+	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		// in a single transaction:
+		// - first, set the question content type, which fails if the question already has content
+		if err := setQuestionContentType(ctx, query, params.QuestionID, model.ContentTypeConfirm); err != nil {
+			return err
+		}
+
+		// - then, insert confirm
+		confirmRow, err := query.CreateConfirm(ctx, params.QuestionID)
+		if err != nil {
+			return fmt.Errorf("CreateConfirm: %w", wrapError(err))
+		}
+		confirmID = confirmRow.ID
+
+		return nil
+	})
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionRepo::CreateConfirm: %w", err)
+	}
+
+	return confirmID, nil
+}
+
+func (r *questionRepo) AnswerConfirm(ctx context.Context, userID string, params model.AnswerConfirmParams) error {
+	if params.IsYes == nil {
+		if err := r.query.DeleteConfirmAnswer(ctx, params.ConfirmID, userID); err != nil {
+			return fmt.Errorf("QuestionRepo::AnswerConfirm: %w", wrapError(err))
+		}
+		return nil
+	}
+
+	// nothing is upserted if the question's author blocked the user
+	numRows, err := r.query.UpsertConfirmAnswer(ctx, params.ConfirmID, userID, *params.IsYes)
+	if err != nil {
+		return fmt.Errorf("QuestionRepo::AnswerConfirm: %w", wrapError(err))
+	}
+	if numRows == 0 {
+		err := errs.UnauthorizedError("You cannot answer this question", fmt.Errorf("user id %s is blocked by the author of confirm id %s", userID, params.ConfirmID))
+		return fmt.Errorf("QuestionRepo::AnswerConfirm: %w", err)
+	}
+	return nil
+}
+
+func (r *questionRepo) GetQuestionByConfirmID(ctx context.Context, userID string, confirmID uuid.UUID) (model.Question, error) {
+	questionID, err := r.query.GetQuestionIDByConfirmID(ctx, confirmID)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionRepo::GetQuestionByConfirmID: %w", wrapError(err))
+	}
+
+	question, err := r.GetQuestionByID(ctx, userID, questionID)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionRepo::GetQuestionByConfirmID: %w", err)
+	}
+
+	return question, nil
+}
+
+func (r *questionRepo) GetQuestionsExpiredBetween(ctx context.Context, expiredAfter, expiredUntil time.Time) ([]model.QuestionExpiry, error) {
+	rows, err := r.query.GetQuestionsExpiredBetween(ctx, expiredAfter, expiredUntil)
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetQuestionsExpiredBetween: %w", wrapError(err))
+	}
+
+	expiries := make([]model.QuestionExpiry, 0, len(rows))
+	for _, row := range rows {
+		expiries = append(expiries, model.QuestionExpiry{
+			QuestionID: row.ID,
+			Location: model.GeoPoint{
+				Latitude:  row.Location.Location.Y,
+				Longitude: row.Location.Location.X,
+			},
+			ExpiredAt: row.ExpiredAt,
+		})
+	}
+	return expiries, nil
+}
+
+func (r *questionRepo) EditQuestion(ctx context.Context, userID string, params model.EditQuestionParams) (model.Question, []string, error) {
 	var questionRow sqlc.EditQuestionRow
+	var previousImageURLs []string
 	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
 		// in a single transaction
 		// - edit the location
@@ -168,16 +650,52 @@ func (r *questionRepo) EditQuestion(ctx context.Context, userID string, params m
 			return fmt.Errorf("EditLocation: %w", wrapError(err))
 		}
 
+		// - store the current content as a revision
+		previousImageURLs, err = query.CreateQuestionRevision(ctx, params.QuestionID)
+		if err != nil {
+			return fmt.Errorf("CreateQuestionRevision: %w", wrapError(err))
+		}
+
+		// - store the mentions of the new body, only the newly mentioned users are notified
+		previousMentionsData, err := query.GetQuestionMentions(ctx, params.QuestionID)
+		if err != nil {
+			return fmt.Errorf("GetQuestionMentions: %w", wrapError(err))
+		}
+		previousMentions, err := unmarshalMentions(previousMentionsData)
+		if err != nil {
+			return err
+		}
+		mentions, mentionsData, err := resolveMentions(ctx, query, userID, ptr.Deref(params.Body))
+		if err != nil {
+			return err
+		}
+		err = query.SetQuestionMentions(ctx, params.QuestionID, mentionsData)
+		if err != nil {
+			return fmt.Errorf("SetQuestionMentions: %w", wrapError(err))
+		}
+		err = enqueueMentionNotification(ctx, query, model.MentionPayload{
+			QuestionID: params.QuestionID,
+			AuthorID:   userID,
+		}, mentions, previousMentions)
+		if err != nil {
+			return err
+		}
+
 		// - edit the question
-		questionRow, err = query.EditQuestion(ctx, params.Title, params.Body, string(params.Category), params.QuestionID)
+		questionRow, err = query.EditQuestion(ctx, params.Title, params.Body, string(params.Category), params.ImageURLs, params.QuestionID)
 		if err != nil {
 			return fmt.Errorf("EditQuestion: %w", wrapError(err))
 		}
 
+		// - drop the removed images from the revisions, they are deleted once the edit is committed
+		if err := query.DropRemovedQuestionRevisionImages(ctx, params.QuestionID); err != nil {
+			return fmt.Errorf("DropRemovedQuestionRevisionImages: %w", wrapError(err))
+		}
+
 		return nil
 	})
 	if err != nil {
-		return model.Question{}, fmt.Errorf("QuestionRepo::EditQuestion: %w", err)
+		return model.Question{}, nil, fmt.Errorf("QuestionRepo::EditQuestion: %w", err)
 	}
 
 	question := questionRow.ToDomainModel()
@@ -185,20 +703,81 @@ func (r *questionRepo) EditQuestion(ctx context.Context, userID string, params m
 	// set question content (if applicable)
 	question.Content, err = r.getQuestionContent(ctx, question, userID)
 	if err != nil {
-		return model.Question{}, fmt.Errorf("QuestionRepo::EditQuestion: %w", err)
+		return model.Question{}, nil, fmt.Errorf("QuestionRepo::EditQuestion: %w", err)
 	}
 
-	return question, nil
+	return question, previousImageURLs, nil
+}
+
+func (r *questionRepo) GetQuestionRevisions(ctx context.Context, questionID uuid.UUID, page model.PageParams) ([]model.QuestionRevision, error) {
+	revisions, err := r.query.GetQuestionRevisions(ctx, questionID, int32(page.Offset), int32(page.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetQuestionRevisions: %w", wrapError(err))
+	}
+
+	return convertRowsToDomain(revisions), nil
 }
 
 func (r *questionRepo) DeleteQuestion(ctx context.Context, userID string, questionID uuid.UUID) error {
-	err := r.query.DeleteQuestion(ctx, questionID)
+	// soft delete, the question is hard-deleted by the purger after the grace period
+	numRows, err := r.query.SoftDeleteQuestion(ctx, questionID)
 	if err != nil {
-		return fmt.Errorf("QuestionRepo::DeleteQuestion: %w", err)
+		return fmt.Errorf("QuestionRepo::DeleteQuestion: %w", wrapError(err))
+	}
+	// already deleted, e.g. by a concurrent request
+	if numRows == 0 {
+		return fmt.Errorf("QuestionRepo::DeleteQuestion: %w", errs.Error{
+			Type:     errs.TypeNotFound,
+			Message:  "This question does not exist",
+			Internal: fmt.Errorf("question id %s is already deleted", questionID),
+		})
 	}
 	return nil
 }
 
+func (r *questionRepo) GetDeletedQuestionByID(ctx context.Context, questionID uuid.UUID) (model.DeletedContent, error) {
+	row, err := r.query.GetDeletedQuestionByID(ctx, questionID)
+	if err != nil {
+		return model.DeletedContent{}, fmt.Errorf("QuestionRepo::GetDeletedQuestionByID: %w", wrapError(err))
+	}
+	return row.ToDomainModel(), nil
+}
+
+func (r *questionRepo) RestoreQuestion(ctx context.Context, questionID uuid.UUID, deletedAfter time.Time) (bool, error) {
+	numRows, err := r.query.RestoreQuestion(ctx, questionID, deletedAfter)
+	if err != nil {
+		return false, fmt.Errorf("QuestionRepo::RestoreQuestion: %w", wrapError(err))
+	}
+	return numRows > 0, nil
+}
+
+func (r *questionRepo) GetQuestionsDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int) ([]model.DeletedContent, error) {
+	rows, err := r.query.GetQuestionsDeletedBefore(ctx, deletedBefore, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetQuestionsDeletedBefore: %w", wrapError(err))
+	}
+
+	// the images of the responses are deleted along with the question
+	questions := convertRowsToDomain(rows)
+	for i, question := range questions {
+		imageURLs, err := r.query.GetQuestionResponseImageURLs(ctx, question.ID)
+		if err != nil {
+			return nil, fmt.Errorf("QuestionRepo::GetQuestionsDeletedBefore: %w", wrapError(err))
+		}
+		questions[i].ImageURLs = append(questions[i].ImageURLs, imageURLs...)
+	}
+
+	return questions, nil
+}
+
+func (r *questionRepo) HardDeleteQuestion(ctx context.Context, questionID uuid.UUID, deletedBefore time.Time) (bool, error) {
+	numRows, err := r.query.HardDeleteQuestion(ctx, questionID, deletedBefore)
+	if err != nil {
+		return false, fmt.Errorf("QuestionRepo::HardDeleteQuestion: %w", wrapError(err))
+	}
+	return numRows > 0, nil
+}
+
 func (r *questionRepo) GetQuestionsInRadiusFeed(
 	ctx context.Context,
 	userID string,
@@ -259,6 +838,25 @@ func (r *questionRepo) GetQuestionsInRadiusFeed(
 	}
 }
 
+// getQuestionsContent sets the content of each question (parallelized)
+func (r *questionRepo) getQuestionsContent(ctx context.Context, questions []model.Question, userID string) error {
+	grouper, gCtx := errgroup.WithContext(ctx)
+	for i := range questions {
+		grouper.Go(func() error {
+			content, err := r.getQuestionContent(gCtx, questions[i], userID)
+			if err != nil {
+				return err
+			}
+			questions[i].Content = content
+			return nil
+		})
+	}
+	if err := grouper.Wait(); err != nil {
+		return fmt.Errorf("getQuestionsContent: grouper err: %w", err)
+	}
+	return nil
+}
+
 func (r *questionRepo) getQuestionContent(ctx context.Context, question model.Question, userID string) (model.QuestionContent, error) {
 	content := model.QuestionContent{
 		Type: question.Content.Type,
@@ -271,6 +869,18 @@ func (r *questionRepo) getQuestionContent(ctx context.Context, question model.Qu
 			return content, fmt.Errorf("getQuestionContent: %w", err)
 		}
 		content.Data = poll
+	case model.ContentTypeRating:
+		rating, err := r.getRating(ctx, question, userID)
+		if err != nil {
+			return content, fmt.Errorf("getQuestionContent: %w", err)
+		}
+		content.Data = rating
+	case model.ContentTypeConfirm:
+		confirm, err := r.getConfirm(ctx, question, userID)
+		if err != nil {
+			return content, fmt.Errorf("getQuestionContent: %w", err)
+		}
+		content.Data = confirm
 	default:
 		content.Data = nil
 	}
@@ -285,10 +895,29 @@ func (r *questionRepo) getPoll(ctx context.Context, question model.Question, use
 		return model.Poll{}, fmt.Errorf("getPoll:GetPollByQuestionID: %w", wrapError(err))
 	}
 	poll := model.Poll{
-		ID:         pollRow.Poll.ID,
-		QuestionID: pollRow.Poll.QuestionID,
-		CreatedAt:  pollRow.Poll.CreatedAt,
-		ExpiredAt:  question.ExpiredAt,
+		ID:                pollRow.Poll.ID,
+		QuestionID:        pollRow.Poll.QuestionID,
+		Type:              model.PollType(pollRow.Poll.Type),
+		MaxSelections:     pollRow.Poll.MaxSelections,
+		AllowOther:        pollRow.Poll.AllowOther,
+		ResultsVisibility: model.PollResultsVisibility(pollRow.Poll.ResultsVisibility),
+		IsAnonymous:       pollRow.Poll.IsAnonymous,
+		OtherVotes:        []model.PollOther{},
+		ClosesAt:          pollRow.Poll.ClosesAt,
+		ClosedAt:          pollRow.Poll.ClosedAt,
+		CreatedAt:         pollRow.Poll.CreatedAt,
+		ExpiredAt:         question.ExpiredAt,
+	}
+	if poll.ClosesAt != nil && poll.ClosesAt.Before(poll.ExpiredAt) {
+		poll.ExpiredAt = *poll.ClosesAt
+	}
+	if poll.ClosedAt != nil && poll.ClosedAt.Before(poll.ExpiredAt) {
+		poll.ExpiredAt = *poll.ClosedAt
+	}
+	if pollRow.Poll.FinalResults != nil {
+		if err := json.Unmarshal(pollRow.Poll.FinalResults, &poll.FinalResults); err != nil {
+			return model.Poll{}, fmt.Errorf("getPoll: could not unmarshal final results: %w", err)
+		}
 	}
 
 	// get poll options
@@ -313,13 +942,133 @@ func (r *questionRepo) getPoll(ctx context.Context, question model.Question, use
 	for _, row := range pollVoteRows {
 		pollOptions[row.Index].NumVotes = row.NumVotes
 		pollOptions[row.Index].IsSelected = row.IsSelected
+		if row.UserRank > 0 {
+			pollOptions[row.Index].Rank = ptr.To(row.UserRank)
+		}
 		poll.NumTotalVotes += row.NumVotes
 	}
 	poll.Options = pollOptions
 
+	// get "other" votes
+	if poll.AllowOther {
+		otherVoteRows, err := r.query.GetPollOtherVotes(ctx, poll.ID, userID)
+		if err != nil {
+			return model.Poll{}, fmt.Errorf("getPoll:GetPollOtherVotes: %w", wrapError(err))
+		}
+		for _, row := range otherVoteRows {
+			poll.OtherVotes = append(poll.OtherVotes, model.PollOther{
+				Text:  row.OtherText,
+				IsOwn: row.IsOwn,
+			})
+		}
+		poll.NumTotalVotes += len(otherVoteRows)
+	}
+
+	poll.NumVoters, err = r.query.GetPollNumVoters(ctx, poll.ID)
+	if err != nil {
+		return model.Poll{}, fmt.Errorf("getPoll:GetPollNumVoters: %w", wrapError(err))
+	}
+
+	// count ranked choice ballots
+	if poll.Type == model.PollTypeRankedChoice {
+		ballotRows, err := r.query.GetPollBallots(ctx, poll.ID)
+		if err != nil {
+			return model.Poll{}, fmt.Errorf("getPoll:GetPollBallots: %w", wrapError(err))
+		}
+
+		// rows are ordered by user, so every ballot is a consecutive run of rows
+		var ballots [][]uuid.UUID
+		for i, row := range ballotRows {
+			if i == 0 || ballotRows[i-1].UserID != row.UserID {
+				ballots = append(ballots, nil)
+			}
+			ballots[len(ballots)-1] = append(ballots[len(ballots)-1], row.OptionID)
+		}
+
+		optionIDs := make([]uuid.UUID, 0, len(pollOptions))
+		for _, option := range pollOptions {
+			optionIDs = append(optionIDs, option.ID)
+		}
+		runoff := model.InstantRunoff(optionIDs, ballots)
+		poll.Runoff = &runoff
+	}
+
+	// only keep the user's own votes if they may not see the results yet
+	if !poll.CanSeeResults(question.IsOwned) {
+		poll.HideResults()
+	}
+
 	return poll, nil
 }
 
+func (r *questionRepo) getRating(ctx context.Context, question model.Question, userID string) (model.Rating, error) {
+	// get rating
+	ratingRow, err := r.query.GetRatingByQuestionID(ctx, question.ID)
+	if err != nil {
+		return model.Rating{}, fmt.Errorf("getRating:GetRatingByQuestionID: %w", wrapError(err))
+	}
+	rating := model.Rating{
+		ID:         ratingRow.Rating.ID,
+		QuestionID: ratingRow.Rating.QuestionID,
+		MinValue:   ratingRow.Rating.MinValue,
+		MaxValue:   ratingRow.Rating.MaxValue,
+		MinLabel:   ratingRow.Rating.MinLabel,
+		MaxLabel:   ratingRow.Rating.MaxLabel,
+		CreatedAt:  ratingRow.Rating.CreatedAt,
+		ExpiredAt:  question.ExpiredAt,
+	}
+
+	// get rating answers
+	histogramRows, err := r.query.GetRatingHistogram(ctx, rating.ID, userID)
+	if err != nil {
+		return model.Rating{}, fmt.Errorf("getRating:GetRatingHistogram: %w", wrapError(err))
+	}
+	answers := make([]model.RatingBucket, 0, len(histogramRows))
+	for _, row := range histogramRows {
+		answers = append(answers, model.RatingBucket{Value: row.Value, NumAnswers: row.NumAnswers})
+		if row.IsSelected {
+			rating.UserValue = ptr.To(row.Value)
+		}
+	}
+	rating.TallyAnswers(answers)
+
+	return rating, nil
+}
+
+func (r *questionRepo) getConfirm(ctx context.Context, question model.Question, userID string) (model.Confirm, error) {
+	// get confirm
+	confirmRow, err := r.query.GetConfirmByQuestionID(ctx, question.ID)
+	if err != nil {
+		return model.Confirm{}, fmt.Errorf("getConfirm:GetConfirmByQuestionID: %w", wrapError(err))
+	}
+	confirm := model.Confirm{
+		ID:         confirmRow.Confirm.ID,
+		QuestionID: confirmRow.Confirm.QuestionID,
+		CreatedAt:  confirmRow.Confirm.CreatedAt,
+		ExpiredAt:  question.ExpiredAt,
+	}
+
+	// get confirm answers
+	tallyRow, err := r.query.GetConfirmTally(ctx, model.ConfirmHalfLife.Seconds(), confirm.ID)
+	if err != nil {
+		return model.Confirm{}, fmt.Errorf("getConfirm:GetConfirmTally: %w", wrapError(err))
+	}
+	confirm.NumYes = tallyRow.NumYes
+	confirm.NumNo = tallyRow.NumNo
+	confirm.YesConfidence = model.ConfirmConfidence(tallyRow.YesWeight, tallyRow.NoWeight)
+
+	// get the user's answer
+	isYes, err := r.query.GetConfirmAnswer(ctx, confirm.ID, userID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return model.Confirm{}, fmt.Errorf("getConfirm:GetConfirmAnswer: %w", wrapError(err))
+	}
+	if err == nil {
+		confirm.UserAnswer = &isYes
+	}
+
+	return confirm, nil
+}
+
 
 
 func (r *questionRepo) GetQuestionsByUserID(
@@ -342,6 +1091,11 @@ func (r *questionRepo) GetQuestionsByUserID(
         questions[i] = row.ToDomainModel()
     }
 
+    // the poll, rating and confirm content is part of the data export too
+    if err := r.getQuestionsContent(ctx, questions, userID); err != nil {
+        return nil, fmt.Errorf("QuestionRepo::GetQuestionsByUserID: %w", err)
+    }
+
     return questions, nil
 }
 
@@ -374,4 +1128,70 @@ func (r *questionRepo) GetQuestionsRespondedByUserID(
 	}
 
 	return result, nil
-}
\ No newline at end of file
+}
+
+func (r *questionRepo) GetQuestionsFromFollowedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error) {
+	rows, err := r.query.GetQuestionsFromFollowedUsers(ctx, userID, int32(page.Offset), int32(page.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetQuestionsFromFollowedUsers: %w", wrapError(err))
+	}
+
+	questions := convertRowsToDomain(rows)
+	if err := r.getQuestionsContent(ctx, questions, userID); err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetQuestionsFromFollowedUsers: %w", err)
+	}
+
+	return questions, nil
+}
+
+func (r *questionRepo) BookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID, notify bool) error {
+	err := r.query.BookmarkQuestion(ctx, userID, questionID, notify)
+	if err != nil {
+		return fmt.Errorf("QuestionRepo::BookmarkQuestion: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *questionRepo) UnbookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID) error {
+	err := r.query.UnbookmarkQuestion(ctx, userID, questionID)
+	if err != nil {
+		return fmt.Errorf("QuestionRepo::UnbookmarkQuestion: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *questionRepo) GetBookmarkedQuestions(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error) {
+	rows, err := r.query.GetBookmarkedQuestions(ctx, userID, int32(page.Offset), int32(page.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetBookmarkedQuestions: %w", wrapError(err))
+	}
+
+	questions := convertRowsToDomain(rows)
+	if err := r.getQuestionsContent(ctx, questions, userID); err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetBookmarkedQuestions: %w", err)
+	}
+
+	return questions, nil
+}
+
+func (r *questionRepo) GetBookmarkSubscriberIDs(ctx context.Context, questionID uuid.UUID) ([]string, error) {
+	userIDs, err := r.query.GetBookmarkSubscriberIDs(ctx, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetBookmarkSubscriberIDs: %w", wrapError(err))
+	}
+	return userIDs, nil
+}
+
+func (r *questionRepo) GetQuestionsByAuthorID(ctx context.Context, userID string, authorID string, page model.PageParams) ([]model.Question, error) {
+	rows, err := r.query.GetPublicQuestionsByAuthorID(ctx, userID, authorID, int32(page.Offset), int32(page.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetQuestionsByAuthorID: %w", wrapError(err))
+	}
+
+	questions := convertRowsToDomain(rows)
+	if err := r.getQuestionsContent(ctx, questions, userID); err != nil {
+		return nil, fmt.Errorf("QuestionRepo::GetQuestionsByAuthorID: %w", err)
+	}
+
+	return questions, nil
+}
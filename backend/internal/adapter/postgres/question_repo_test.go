@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ksha23/CS407-FactSnap/internal/adapter/postgres/sqlc"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/errs"
+)
+
+// fakeDBTX answers every statement with the same command tag, other methods are not implemented
+type fakeDBTX struct {
+	sqlc.DBTX
+	tag  pgconn.CommandTag
+	err  error
+	args []any
+}
+
+func (db *fakeDBTX) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	db.args = args
+	return db.tag, db.err
+}
+
+func TestSetQuestionContentType(t *testing.T) {
+	questionID := uuid.New()
+
+	tests := []struct {
+		name     string
+		db       *fakeDBTX
+		wantErr  bool
+		wantType errs.Type
+	}{
+		{
+			name: "question without content",
+			db:   &fakeDBTX{tag: pgconn.NewCommandTag("UPDATE 1")},
+		},
+		{
+			// the update only matches questions whose content type is still None
+			name:     "content already set",
+			db:       &fakeDBTX{tag: pgconn.NewCommandTag("UPDATE 0")},
+			wantErr:  true,
+			wantType: errs.TypeUnauthorized,
+		},
+		{
+			name:    "database error",
+			db:      &fakeDBTX{err: errors.New("connection reset")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := setQuestionContentType(context.Background(), sqlc.New(tt.db), questionID, model.ContentTypeRating)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("setQuestionContentType() error = %v, want error %t", err, tt.wantErr)
+			}
+			if errs.ErrType(err) != tt.wantType {
+				t.Errorf("setQuestionContentType() error type = %q, want %q", errs.ErrType(err), tt.wantType)
+			}
+
+			if len(tt.db.args) != 2 || tt.db.args[0] != string(model.ContentTypeRating) || tt.db.args[1] != questionID {
+				t.Errorf("SetQuestionContentType args = %v, want [%s %s]", tt.db.args, model.ContentTypeRating, questionID)
+			}
+		})
+	}
+}
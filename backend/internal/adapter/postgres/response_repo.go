@@ -2,12 +2,16 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/ksha23/CS407-FactSnap/internal/adapter/postgres/sqlc"
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/errs"
 )
 
 type responseRepo struct {
@@ -26,17 +30,50 @@ func (r *responseRepo) CreateResponse(ctx context.Context, userID string, params
 	var responseRow sqlc.CreateResponseRow
 	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
 		// create response
-		row, err := r.query.CreateResponse(ctx, userID, params.QuestionID, params.Body, params.ImageURLs)
+		// nothing is created if the question's author blocked the user
+		row, err := query.CreateResponse(ctx, userID, params.QuestionID, params.Body, params.ImageURLs)
 		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errs.UnauthorizedError("You cannot respond to this question", fmt.Errorf("user id %s is blocked by the author of question id %s", userID, params.QuestionID))
+			}
 			return fmt.Errorf("CreateResponse: %w", wrapError(err))
 		}
 
 		// increment response amount for the question
-		err = r.query.IncrementResponseAmount(ctx, params.QuestionID)
+		err = query.IncrementResponseAmount(ctx, params.QuestionID)
 		if err != nil {
 			return fmt.Errorf("IncrementResponseAmount: %w", wrapError(err))
 		}
 
+		// notify the users that bookmarked the question
+		err = enqueueOutboxMessage(ctx, query, model.OutboxMessageTypeNewResponse, model.NewResponsePayload{
+			QuestionID: params.QuestionID,
+			ResponseID: row.ID,
+			AuthorID:   userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// store the mentions of the body and notify the mentioned users
+		mentions, mentionsData, err := resolveMentions(ctx, query, userID, params.Body)
+		if err != nil {
+			return err
+		}
+		err = query.SetResponseMentions(ctx, row.ID, mentionsData)
+		if err != nil {
+			return fmt.Errorf("SetResponseMentions: %w", wrapError(err))
+		}
+		row.Mentions = mentionsData
+		err = enqueueMentionNotification(ctx, query, model.MentionPayload{
+			QuestionID: params.QuestionID,
+			ResponseID: &row.ID,
+			AuthorID:   userID,
+		}, mentions, nil)
+		if err != nil {
+			return err
+		}
+
 		responseRow = row
 		return nil
 	})
@@ -56,21 +93,86 @@ func (r *responseRepo) GetResponsesByQuestionID(ctx context.Context, userID stri
 	return convertRowsToDomain(responses), nil
 }
 
-func (r *responseRepo) EditResponse(ctx context.Context, userID string, params model.EditResponseParams) (model.Response, error) {
-	row, err := r.query.EditResponse(ctx, params.Body, params.ResponseID)
+func (r *responseRepo) EditResponse(ctx context.Context, userID string, params model.EditResponseParams) (model.Response, []string, error) {
+	var row sqlc.EditResponseRow
+	var previousImageURLs []string
+	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		// store the current content as a revision before editing it
+		var err error
+		previousImageURLs, err = query.CreateResponseRevision(ctx, params.ResponseID)
+		if err != nil {
+			return fmt.Errorf("CreateResponseRevision: %w", wrapError(err))
+		}
+
+		// store the mentions of the new body, only the newly mentioned users are notified
+		previousMentionsData, err := query.GetResponseMentions(ctx, params.ResponseID)
+		if err != nil {
+			return fmt.Errorf("GetResponseMentions: %w", wrapError(err))
+		}
+		previousMentions, err := unmarshalMentions(previousMentionsData)
+		if err != nil {
+			return err
+		}
+		mentions, mentionsData, err := resolveMentions(ctx, query, userID, params.Body)
+		if err != nil {
+			return err
+		}
+		err = query.SetResponseMentions(ctx, params.ResponseID, mentionsData)
+		if err != nil {
+			return fmt.Errorf("SetResponseMentions: %w", wrapError(err))
+		}
+
+		row, err = query.EditResponse(ctx, params.Body, params.ImageURLs, params.ResponseID)
+		if err != nil {
+			return fmt.Errorf("EditResponse: %w", wrapError(err))
+		}
+
+		// drop the removed images from the revisions, they are deleted once the edit is committed
+		if err := query.DropRemovedResponseRevisionImages(ctx, params.ResponseID); err != nil {
+			return fmt.Errorf("DropRemovedResponseRevisionImages: %w", wrapError(err))
+		}
+
+		err = enqueueMentionNotification(ctx, query, model.MentionPayload{
+			QuestionID: row.QuestionID,
+			ResponseID: &row.ID,
+			AuthorID:   userID,
+		}, mentions, previousMentions)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
 	if err != nil {
-		return model.Response{}, fmt.Errorf("ResponseRepo::EditResponse: %w", wrapError(err))
+		return model.Response{}, nil, fmt.Errorf("ResponseRepo::EditResponse: %w", err)
 	}
 
-	return row.ToDomainModel(), nil
+	return row.ToDomainModel(), previousImageURLs, nil
+}
+
+func (r *responseRepo) GetResponseRevisions(ctx context.Context, responseID uuid.UUID, page model.PageParams) ([]model.ResponseRevision, error) {
+	revisions, err := r.query.GetResponseRevisions(ctx, responseID, int32(page.Offset), int32(page.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("ResponseRepo::GetResponseRevisions: %w", wrapError(err))
+	}
+
+	return convertRowsToDomain(revisions), nil
 }
 
 func (r *responseRepo) DeleteResponse(ctx context.Context, userID string, questionID uuid.UUID, responseID uuid.UUID) error {
 	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
-		// delete response
-		err := query.DeleteResponse(ctx, responseID)
+		// soft delete response, it is hard-deleted by the purger after the grace period
+		numRows, err := query.SoftDeleteResponse(ctx, responseID)
 		if err != nil {
-			return fmt.Errorf("DeleteResponse: %w", wrapError(err))
+			return fmt.Errorf("SoftDeleteResponse: %w", wrapError(err))
+		}
+		// already deleted, e.g. by a concurrent request
+		if numRows == 0 {
+			return errs.Error{
+				Type:     errs.TypeNotFound,
+				Message:  "This response does not exist",
+				Internal: fmt.Errorf("response id %s is already deleted", responseID),
+			}
 		}
 
 		// decrement response amount by one for question
@@ -88,6 +190,59 @@ func (r *responseRepo) DeleteResponse(ctx context.Context, userID string, questi
 	return nil
 }
 
+func (r *responseRepo) GetDeletedResponseByID(ctx context.Context, responseID uuid.UUID) (model.DeletedContent, error) {
+	row, err := r.query.GetDeletedResponseByID(ctx, responseID)
+	if err != nil {
+		return model.DeletedContent{}, fmt.Errorf("ResponseRepo::GetDeletedResponseByID: %w", wrapError(err))
+	}
+	return row.ToDomainModel(), nil
+}
+
+func (r *responseRepo) RestoreResponse(ctx context.Context, response model.DeletedContent, deletedAfter time.Time) (bool, error) {
+	restored := false
+	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		// restore response
+		numRows, err := query.RestoreResponse(ctx, response.ID, deletedAfter)
+		if err != nil {
+			return fmt.Errorf("RestoreResponse: %w", wrapError(err))
+		}
+		// the grace period has passed meanwhile
+		if numRows == 0 {
+			return nil
+		}
+
+		// increment response amount for the question again
+		err = query.IncrementResponseAmount(ctx, response.QuestionID)
+		if err != nil {
+			return fmt.Errorf("IncrementResponseAmount: %w", wrapError(err))
+		}
+
+		restored = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("ResponseRepo::RestoreResponse: %w", err)
+	}
+
+	return restored, nil
+}
+
+func (r *responseRepo) GetResponsesDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int) ([]model.DeletedContent, error) {
+	rows, err := r.query.GetResponsesDeletedBefore(ctx, deletedBefore, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("ResponseRepo::GetResponsesDeletedBefore: %w", wrapError(err))
+	}
+	return convertRowsToDomain(rows), nil
+}
+
+func (r *responseRepo) HardDeleteResponse(ctx context.Context, responseID uuid.UUID, deletedBefore time.Time) (bool, error) {
+	numRows, err := r.query.HardDeleteResponse(ctx, responseID, deletedBefore)
+	if err != nil {
+		return false, fmt.Errorf("ResponseRepo::HardDeleteResponse: %w", wrapError(err))
+	}
+	return numRows > 0, nil
+}
+
 func (r *responseRepo) GetResponseByID(ctx context.Context, userID string, responseID uuid.UUID) (model.Response, error) {
 	row, err := r.query.GetResponseByID(ctx, userID, responseID)
 	if err != nil {
@@ -101,3 +256,12 @@ func (r *responseRepo) GetResponseByID(ctx context.Context, userID string, respo
 //	//TODO implement me
 //	panic("implement me")
 //}
+
+func (r *responseRepo) GetResponsesByAuthorID(ctx context.Context, userID string, authorID string, page model.PageParams) ([]model.Response, error) {
+	responses, err := r.query.GetPublicResponsesByAuthorID(ctx, userID, authorID, int32(page.Offset), int32(page.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("ResponseRepo::GetResponsesByAuthorID: %w", wrapError(err))
+	}
+
+	return convertRowsToDomain(responses), nil
+}
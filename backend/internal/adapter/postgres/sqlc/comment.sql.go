@@ -0,0 +1,270 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: comment.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createComment = `-- name: CreateComment :one
+WITH new_comment AS (
+    INSERT INTO comments (
+        author_id,
+        response_id,
+        body
+    )
+    VALUES ($1, $2, $3)
+    RETURNING id, author_id, response_id, body, created_at, edited_at
+)
+SELECT
+    nc.id, nc.author_id, nc.response_id, nc.body, nc.created_at, nc.edited_at,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    TRUE AS is_owned
+FROM
+    new_comment nc
+    JOIN users u ON nc.author_id = u.id
+`
+
+type CreateCommentRow struct {
+	ID         uuid.UUID
+	AuthorID   string
+	ResponseID uuid.UUID
+	Body       string
+	CreatedAt  time.Time
+	EditedAt   time.Time
+	User       User
+	IsOwned    bool
+}
+
+func (q *Queries) CreateComment(ctx context.Context, authorID string, responseID uuid.UUID, body string) (CreateCommentRow, error) {
+	row := q.db.QueryRow(ctx, createComment, authorID, responseID, body)
+	var i CreateCommentRow
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.ResponseID,
+		&i.Body,
+		&i.CreatedAt,
+		&i.EditedAt,
+		&i.User.ID,
+		&i.User.Username,
+		&i.User.Email,
+		&i.User.DisplayName,
+		&i.User.Role,
+		&i.User.AboutMe,
+		&i.User.AvatarUrl,
+		&i.User.CreatedAt,
+		&i.User.LastKnownLocation,
+		&i.User.UsernameChangedAt,
+		&i.IsOwned,
+	)
+	return i, err
+}
+
+const decrementReplyCount = `-- name: DecrementReplyCount :exec
+UPDATE responses
+SET num_replies = GREATEST(num_replies - 1, 0)
+WHERE id = $1
+`
+
+func (q *Queries) DecrementReplyCount(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, decrementReplyCount, id)
+	return err
+}
+
+const deleteComment = `-- name: DeleteComment :execrows
+DELETE FROM comments
+WHERE id = $1
+`
+
+func (q *Queries) DeleteComment(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteComment, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const editComment = `-- name: EditComment :one
+WITH edited_comment AS (
+    UPDATE comments
+    SET
+        body = $1,
+        edited_at = current_timestamp
+    WHERE comments.id = $2
+    RETURNING id, author_id, response_id, body, created_at, edited_at
+)
+SELECT
+    ec.id, ec.author_id, ec.response_id, ec.body, ec.created_at, ec.edited_at,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    TRUE AS is_owned
+FROM
+    edited_comment ec
+    JOIN users u ON ec.author_id = u.id
+`
+
+type EditCommentRow struct {
+	ID         uuid.UUID
+	AuthorID   string
+	ResponseID uuid.UUID
+	Body       string
+	CreatedAt  time.Time
+	EditedAt   time.Time
+	User       User
+	IsOwned    bool
+}
+
+func (q *Queries) EditComment(ctx context.Context, body string, iD uuid.UUID) (EditCommentRow, error) {
+	row := q.db.QueryRow(ctx, editComment, body, iD)
+	var i EditCommentRow
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.ResponseID,
+		&i.Body,
+		&i.CreatedAt,
+		&i.EditedAt,
+		&i.User.ID,
+		&i.User.Username,
+		&i.User.Email,
+		&i.User.DisplayName,
+		&i.User.Role,
+		&i.User.AboutMe,
+		&i.User.AvatarUrl,
+		&i.User.CreatedAt,
+		&i.User.LastKnownLocation,
+		&i.User.UsernameChangedAt,
+		&i.IsOwned,
+	)
+	return i, err
+}
+
+const getCommentByID = `-- name: GetCommentByID :one
+SELECT
+    c.id, c.author_id, c.response_id, c.body, c.created_at, c.edited_at,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    c.author_id = $1 AS is_owned
+FROM
+    comments c
+    JOIN users u ON c.author_id = u.id
+WHERE
+    c.id = $2
+    LIMIT 1
+`
+
+type GetCommentByIDRow struct {
+	Comment Comment
+	User    User
+	IsOwned bool
+}
+
+func (q *Queries) GetCommentByID(ctx context.Context, userID string, iD uuid.UUID) (GetCommentByIDRow, error) {
+	row := q.db.QueryRow(ctx, getCommentByID, userID, iD)
+	var i GetCommentByIDRow
+	err := row.Scan(
+		&i.Comment.ID,
+		&i.Comment.AuthorID,
+		&i.Comment.ResponseID,
+		&i.Comment.Body,
+		&i.Comment.CreatedAt,
+		&i.Comment.EditedAt,
+		&i.User.ID,
+		&i.User.Username,
+		&i.User.Email,
+		&i.User.DisplayName,
+		&i.User.Role,
+		&i.User.AboutMe,
+		&i.User.AvatarUrl,
+		&i.User.CreatedAt,
+		&i.User.LastKnownLocation,
+		&i.User.UsernameChangedAt,
+		&i.IsOwned,
+	)
+	return i, err
+}
+
+const getCommentsByResponseID = `-- name: GetCommentsByResponseID :many
+SELECT
+    c.id, c.author_id, c.response_id, c.body, c.created_at, c.edited_at,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    c.author_id = $1 AS is_owned
+FROM
+    comments c
+    JOIN users u ON c.author_id = u.id
+WHERE
+    c.response_id = $2 AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = $1 AND b.blocked_id = c.author_id)
+           OR (b.blocker_id = c.author_id AND b.blocked_id = $1)
+    )
+ORDER BY c.created_at, c.id
+LIMIT $4 OFFSET $3
+`
+
+type GetCommentsByResponseIDRow struct {
+	Comment Comment
+	User    User
+	IsOwned bool
+}
+
+// comments read as a conversation, so the oldest come first
+func (q *Queries) GetCommentsByResponseID(ctx context.Context, userID string, responseID uuid.UUID, offsetNum int32, limitNum int32) ([]GetCommentsByResponseIDRow, error) {
+	rows, err := q.db.Query(ctx, getCommentsByResponseID,
+		userID,
+		responseID,
+		offsetNum,
+		limitNum,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCommentsByResponseIDRow{}
+	for rows.Next() {
+		var i GetCommentsByResponseIDRow
+		if err := rows.Scan(
+			&i.Comment.ID,
+			&i.Comment.AuthorID,
+			&i.Comment.ResponseID,
+			&i.Comment.Body,
+			&i.Comment.CreatedAt,
+			&i.Comment.EditedAt,
+			&i.User.ID,
+			&i.User.Username,
+			&i.User.Email,
+			&i.User.DisplayName,
+			&i.User.Role,
+			&i.User.AboutMe,
+			&i.User.AvatarUrl,
+			&i.User.CreatedAt,
+			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
+			&i.IsOwned,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const incrementReplyCount = `-- name: IncrementReplyCount :exec
+UPDATE responses
+SET num_replies = num_replies + 1
+WHERE id = $1
+`
+
+func (q *Queries) IncrementReplyCount(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, incrementReplyCount, id)
+	return err
+}
@@ -0,0 +1,53 @@
+package sqlc
+
+import (
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+)
+
+func (row CreateCommentRow) ToDomainModel() model.Comment {
+	return model.Comment{
+		ID:         row.ID,
+		ResponseID: row.ResponseID,
+		Author:     toDomainUser(row.User),
+		Body:       row.Body,
+		IsOwned:    row.IsOwned,
+		CreatedAt:  row.CreatedAt,
+		EditedAt:   row.EditedAt,
+	}
+}
+
+func (row EditCommentRow) ToDomainModel() model.Comment {
+	return model.Comment{
+		ID:         row.ID,
+		ResponseID: row.ResponseID,
+		Author:     toDomainUser(row.User),
+		Body:       row.Body,
+		IsOwned:    row.IsOwned,
+		CreatedAt:  row.CreatedAt,
+		EditedAt:   row.EditedAt,
+	}
+}
+
+func (row GetCommentByIDRow) ToDomainModel() model.Comment {
+	return model.Comment{
+		ID:         row.Comment.ID,
+		ResponseID: row.Comment.ResponseID,
+		Author:     toDomainUser(row.User),
+		Body:       row.Comment.Body,
+		IsOwned:    row.IsOwned,
+		CreatedAt:  row.Comment.CreatedAt,
+		EditedAt:   row.Comment.EditedAt,
+	}
+}
+
+func (row GetCommentsByResponseIDRow) ToDomainModel() model.Comment {
+	return model.Comment{
+		ID:         row.Comment.ID,
+		ResponseID: row.Comment.ResponseID,
+		Author:     toDomainUser(row.User),
+		Body:       row.Comment.Body,
+		IsOwned:    row.IsOwned,
+		CreatedAt:  row.Comment.CreatedAt,
+		EditedAt:   row.Comment.EditedAt,
+	}
+}
@@ -1,6 +1,8 @@
 package sqlc
 
 import (
+	"encoding/json"
+
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 )
 
@@ -9,7 +11,20 @@ type DomainConverter[T any] interface {
 	ToDomainModel() T
 }
 
+// toDomainUser converts the public fields of the user, see toDomainAuthUser for the private ones
 func toDomainUser(user User) model.User {
+	return model.User{
+		ID:          user.ID,
+		Username:    user.Username,
+		AvatarURL:   user.AvatarUrl,
+		AboutMe:     user.AboutMe,
+		DisplayName: user.DisplayName,
+		Role:        model.Role(user.Role),
+		CreatedAt:   user.CreatedAt,
+	}
+}
+
+func toDomainAuthUser(user User) model.AuthUser {
 	var loc *model.GeoPoint
 	if user.LastKnownLocation != nil {
 		loc = &model.GeoPoint{
@@ -18,24 +33,21 @@ func toDomainUser(user User) model.User {
 		}
 	}
 
-	return model.User{
-		ID:                user.ID,
-		Username:          user.Username,
-		AvatarURL:         user.AvatarUrl,
-		AboutMe:           user.AboutMe,
-		DisplayName:       user.DisplayName,
-		Role:              model.Role(user.Role),
-		CreatedAt:         user.CreatedAt,
-		ExpoPushToken:     user.ExpoPushToken,
+	return model.AuthUser{
+		User:              toDomainUser(user),
+		Email:             user.Email,
 		LastKnownLocation: loc,
+		UsernameChangedAt: user.UsernameChangedAt,
 	}
 }
 
-func toDomainAuthUser(user User) model.AuthUser {
-	return model.AuthUser{
-		User:  toDomainUser(user),
-		Email: user.Email,
+// toDomainMentions decodes the mentions column, which is only ever written from a marshalled []model.Mention
+func toDomainMentions(raw []byte) []model.Mention {
+	mentions := []model.Mention{}
+	if err := json.Unmarshal(raw, &mentions); err != nil {
+		return []model.Mention{}
 	}
+	return mentions
 }
 
 func toDomainLocation(location Location) model.Location {
@@ -48,54 +60,10 @@ func toDomainLocation(location Location) model.Location {
 	}
 }
 
-// NEW: row -> domain Question
 func (row GetQuestionsByUserIDRow) ToDomainModel() model.Question {
-    return model.Question{
-        ID:     row.Question.ID,
-        Author: toDomainUser(row.User),
-
-        Title: row.Question.Title,
-        Body:  row.Question.Body,
-
-        Category: model.Category(row.Question.Category),
-        Content: model.QuestionContent{
-            Type: model.ContentType(row.Question.ContentType),
-            Data: nil, // 内容（Poll 等）由仓库层单独补充
-        },
-
-        Location:        toDomainLocation(row.Location),
-        ImageURLs:       row.Question.ImageUrls,
-        IsOwned:         row.IsOwned,
-        ResponsesAmount: row.Question.NumResponses,
-        CreatedAt:       row.Question.CreatedAt,
-        EditedAt:        row.Question.EditedAt,
-        ExpiredAt:       row.Question.ExpiredAt,
-    }
+	return toDomainQuestion(row.Question, row.Location, row.User, row.IsOwned, row.IsBookmarked)
 }
 
-
 func (row GetQuestionsRespondedByUserIDRow) ToDomainModel() model.Question {
-	return model.Question{
-		ID:    row.Question.ID,
-		Author: toDomainUser(row.User),
-
-		Title: row.Question.Title,
-		Body:  row.Question.Body,
-
-		Category: model.Category(row.Question.Category),
-		Content: model.QuestionContent{
-			Type: model.ContentType(row.Question.ContentType),
-			Data: nil,
-		},
-
-		Location: toDomainLocation(row.Location),
-
-		ResponsesAmount: row.Question.NumResponses,
-		ImageURLs:       row.Question.ImageUrls,
-		IsOwned:         row.IsOwned,
-
-		CreatedAt: row.Question.CreatedAt,
-		EditedAt:  row.Question.EditedAt,
-		ExpiredAt: row.Question.ExpiredAt,
-	}
+	return toDomainQuestion(row.Question, row.Location, row.User, row.IsOwned, row.IsBookmarked)
 }
\ No newline at end of file
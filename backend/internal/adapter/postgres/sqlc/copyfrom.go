@@ -42,3 +42,37 @@ func (r iteratorForCreatePollOptions) Err() error {
 func (q *Queries) CreatePollOptions(ctx context.Context, arg []CreatePollOptionsParams) (int64, error) {
 	return q.db.CopyFrom(ctx, []string{"poll_options"}, []string{"poll_id", "label", "index"}, &iteratorForCreatePollOptions{rows: arg})
 }
+
+// iteratorForCreatePushTickets implements pgx.CopyFromSource.
+type iteratorForCreatePushTickets struct {
+	rows                 []CreatePushTicketsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreatePushTickets) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreatePushTickets) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].TicketID,
+		r.rows[0].UserID,
+		r.rows[0].Token,
+	}, nil
+}
+
+func (r iteratorForCreatePushTickets) Err() error {
+	return nil
+}
+
+func (q *Queries) CreatePushTickets(ctx context.Context, arg []CreatePushTicketsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"push_tickets"}, []string{"ticket_id", "user_id", "token"}, &iteratorForCreatePushTickets{rows: arg})
+}
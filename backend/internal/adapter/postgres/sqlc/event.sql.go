@@ -0,0 +1,19 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: event.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const publishEvent = `-- name: PublishEvent :exec
+SELECT pg_notify($1::text, $2::text)
+`
+
+func (q *Queries) PublishEvent(ctx context.Context, channel string, payload string) error {
+	_, err := q.db.Exec(ctx, publishEvent, channel, payload)
+	return err
+}
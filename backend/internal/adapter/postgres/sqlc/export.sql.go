@@ -0,0 +1,268 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: export.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const claimDataExport = `-- name: ClaimDataExport :one
+WITH claimable AS (
+    SELECT id
+    FROM data_exports
+    WHERE
+        status = 'Pending' OR
+        (status = 'Processing' AND claimed_at < $1::timestamptz)
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE data_exports e
+SET
+    status = 'Processing',
+    claimed_at = now()
+FROM claimable c
+WHERE e.id = c.id
+RETURNING e.id, e.user_id, e.status, e.object_key, e.last_error, e.claimed_at, e.completed_at, e.created_at
+`
+
+// claims the oldest pending export, or an export whose processing was abandoned before stale_before
+func (q *Queries) ClaimDataExport(ctx context.Context, staleBefore time.Time) (DataExport, error) {
+	row := q.db.QueryRow(ctx, claimDataExport, staleBefore)
+	var i DataExport
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Status,
+		&i.ObjectKey,
+		&i.LastError,
+		&i.ClaimedAt,
+		&i.CompletedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const completeDataExport = `-- name: CompleteDataExport :execrows
+UPDATE data_exports
+SET
+    status = 'Ready',
+    object_key = $1,
+    completed_at = now()
+WHERE id = $2 AND status = 'Processing'
+`
+
+func (q *Queries) CompleteDataExport(ctx context.Context, objectKey *string, exportID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, completeDataExport, objectKey, exportID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createDataExport = `-- name: CreateDataExport :one
+INSERT INTO data_exports (user_id)
+VALUES ($1)
+ON CONFLICT (user_id) WHERE status IN ('Pending', 'Processing') DO NOTHING
+RETURNING id, user_id, status, object_key, last_error, claimed_at, completed_at, created_at
+`
+
+// returns no rows if the user already has an export in progress
+func (q *Queries) CreateDataExport(ctx context.Context, userID string) (DataExport, error) {
+	row := q.db.QueryRow(ctx, createDataExport, userID)
+	var i DataExport
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Status,
+		&i.ObjectKey,
+		&i.LastError,
+		&i.ClaimedAt,
+		&i.CompletedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const failDataExport = `-- name: FailDataExport :exec
+UPDATE data_exports
+SET
+    status = 'Failed',
+    last_error = $1,
+    completed_at = now()
+WHERE id = $2
+`
+
+func (q *Queries) FailDataExport(ctx context.Context, lastError *string, exportID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, failDataExport, lastError, exportID)
+	return err
+}
+
+const getConfirmAnswersByUserID = `-- name: GetConfirmAnswersByUserID :many
+SELECT
+    q.id AS question_id,
+    q.title AS question_title,
+    ca.is_yes,
+    ca.updated_at
+FROM confirm_answers ca
+    JOIN confirms c ON ca.confirm_id = c.id
+    JOIN questions q ON c.question_id = q.id
+WHERE ca.user_id = $1
+ORDER BY ca.updated_at
+`
+
+type GetConfirmAnswersByUserIDRow struct {
+	QuestionID    uuid.UUID
+	QuestionTitle string
+	IsYes         bool
+	UpdatedAt     time.Time
+}
+
+func (q *Queries) GetConfirmAnswersByUserID(ctx context.Context, userID string) ([]GetConfirmAnswersByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getConfirmAnswersByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetConfirmAnswersByUserIDRow{}
+	for rows.Next() {
+		var i GetConfirmAnswersByUserIDRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.QuestionTitle,
+			&i.IsYes,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDataExportByID = `-- name: GetDataExportByID :one
+SELECT id, user_id, status, object_key, last_error, claimed_at, completed_at, created_at FROM data_exports
+WHERE id = $1 AND user_id = $2
+`
+
+func (q *Queries) GetDataExportByID(ctx context.Context, exportID uuid.UUID, userID string) (DataExport, error) {
+	row := q.db.QueryRow(ctx, getDataExportByID, exportID, userID)
+	var i DataExport
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Status,
+		&i.ObjectKey,
+		&i.LastError,
+		&i.ClaimedAt,
+		&i.CompletedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPollVotesByUserID = `-- name: GetPollVotesByUserID :many
+SELECT
+    q.id AS question_id,
+    q.title AS question_title,
+    po.label AS option_label,
+    pv.other_text,
+    pv.rank,
+    pv.created_at
+FROM poll_votes pv
+    JOIN polls p ON pv.poll_id = p.id
+    JOIN questions q ON p.question_id = q.id
+    LEFT JOIN poll_options po ON pv.option_id = po.id
+WHERE pv.user_id = $1
+ORDER BY pv.created_at, pv.rank
+`
+
+type GetPollVotesByUserIDRow struct {
+	QuestionID    uuid.UUID
+	QuestionTitle string
+	OptionLabel   *string
+	OtherText     *string
+	Rank          *int
+	CreatedAt     time.Time
+}
+
+func (q *Queries) GetPollVotesByUserID(ctx context.Context, userID string) ([]GetPollVotesByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getPollVotesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPollVotesByUserIDRow{}
+	for rows.Next() {
+		var i GetPollVotesByUserIDRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.QuestionTitle,
+			&i.OptionLabel,
+			&i.OtherText,
+			&i.Rank,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRatingAnswersByUserID = `-- name: GetRatingAnswersByUserID :many
+SELECT
+    q.id AS question_id,
+    q.title AS question_title,
+    ra.value,
+    ra.updated_at
+FROM rating_answers ra
+    JOIN ratings r ON ra.rating_id = r.id
+    JOIN questions q ON r.question_id = q.id
+WHERE ra.user_id = $1
+ORDER BY ra.updated_at
+`
+
+type GetRatingAnswersByUserIDRow struct {
+	QuestionID    uuid.UUID
+	QuestionTitle string
+	Value         int
+	UpdatedAt     time.Time
+}
+
+func (q *Queries) GetRatingAnswersByUserID(ctx context.Context, userID string) ([]GetRatingAnswersByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getRatingAnswersByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetRatingAnswersByUserIDRow{}
+	for rows.Next() {
+		var i GetRatingAnswersByUserIDRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.QuestionTitle,
+			&i.Value,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
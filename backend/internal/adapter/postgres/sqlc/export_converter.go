@@ -0,0 +1,61 @@
+package sqlc
+
+import (
+	"strconv"
+
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/ptr"
+)
+
+func (row DataExport) ToDomainModel() model.DataExport {
+	return model.DataExport{
+		ID:          row.ID,
+		UserID:      row.UserID,
+		Status:      model.DataExportStatus(row.Status),
+		ObjectKey:   row.ObjectKey,
+		CreatedAt:   row.CreatedAt,
+		CompletedAt: row.CompletedAt,
+	}
+}
+
+func (row GetPollVotesByUserIDRow) ToDomainModel() model.ExportedVote {
+	// a write-in vote has no option
+	answer := ptr.Deref(row.OptionLabel)
+	if row.OptionLabel == nil {
+		answer = ptr.Deref(row.OtherText)
+	}
+
+	return model.ExportedVote{
+		QuestionID:    row.QuestionID,
+		QuestionTitle: row.QuestionTitle,
+		Type:          model.ContentTypePoll,
+		Answer:        answer,
+		Rank:          row.Rank,
+		CreatedAt:     row.CreatedAt,
+	}
+}
+
+func (row GetRatingAnswersByUserIDRow) ToDomainModel() model.ExportedVote {
+	return model.ExportedVote{
+		QuestionID:    row.QuestionID,
+		QuestionTitle: row.QuestionTitle,
+		Type:          model.ContentTypeRating,
+		Answer:        strconv.Itoa(row.Value),
+		CreatedAt:     row.UpdatedAt,
+	}
+}
+
+func (row GetConfirmAnswersByUserIDRow) ToDomainModel() model.ExportedVote {
+	answer := "No"
+	if row.IsYes {
+		answer = "Yes"
+	}
+
+	return model.ExportedVote{
+		QuestionID:    row.QuestionID,
+		QuestionTitle: row.QuestionTitle,
+		Type:          model.ContentTypeConfirm,
+		Answer:        answer,
+		CreatedAt:     row.UpdatedAt,
+	}
+}
@@ -12,6 +12,53 @@ import (
 	"github.com/google/uuid"
 )
 
+type Bookmark struct {
+	UserID     string
+	QuestionID uuid.UUID
+	Notify     bool
+	CreatedAt  time.Time
+}
+
+type Comment struct {
+	ID         uuid.UUID
+	AuthorID   string
+	ResponseID uuid.UUID
+	Body       string
+	CreatedAt  time.Time
+	EditedAt   time.Time
+}
+
+type Confirm struct {
+	ID         uuid.UUID
+	QuestionID uuid.UUID
+	CreatedAt  time.Time
+}
+
+type ConfirmAnswer struct {
+	ConfirmID uuid.UUID
+	UserID    string
+	IsYes     bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type DataExport struct {
+	ID          uuid.UUID
+	UserID      string
+	Status      string
+	ObjectKey   *string
+	LastError   *string
+	ClaimedAt   *time.Time
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+}
+
+type Follow struct {
+	FollowerID string
+	FolloweeID string
+	CreatedAt  time.Time
+}
+
 type Location struct {
 	ID         uuid.UUID
 	QuestionID uuid.UUID
@@ -20,10 +67,43 @@ type Location struct {
 	Address    *string
 }
 
+type Notification struct {
+	ID        uuid.UUID
+	UserID    string
+	Type      string
+	Title     string
+	Body      string
+	Data      []byte
+	DedupeKey *string
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+type NotificationOutbox struct {
+	ID          uuid.UUID
+	Type        string
+	Payload     []byte
+	Status      string
+	Attempts    int
+	LastError   *string
+	AvailableAt time.Time
+	LockedUntil *time.Time
+	DeliveredAt *time.Time
+	CreatedAt   time.Time
+}
+
 type Poll struct {
-	ID         uuid.UUID
-	QuestionID uuid.UUID
-	CreatedAt  time.Time
+	ID                uuid.UUID
+	QuestionID        uuid.UUID
+	CreatedAt         time.Time
+	Type              string
+	MaxSelections     int
+	AllowOther        bool
+	ResultsVisibility string
+	IsAnonymous       bool
+	ClosesAt          *time.Time
+	ClosedAt          *time.Time
+	FinalResults      []byte
 }
 
 type PollOption struct {
@@ -35,23 +115,69 @@ type PollOption struct {
 
 type PollVote struct {
 	PollID    uuid.UUID
-	OptionID  uuid.UUID
+	OptionID  *uuid.UUID
+	UserID    string
+	CreatedAt time.Time
+	ID        uuid.UUID
+	Rank      *int
+	OtherText *string
+}
+
+type PushTicket struct {
+	TicketID  string
 	UserID    string
+	Token     string
 	CreatedAt time.Time
 }
 
 type Question struct {
-	ID           uuid.UUID
-	AuthorID     string
-	ContentType  string
-	Title        string
-	Body         *string
-	ImageUrls    []string
-	Category     string
-	NumResponses int
-	CreatedAt    time.Time
-	EditedAt     time.Time
-	ExpiredAt    time.Time
+	ID            uuid.UUID
+	AuthorID      string
+	ContentType   string
+	Title         string
+	Body          *string
+	ImageUrls     []string
+	Category      string
+	NumResponses  int
+	CreatedAt     time.Time
+	EditedAt      time.Time
+	ExpiredAt     time.Time
+	NumExtensions int
+	ReopenedAt    *time.Time
+	Status        string
+	Summary       *string
+	DeletedAt     *time.Time
+	EditCount     int
+	Mentions      []byte
+}
+
+type QuestionRevision struct {
+	ID         uuid.UUID
+	QuestionID uuid.UUID
+	Title      string
+	Body       *string
+	Category   string
+	ImageUrls  []string
+	EditedAt   time.Time
+	CreatedAt  time.Time
+}
+
+type Rating struct {
+	ID         uuid.UUID
+	QuestionID uuid.UUID
+	MinValue   int
+	MaxValue   int
+	MinLabel   *string
+	MaxLabel   *string
+	CreatedAt  time.Time
+}
+
+type RatingAnswer struct {
+	RatingID  uuid.UUID
+	UserID    string
+	Value     int
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type Response struct {
@@ -62,6 +188,19 @@ type Response struct {
 	ImageUrls  []string
 	CreatedAt  time.Time
 	EditedAt   time.Time
+	DeletedAt  *time.Time
+	EditCount  int
+	NumReplies int
+	Mentions   []byte
+}
+
+type ResponseRevision struct {
+	ID         uuid.UUID
+	ResponseID uuid.UUID
+	Body       string
+	ImageUrls  []string
+	EditedAt   time.Time
+	CreatedAt  time.Time
 }
 
 type User struct {
@@ -73,6 +212,28 @@ type User struct {
 	AboutMe           *string
 	AvatarUrl         *string
 	CreatedAt         time.Time
-	ExpoPushToken     *string
 	LastKnownLocation *go_postgis.PointS
+	UsernameChangedAt *time.Time
+}
+
+type UserBlock struct {
+	BlockerID string
+	BlockedID string
+	CreatedAt time.Time
+}
+
+type UserDevice struct {
+	ID         uuid.UUID
+	UserID     string
+	Token      string
+	Platform   *string
+	AppVersion *string
+	LastSeenAt time.Time
+	CreatedAt  time.Time
+}
+
+type UserMute struct {
+	MuterID   string
+	MutedID   string
+	CreatedAt time.Time
 }
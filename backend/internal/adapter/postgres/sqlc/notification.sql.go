@@ -0,0 +1,306 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: notification.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const claimOutboxMessages = `-- name: ClaimOutboxMessages :many
+WITH claimable AS (
+    SELECT id
+    FROM notification_outbox
+    WHERE
+        (status = 'Pending' AND available_at <= now()) OR
+        (status = 'Processing' AND locked_until < now())
+    ORDER BY available_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE notification_outbox o
+SET
+    status = 'Processing',
+    attempts = o.attempts + 1,
+    locked_until = $1::timestamptz
+FROM claimable c
+WHERE o.id = c.id
+RETURNING o.id, o.type, o.payload, o.status, o.attempts, o.last_error, o.available_at, o.locked_until, o.delivered_at, o.created_at
+`
+
+func (q *Queries) ClaimOutboxMessages(ctx context.Context, lockedUntil time.Time, limitNum int32) ([]NotificationOutbox, error) {
+	rows, err := q.db.Query(ctx, claimOutboxMessages, lockedUntil, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NotificationOutbox{}
+	for rows.Next() {
+		var i NotificationOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.AvailableAt,
+			&i.LockedUntil,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createNotifications = `-- name: CreateNotifications :many
+INSERT INTO notifications (user_id, type, title, body, data, dedupe_key)
+SELECT
+    unnest($1::text[]),
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+ON CONFLICT (user_id, dedupe_key) DO NOTHING
+RETURNING user_id
+`
+
+type CreateNotificationsParams struct {
+	UserIds          []string
+	NotificationType string
+	Title            string
+	Body             string
+	Data             []byte
+	DedupeKey        *string
+}
+
+// returns the users that did not have the notification yet
+func (q *Queries) CreateNotifications(ctx context.Context, arg CreateNotificationsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, createNotifications,
+		arg.UserIds,
+		arg.NotificationType,
+		arg.Title,
+		arg.Body,
+		arg.Data,
+		arg.DedupeKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var user_id string
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createOutboxMessage = `-- name: CreateOutboxMessage :exec
+INSERT INTO notification_outbox (type, payload)
+VALUES ($1, $2)
+`
+
+func (q *Queries) CreateOutboxMessage(ctx context.Context, messageType string, payload []byte) error {
+	_, err := q.db.Exec(ctx, createOutboxMessage, messageType, payload)
+	return err
+}
+
+type CreatePushTicketsParams struct {
+	TicketID string
+	UserID   string
+	Token    string
+}
+
+const deleteNotification = `-- name: DeleteNotification :one
+DELETE FROM notifications
+WHERE user_id = $1 AND id = $2
+RETURNING id
+`
+
+func (q *Queries) DeleteNotification(ctx context.Context, userID string, iD uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, deleteNotification, userID, iD)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deletePushTickets = `-- name: DeletePushTickets :exec
+DELETE FROM push_tickets
+WHERE ticket_id = ANY($1::text[])
+`
+
+func (q *Queries) DeletePushTickets(ctx context.Context, ticketIds []string) error {
+	_, err := q.db.Exec(ctx, deletePushTickets, ticketIds)
+	return err
+}
+
+const failOutboxMessage = `-- name: FailOutboxMessage :exec
+UPDATE notification_outbox
+SET
+    status = 'Failed',
+    last_error = $1::text,
+    locked_until = NULL
+WHERE id = $2
+`
+
+func (q *Queries) FailOutboxMessage(ctx context.Context, lastError string, iD uuid.UUID) error {
+	_, err := q.db.Exec(ctx, failOutboxMessage, lastError, iD)
+	return err
+}
+
+const getNotificationsByUserID = `-- name: GetNotificationsByUserID :many
+SELECT id, user_id, type, title, body, data, dedupe_key, read_at, created_at
+FROM notifications
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $3 OFFSET $2
+`
+
+func (q *Queries) GetNotificationsByUserID(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]Notification, error) {
+	rows, err := q.db.Query(ctx, getNotificationsByUserID, userID, offsetNum, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Notification{}
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Type,
+			&i.Title,
+			&i.Body,
+			&i.Data,
+			&i.DedupeKey,
+			&i.ReadAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPushTicketsCreatedBefore = `-- name: GetPushTicketsCreatedBefore :many
+SELECT ticket_id, user_id, token, created_at
+FROM push_tickets
+WHERE created_at <= $1
+ORDER BY created_at
+LIMIT $2
+`
+
+func (q *Queries) GetPushTicketsCreatedBefore(ctx context.Context, createdBefore time.Time, limitNum int32) ([]PushTicket, error) {
+	rows, err := q.db.Query(ctx, getPushTicketsCreatedBefore, createdBefore, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PushTicket{}
+	for rows.Next() {
+		var i PushTicket
+		if err := rows.Scan(
+			&i.TicketID,
+			&i.UserID,
+			&i.Token,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnreadNotificationCount = `-- name: GetUnreadNotificationCount :one
+SELECT COUNT(*)
+FROM notifications
+WHERE user_id = $1 AND read_at IS NULL
+`
+
+func (q *Queries) GetUnreadNotificationCount(ctx context.Context, userID string) (int, error) {
+	row := q.db.QueryRow(ctx, getUnreadNotificationCount, userID)
+	var count int
+	err := row.Scan(&count)
+	return count, err
+}
+
+const markAllNotificationsRead = `-- name: MarkAllNotificationsRead :exec
+UPDATE notifications
+SET read_at = now()
+WHERE user_id = $1 AND read_at IS NULL
+`
+
+func (q *Queries) MarkAllNotificationsRead(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, markAllNotificationsRead, userID)
+	return err
+}
+
+const markNotificationRead = `-- name: MarkNotificationRead :one
+UPDATE notifications
+SET read_at = COALESCE(read_at, now())
+WHERE user_id = $1 AND id = $2
+RETURNING id
+`
+
+func (q *Queries) MarkNotificationRead(ctx context.Context, userID string, iD uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, markNotificationRead, userID, iD)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const markOutboxMessageDelivered = `-- name: MarkOutboxMessageDelivered :exec
+UPDATE notification_outbox
+SET
+    status = 'Delivered',
+    delivered_at = now(),
+    locked_until = NULL
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxMessageDelivered(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, markOutboxMessageDelivered, id)
+	return err
+}
+
+const retryOutboxMessage = `-- name: RetryOutboxMessage :exec
+UPDATE notification_outbox
+SET
+    status = 'Pending',
+    last_error = $1::text,
+    available_at = $2,
+    locked_until = NULL
+WHERE id = $3
+`
+
+func (q *Queries) RetryOutboxMessage(ctx context.Context, lastError string, availableAt time.Time, iD uuid.UUID) error {
+	_, err := q.db.Exec(ctx, retryOutboxMessage, lastError, availableAt, iD)
+	return err
+}
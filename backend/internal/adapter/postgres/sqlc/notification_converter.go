@@ -0,0 +1,40 @@
+package sqlc
+
+import (
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+)
+
+func (row NotificationOutbox) ToDomainModel() model.OutboxMessage {
+	return model.OutboxMessage{
+		ID:          row.ID,
+		Type:        model.OutboxMessageType(row.Type),
+		Payload:     row.Payload,
+		Status:      model.OutboxStatus(row.Status),
+		Attempts:    row.Attempts,
+		LastError:   row.LastError,
+		AvailableAt: row.AvailableAt,
+		CreatedAt:   row.CreatedAt,
+	}
+}
+
+func (row PushTicket) ToDomainModel() model.PushTicket {
+	return model.PushTicket{
+		TicketID:  row.TicketID,
+		UserID:    row.UserID,
+		Token:     row.Token,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func (row Notification) ToDomainModel() model.Notification {
+	return model.Notification{
+		ID:        row.ID,
+		Type:      model.NotificationType(row.Type),
+		Title:     row.Title,
+		Body:      row.Body,
+		Data:      row.Data,
+		IsRead:    row.ReadAt != nil,
+		ReadAt:    row.ReadAt,
+		CreatedAt: row.CreatedAt,
+	}
+}
@@ -6,46 +6,205 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	// the conditions are checked again, in case a question was reopened meanwhile
+	ArchiveQuestions(ctx context.Context, ids []uuid.UUID, expiredBefore time.Time) (int64, error)
+	BlockUser(ctx context.Context, blockerID string, blockedID string) error
+	BookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID, notify bool) error
+	// claims the oldest pending export, or an export whose processing was abandoned before stale_before
+	ClaimDataExport(ctx context.Context, staleBefore time.Time) (DataExport, error)
+	ClaimOutboxMessages(ctx context.Context, lockedUntil time.Time, limitNum int32) ([]NotificationOutbox, error)
+	// polls closed by the poll closer are closed at the time they were due
+	ClosePoll(ctx context.Context, id uuid.UUID) error
+	CompleteDataExport(ctx context.Context, objectKey *string, exportID uuid.UUID) (int64, error)
+	CreateComment(ctx context.Context, authorID string, responseID uuid.UUID, body string) (CreateCommentRow, error)
+	CreateConfirm(ctx context.Context, questionID uuid.UUID) (Confirm, error)
+	// returns no rows if the user already has an export in progress
+	CreateDataExport(ctx context.Context, userID string) (DataExport, error)
 	CreateLocation(ctx context.Context, wkt string, name *string, address *string, questionID uuid.UUID) (Location, error)
-	CreatePoll(ctx context.Context, questionID uuid.UUID) (Poll, error)
+	// returns the users that did not have the notification yet
+	CreateNotifications(ctx context.Context, arg CreateNotificationsParams) ([]string, error)
+	CreateOutboxMessage(ctx context.Context, messageType string, payload []byte) error
+	CreatePoll(ctx context.Context, arg CreatePollParams) (Poll, error)
 	CreatePollOptions(ctx context.Context, arg []CreatePollOptionsParams) (int64, error)
-	CreatePollVote(ctx context.Context, pollID uuid.UUID, optionID uuid.UUID, userID string) error
+	CreatePollVote(ctx context.Context, pollID uuid.UUID, optionID *uuid.UUID, userID string, rank *int, otherText *string) error
+	CreatePushTickets(ctx context.Context, arg []CreatePushTicketsParams) (int64, error)
 	CreateQuestion(ctx context.Context, arg CreateQuestionParams) (CreateQuestionRow, error)
+	// snapshots the current content before it is edited, the row stays locked until the end of the transaction
+	CreateQuestionRevision(ctx context.Context, id uuid.UUID) ([]string, error)
+	CreateRating(ctx context.Context, questionID uuid.UUID, minValue int, maxValue int, minLabel *string, maxLabel *string) (Rating, error)
 	CreateResponse(ctx context.Context, authorID string, questionID uuid.UUID, body string, imageUrls []string) (CreateResponseRow, error)
+	// snapshots the current content before it is edited, the row stays locked until the end of the transaction
+	CreateResponseRevision(ctx context.Context, id uuid.UUID) ([]string, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DecrementReplyCount(ctx context.Context, id uuid.UUID) error
+	// the comments of the author on other users' responses are deleted along with the author
+	DecrementReplyCountsByAuthorID(ctx context.Context, authorID string) error
 	DecrementResponseAmount(ctx context.Context, iD uuid.UUID, numResponses int) error
+	// the responses of the author to other users' questions are deleted along with the author
+	DecrementResponseCountsByAuthorID(ctx context.Context, authorID string) error
+	DeleteAllUserDevices(ctx context.Context, userID string) error
+	DeleteComment(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteConfirmAnswer(ctx context.Context, confirmID uuid.UUID, userID string) error
+	DeleteFollowsBetween(ctx context.Context, followerID string, followeeID string) error
+	DeleteNotification(ctx context.Context, userID string, iD uuid.UUID) (uuid.UUID, error)
+	DeletePollOptions(ctx context.Context, pollID uuid.UUID) error
 	DeletePollVote(ctx context.Context, userID string, pollID uuid.UUID) error
-	DeleteQuestion(ctx context.Context, id uuid.UUID) error
-	DeleteResponse(ctx context.Context, id uuid.UUID) error
-	DeleteUserPushToken(ctx context.Context, id string) error
+	DeletePushTickets(ctx context.Context, ticketIds []string) error
+	DeleteRatingAnswer(ctx context.Context, ratingID uuid.UUID, userID string) error
+	DeleteUser(ctx context.Context, id string) (int64, error)
+	DeleteUserDevice(ctx context.Context, userID string, token string) error
+	// the images removed from the question are deleted, so its revisions only keep the images the question still has
+	DropRemovedQuestionRevisionImages(ctx context.Context, questionID uuid.UUID) error
+	// the images removed from the response are deleted, so its revisions only keep the images the response still has
+	DropRemovedResponseRevisionImages(ctx context.Context, responseID uuid.UUID) error
+	EditComment(ctx context.Context, body string, iD uuid.UUID) (EditCommentRow, error)
 	EditLocation(ctx context.Context, wkt string, name *string, address *string, iD uuid.UUID) (Location, error)
-	EditQuestion(ctx context.Context, title string, body *string, category string, iD uuid.UUID) (EditQuestionRow, error)
-	EditResponse(ctx context.Context, body string, iD uuid.UUID) (EditResponseRow, error)
+	EditQuestion(ctx context.Context, title string, body *string, category string, imageUrls []string, iD uuid.UUID) (EditQuestionRow, error)
+	EditResponse(ctx context.Context, body string, imageUrls []string, iD uuid.UUID) (EditResponseRow, error)
+	// null fields are left unchanged, an empty about me or avatar url clears it
+	EditUser(ctx context.Context, displayName *string, username *string, aboutMe *string, avatarUrl *string, userID string) (User, error)
+	// does nothing if another instance already expired the question, or it was extended or reopened meanwhile
+	ExpireQuestion(ctx context.Context, iD uuid.UUID, summary *string) (int64, error)
+	// the limits are checked by the update itself, so that concurrent extensions cannot exceed them
+	ExtendQuestion(ctx context.Context, durationSecs float64, questionID uuid.UUID, maxExtensions int, maxLifetimeSecs float64) (int64, error)
+	FailDataExport(ctx context.Context, lastError *string, exportID uuid.UUID) error
+	FailOutboxMessage(ctx context.Context, lastError string, iD uuid.UUID) error
+	// users cannot follow each other while either of them blocks the other
+	FollowUser(ctx context.Context, followerID string, followeeID string) error
+	GetBlockedUsers(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetBlockedUsersRow, error)
+	// the users that bookmarked the question and asked to be notified about it
+	GetBookmarkSubscriberIDs(ctx context.Context, questionID uuid.UUID) ([]string, error)
+	// bookmarks outlive the feed, so expired questions are included
+	GetBookmarkedQuestions(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetBookmarkedQuestionsRow, error)
+	GetCommentByID(ctx context.Context, userID string, iD uuid.UUID) (GetCommentByIDRow, error)
+	// comments read as a conversation, so the oldest come first
+	GetCommentsByResponseID(ctx context.Context, userID string, responseID uuid.UUID, offsetNum int32, limitNum int32) ([]GetCommentsByResponseIDRow, error)
+	GetConfirmAnswer(ctx context.Context, confirmID uuid.UUID, userID string) (bool, error)
+	GetConfirmAnswersByUserID(ctx context.Context, userID string) ([]GetConfirmAnswersByUserIDRow, error)
+	GetConfirmByQuestionID(ctx context.Context, questionID uuid.UUID) (GetConfirmByQuestionIDRow, error)
+	// every answer weighs 0.5^(age / half life), so that recent answers count more
+	GetConfirmTally(ctx context.Context, halfLifeSeconds float64, confirmID uuid.UUID) (GetConfirmTallyRow, error)
+	GetDataExportByID(ctx context.Context, exportID uuid.UUID, userID string) (DataExport, error)
+	GetDeletedQuestionByID(ctx context.Context, id uuid.UUID) (GetDeletedQuestionByIDRow, error)
+	GetDeletedResponseByID(ctx context.Context, id uuid.UUID) (GetDeletedResponseByIDRow, error)
+	GetFollowerIDs(ctx context.Context, followeeID string) ([]string, error)
+	GetMutedUsers(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetMutedUsersRow, error)
+	// drops the users that muted or blocked the actor
+	GetNotifiableUserIDs(ctx context.Context, userIds []string, actorID string) ([]string, error)
+	GetNotificationsByUserID(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]Notification, error)
+	// the images of other users' responses to the user's questions, these responses are deleted along with the user
+	GetOtherUsersResponseImageURLs(ctx context.Context, authorID string) ([]string, error)
+	// the ranked options of every voter, in order of preference
+	GetPollBallots(ctx context.Context, pollID uuid.UUID) ([]GetPollBallotsRow, error)
 	GetPollByQuestionID(ctx context.Context, questionID uuid.UUID) (GetPollByQuestionIDRow, error)
+	GetPollIDsToFinalize(ctx context.Context, limit int32) ([]uuid.UUID, error)
+	// the most selections any single voter made on the poll
+	GetPollMaxBallotSize(ctx context.Context, pollID uuid.UUID) (int, error)
+	GetPollNumVoters(ctx context.Context, pollID uuid.UUID) (int, error)
+	GetPollOptionVoters(ctx context.Context, optionID *uuid.UUID, limit int32, offset int32) ([]GetPollOptionVotersRow, error)
 	GetPollOptions(ctx context.Context, id uuid.UUID) ([]GetPollOptionsRow, error)
+	GetPollOtherVotes(ctx context.Context, pollID uuid.UUID, userID string) ([]GetPollOtherVotesRow, error)
+	GetPollVoterIDs(ctx context.Context, pollID uuid.UUID) ([]string, error)
+	// for RankedChoice polls, only first preferences are counted as votes
 	GetPollVotes(ctx context.Context, pollID uuid.UUID, userID string) ([]GetPollVotesRow, error)
+	GetPollVotesByUserID(ctx context.Context, userID string) ([]GetPollVotesByUserIDRow, error)
+	// the questions of another user, hidden if either of them blocks the other
+	GetPublicQuestionsByAuthorID(ctx context.Context, userID string, authorID string, offsetNum int32, limitNum int32) ([]GetPublicQuestionsByAuthorIDRow, error)
+	// the responses of another user, hidden if either of them blocks the other, or if the question's author blocks the viewer
+	GetPublicResponsesByAuthorID(ctx context.Context, userID string, authorID string, offsetNum int32, limitNum int32) ([]GetPublicResponsesByAuthorIDRow, error)
+	// users that blocked the viewer cannot be looked up by them
+	GetPublicUserByID(ctx context.Context, userID string, viewerID string) (User, error)
+	GetPublicUserByUsername(ctx context.Context, username string, viewerID string) (User, error)
+	GetPushRecipients(ctx context.Context, userIds []string, activeSince time.Time) ([]GetPushRecipientsRow, error)
+	GetPushTicketsCreatedBefore(ctx context.Context, createdBefore time.Time, limitNum int32) ([]PushTicket, error)
 	GetQuestionByID(ctx context.Context, iD uuid.UUID, authorID string) (GetQuestionByIDRow, error)
+	GetQuestionIDByConfirmID(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	GetQuestionIDByPollID(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	GetQuestionIDByRatingID(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	GetQuestionIDsToExpire(ctx context.Context, limit int32) ([]uuid.UUID, error)
+	GetQuestionMentions(ctx context.Context, id uuid.UUID) ([]byte, error)
+	GetQuestionResponderIDs(ctx context.Context, questionID uuid.UUID) ([]string, error)
+	GetQuestionResponseImageURLs(ctx context.Context, questionID uuid.UUID) ([]string, error)
+	GetQuestionRevisions(ctx context.Context, questionID uuid.UUID, offsetNum int32, limitNum int32) ([]QuestionRevision, error)
 	GetQuestionsByUserID(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetQuestionsByUserIDRow, error)
+	GetQuestionsDeletedBefore(ctx context.Context, deletedBefore time.Time, limitNum int32) ([]GetQuestionsDeletedBeforeRow, error)
+	GetQuestionsExpiredBetween(ctx context.Context, expiredAfter time.Time, expiredUntil time.Time) ([]GetQuestionsExpiredBetweenRow, error)
+	GetQuestionsFromFollowedUsers(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetQuestionsFromFollowedUsersRow, error)
+	// questions of users blocked by or blocking the viewer are hidden
 	GetQuestionsInRadiusFeed(ctx context.Context, arg GetQuestionsInRadiusFeedParams) ([]GetQuestionsInRadiusFeedRow, error)
 	GetQuestionsInRadiusFeedByCategory(ctx context.Context, arg GetQuestionsInRadiusFeedByCategoryParams) ([]GetQuestionsInRadiusFeedByCategoryRow, error)
 	GetQuestionsRespondedByUserID(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetQuestionsRespondedByUserIDRow, error)
+	GetQuestionsToArchive(ctx context.Context, expiredBefore time.Time, limitNum int32) ([]GetQuestionsToArchiveRow, error)
+	GetQuestionsToPurge(ctx context.Context, expiredBefore time.Time, limitNum int32) ([]GetQuestionsToPurgeRow, error)
+	GetRatingAnswersByUserID(ctx context.Context, userID string) ([]GetRatingAnswersByUserIDRow, error)
+	GetRatingByQuestionID(ctx context.Context, questionID uuid.UUID) (GetRatingByQuestionIDRow, error)
+	GetRatingHistogram(ctx context.Context, ratingID uuid.UUID, userID string) ([]GetRatingHistogramRow, error)
 	GetResponseByID(ctx context.Context, userID string, iD uuid.UUID) (GetResponseByIDRow, error)
+	GetResponseMentions(ctx context.Context, id uuid.UUID) ([]byte, error)
+	GetResponseRevisions(ctx context.Context, responseID uuid.UUID, offsetNum int32, limitNum int32) ([]ResponseRevision, error)
 	GetResponsesByQuestionID(ctx context.Context, userID string, questionID uuid.UUID, offsetNum int32, limitNum int32) ([]GetResponsesByQuestionIDRow, error)
+	GetResponsesDeletedBefore(ctx context.Context, deletedBefore time.Time, limitNum int32) ([]GetResponsesDeletedBeforeRow, error)
+	GetUnreadNotificationCount(ctx context.Context, userID string) (int, error)
 	GetUserByID(ctx context.Context, id string) (User, error)
+	GetUserFollowerCount(ctx context.Context, followeeID string) (int, error)
+	GetUserFollowingCount(ctx context.Context, followerID string) (int, error)
+	GetUserIDsInRadius(ctx context.Context, longitude float64, latitude float64, radiusMeters float64) ([]string, error)
 	GetUserQuestionCount(ctx context.Context, authorID string) (int, error)
 	GetUserResponseCount(ctx context.Context, authorID string) (int, error)
-	GetUsersInRadius(ctx context.Context, longitude float64, latitude float64, radiusMeters float64) ([]GetUsersInRadiusRow, error)
+	// users that blocked the author cannot be mentioned by them, usernames are expected in lower case
+	GetUsersByUsernames(ctx context.Context, usernames []string, authorID string) ([]GetUsersByUsernamesRow, error)
+	// responses, locations and question content are deleted along with the question
+	HardDeleteQuestion(ctx context.Context, iD uuid.UUID, deletedBefore time.Time) (int64, error)
+	HardDeleteResponse(ctx context.Context, iD uuid.UUID, deletedBefore time.Time) (int64, error)
+	IncrementReplyCount(ctx context.Context, id uuid.UUID) error
 	IncrementResponseAmount(ctx context.Context, id uuid.UUID) error
+	// a poll closes early if it was closed by its owner or its closes_at is before the question's expiry
 	IsPollExpired(ctx context.Context, id uuid.UUID) (bool, error)
-	SetQuestionContentType(ctx context.Context, iD uuid.UUID, contentType string) error
-	UpdateUserDisplayName(ctx context.Context, displayName string, iD string) (UpdateUserDisplayNameRow, error)
+	// usernames are compared case-insensitively, so that mentions are not ambiguous to readers
+	IsUsernameTaken(ctx context.Context, username string, userID string) (bool, error)
+	// keeps the poll from being edited or closed until the end of the transaction
+	LockOpenPoll(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	LockPollForEdit(ctx context.Context, id uuid.UUID) (LockPollForEditRow, error)
+	MarkAllNotificationsRead(ctx context.Context, userID string) error
+	MarkNotificationRead(ctx context.Context, userID string, iD uuid.UUID) (uuid.UUID, error)
+	MarkOutboxMessageDelivered(ctx context.Context, id uuid.UUID) error
+	MuteUser(ctx context.Context, muterID string, mutedID string) error
+	PublishEvent(ctx context.Context, channel string, payload string) error
+	// responses, locations and question content are deleted along with the question
+	PurgeQuestion(ctx context.Context, iD uuid.UUID, expiredBefore time.Time) (int64, error)
+	// opens the poll of a question that is about to be reopened again, if it only closed because the question expired.
+	// a poll closed by its owner, or by its own closing time, stays closed
+	ReopenExpiredPoll(ctx context.Context, questionID uuid.UUID) error
+	// the summary is generated again when the reopened question expires.
+	// a question can only be reopened once, which is checked by the update itself, so that concurrent requests cannot both reopen it
+	ReopenQuestion(ctx context.Context, durationSecs float64, questionID uuid.UUID) (int64, error)
+	RestoreQuestion(ctx context.Context, iD uuid.UUID, deletedAfter time.Time) (int64, error)
+	RestoreResponse(ctx context.Context, iD uuid.UUID, deletedAfter time.Time) (int64, error)
+	RetryOutboxMessage(ctx context.Context, lastError string, availableAt time.Time, iD uuid.UUID) error
+	SetPollFinalResults(ctx context.Context, iD uuid.UUID, finalResults []byte) (int64, error)
+	// only a question without content can get content, so that concurrent requests cannot both add content
+	SetQuestionContentType(ctx context.Context, contentType string, questionID uuid.UUID) (int64, error)
+	SetQuestionMentions(ctx context.Context, iD uuid.UUID, mentions []byte) error
+	SetResponseMentions(ctx context.Context, iD uuid.UUID, mentions []byte) error
+	SoftDeleteQuestion(ctx context.Context, id uuid.UUID) (int64, error)
+	SoftDeleteResponse(ctx context.Context, id uuid.UUID) (int64, error)
+	UnblockUser(ctx context.Context, blockerID string, blockedID string) error
+	UnbookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID) error
+	UnfollowUser(ctx context.Context, followerID string, followeeID string) error
+	UnmuteUser(ctx context.Context, muterID string, mutedID string) error
+	UpdatePollSettings(ctx context.Context, maxSelections int, closesAt *time.Time, iD uuid.UUID) error
 	UpdateUserLocation(ctx context.Context, iD string, longitude float64, latitude float64) error
-	UpdateUserPushToken(ctx context.Context, iD string, expoPushToken *string) error
+	// nothing is inserted if the question's author blocked the user
+	UpsertConfirmAnswer(ctx context.Context, confirmID uuid.UUID, userID string, isYes bool) (int64, error)
+	// nothing is inserted if the question's author blocked the user
+	UpsertRatingAnswer(ctx context.Context, ratingID uuid.UUID, userID string, value int) (int64, error)
+	UpsertUserDevice(ctx context.Context, userID string, token string, platform *string, appVersion *string) error
 }
 
 var _ Querier = (*Queries)(nil)
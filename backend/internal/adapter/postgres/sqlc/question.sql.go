@@ -12,19 +12,110 @@ import (
 	"github.com/google/uuid"
 )
 
-const createPoll = `-- name: CreatePoll :one
-INSERT INTO polls (question_id)
+const archiveQuestions = `-- name: ArchiveQuestions :execrows
+UPDATE questions
+SET status = 'Archived'
+WHERE id = ANY($1::uuid[]) AND status = 'Expired' AND expired_at <= $2
+`
+
+// the conditions are checked again, in case a question was reopened meanwhile
+func (q *Queries) ArchiveQuestions(ctx context.Context, ids []uuid.UUID, expiredBefore time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, archiveQuestions, ids, expiredBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const bookmarkQuestion = `-- name: BookmarkQuestion :exec
+INSERT INTO bookmarks (user_id, question_id, notify)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, question_id) DO UPDATE
+SET notify = excluded.notify
+`
+
+func (q *Queries) BookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID, notify bool) error {
+	_, err := q.db.Exec(ctx, bookmarkQuestion, userID, questionID, notify)
+	return err
+}
+
+const closePoll = `-- name: ClosePoll :exec
+UPDATE polls p
+SET closed_at = LEAST(now(), p.closes_at, q.expired_at)
+FROM questions q
+WHERE q.id = p.question_id AND p.id = $1 AND p.closed_at IS NULL
+`
+
+// polls closed by the poll closer are closed at the time they were due
+func (q *Queries) ClosePoll(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, closePoll, id)
+	return err
+}
+
+const createConfirm = `-- name: CreateConfirm :one
+INSERT INTO confirms (question_id)
 VALUES ($1)
 RETURNING id, question_id, created_at
 `
 
-func (q *Queries) CreatePoll(ctx context.Context, questionID uuid.UUID) (Poll, error) {
-	row := q.db.QueryRow(ctx, createPoll, questionID)
-	var i Poll
+func (q *Queries) CreateConfirm(ctx context.Context, questionID uuid.UUID) (Confirm, error) {
+	row := q.db.QueryRow(ctx, createConfirm, questionID)
+	var i Confirm
 	err := row.Scan(&i.ID, &i.QuestionID, &i.CreatedAt)
 	return i, err
 }
 
+const createPoll = `-- name: CreatePoll :one
+INSERT INTO polls (question_id, type, max_selections, allow_other, results_visibility, is_anonymous, closes_at)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+)
+RETURNING id, question_id, created_at, type, max_selections, allow_other, results_visibility, is_anonymous, closes_at, closed_at, final_results
+`
+
+type CreatePollParams struct {
+	QuestionID        uuid.UUID
+	Type              string
+	MaxSelections     int
+	AllowOther        bool
+	ResultsVisibility string
+	IsAnonymous       bool
+	ClosesAt          *time.Time
+}
+
+func (q *Queries) CreatePoll(ctx context.Context, arg CreatePollParams) (Poll, error) {
+	row := q.db.QueryRow(ctx, createPoll,
+		arg.QuestionID,
+		arg.Type,
+		arg.MaxSelections,
+		arg.AllowOther,
+		arg.ResultsVisibility,
+		arg.IsAnonymous,
+		arg.ClosesAt,
+	)
+	var i Poll
+	err := row.Scan(
+		&i.ID,
+		&i.QuestionID,
+		&i.CreatedAt,
+		&i.Type,
+		&i.MaxSelections,
+		&i.AllowOther,
+		&i.ResultsVisibility,
+		&i.IsAnonymous,
+		&i.ClosesAt,
+		&i.ClosedAt,
+		&i.FinalResults,
+	)
+	return i, err
+}
+
 type CreatePollOptionsParams struct {
 	PollID uuid.UUID
 	Label  string
@@ -32,12 +123,18 @@ type CreatePollOptionsParams struct {
 }
 
 const createPollVote = `-- name: CreatePollVote :exec
-INSERT INTO poll_votes (poll_id, option_id, user_id)
-VALUES ($1, $2, $3)
+INSERT INTO poll_votes (poll_id, option_id, user_id, rank, other_text)
+VALUES ($1, $2, $3, $4, $5)
 `
 
-func (q *Queries) CreatePollVote(ctx context.Context, pollID uuid.UUID, optionID uuid.UUID, userID string) error {
-	_, err := q.db.Exec(ctx, createPollVote, pollID, optionID, userID)
+func (q *Queries) CreatePollVote(ctx context.Context, pollID uuid.UUID, optionID *uuid.UUID, userID string, rank *int, otherText *string) error {
+	_, err := q.db.Exec(ctx, createPollVote,
+		pollID,
+		optionID,
+		userID,
+		rank,
+		otherText,
+	)
 	return err
 }
 
@@ -53,11 +150,11 @@ WITH new_question AS (
         expired_at
     )
     VALUES ($1, $2, $3, $4, $5, $6, $7)
-    RETURNING id, author_id, content_type, title, body, image_urls, category, num_responses, created_at, edited_at, expired_at
+    RETURNING id, author_id, content_type, title, body, image_urls, category, num_responses, created_at, edited_at, expired_at, num_extensions, reopened_at, status, summary, deleted_at, edit_count, mentions
 )
 SELECT
-    nq.id, nq.author_id, nq.content_type, nq.title, nq.body, nq.image_urls, nq.category, nq.num_responses, nq.created_at, nq.edited_at, nq.expired_at,
-    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.expo_push_token, u.last_known_location,
+    nq.id, nq.author_id, nq.content_type, nq.title, nq.body, nq.image_urls, nq.category, nq.num_responses, nq.created_at, nq.edited_at, nq.expired_at, nq.num_extensions, nq.reopened_at, nq.status, nq.summary, nq.deleted_at, nq.edit_count, nq.mentions,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
     TRUE AS is_owned
 FROM
     new_question nq
@@ -75,19 +172,26 @@ type CreateQuestionParams struct {
 }
 
 type CreateQuestionRow struct {
-	ID           uuid.UUID
-	AuthorID     string
-	ContentType  string
-	Title        string
-	Body         *string
-	ImageUrls    []string
-	Category     string
-	NumResponses int
-	CreatedAt    time.Time
-	EditedAt     time.Time
-	ExpiredAt    time.Time
-	User         User
-	IsOwned      bool
+	ID            uuid.UUID
+	AuthorID      string
+	ContentType   string
+	Title         string
+	Body          *string
+	ImageUrls     []string
+	Category      string
+	NumResponses  int
+	CreatedAt     time.Time
+	EditedAt      time.Time
+	ExpiredAt     time.Time
+	NumExtensions int
+	ReopenedAt    *time.Time
+	Status        string
+	Summary       *string
+	DeletedAt     *time.Time
+	EditCount     int
+	Mentions      []byte
+	User          User
+	IsOwned       bool
 }
 
 func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams) (CreateQuestionRow, error) {
@@ -113,6 +217,13 @@ func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams)
 		&i.CreatedAt,
 		&i.EditedAt,
 		&i.ExpiredAt,
+		&i.NumExtensions,
+		&i.ReopenedAt,
+		&i.Status,
+		&i.Summary,
+		&i.DeletedAt,
+		&i.EditCount,
+		&i.Mentions,
 		&i.User.ID,
 		&i.User.Username,
 		&i.User.Email,
@@ -121,13 +232,57 @@ func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams)
 		&i.User.AboutMe,
 		&i.User.AvatarUrl,
 		&i.User.CreatedAt,
-		&i.User.ExpoPushToken,
 		&i.User.LastKnownLocation,
+		&i.User.UsernameChangedAt,
 		&i.IsOwned,
 	)
 	return i, err
 }
 
+const createQuestionRevision = `-- name: CreateQuestionRevision :one
+INSERT INTO question_revisions (question_id, title, body, category, image_urls, edited_at)
+SELECT id, title, body, category, image_urls, edited_at
+FROM questions
+WHERE questions.id = $1
+FOR UPDATE
+RETURNING image_urls
+`
+
+// snapshots the current content before it is edited, the row stays locked until the end of the transaction
+func (q *Queries) CreateQuestionRevision(ctx context.Context, id uuid.UUID) ([]string, error) {
+	row := q.db.QueryRow(ctx, createQuestionRevision, id)
+	var image_urls []string
+	err := row.Scan(&image_urls)
+	return image_urls, err
+}
+
+const createRating = `-- name: CreateRating :one
+INSERT INTO ratings (question_id, min_value, max_value, min_label, max_label)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, question_id, min_value, max_value, min_label, max_label, created_at
+`
+
+func (q *Queries) CreateRating(ctx context.Context, questionID uuid.UUID, minValue int, maxValue int, minLabel *string, maxLabel *string) (Rating, error) {
+	row := q.db.QueryRow(ctx, createRating,
+		questionID,
+		minValue,
+		maxValue,
+		minLabel,
+		maxLabel,
+	)
+	var i Rating
+	err := row.Scan(
+		&i.ID,
+		&i.QuestionID,
+		&i.MinValue,
+		&i.MaxValue,
+		&i.MinLabel,
+		&i.MaxLabel,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const decrementResponseAmount = `-- name: DecrementResponseAmount :exec
 UPDATE questions
 SET num_responses =
@@ -144,6 +299,26 @@ func (q *Queries) DecrementResponseAmount(ctx context.Context, iD uuid.UUID, num
 	return err
 }
 
+const deleteConfirmAnswer = `-- name: DeleteConfirmAnswer :exec
+DELETE FROM confirm_answers
+WHERE confirm_id = $1 AND user_id = $2
+`
+
+func (q *Queries) DeleteConfirmAnswer(ctx context.Context, confirmID uuid.UUID, userID string) error {
+	_, err := q.db.Exec(ctx, deleteConfirmAnswer, confirmID, userID)
+	return err
+}
+
+const deletePollOptions = `-- name: DeletePollOptions :exec
+DELETE FROM poll_options
+WHERE poll_id = $1
+`
+
+func (q *Queries) DeletePollOptions(ctx context.Context, pollID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deletePollOptions, pollID)
+	return err
+}
+
 const deletePollVote = `-- name: DeletePollVote :exec
 DELETE FROM poll_votes
 WHERE user_id = $1 AND poll_id = $2
@@ -154,13 +329,26 @@ func (q *Queries) DeletePollVote(ctx context.Context, userID string, pollID uuid
 	return err
 }
 
-const deleteQuestion = `-- name: DeleteQuestion :exec
-DELETE FROM questions
-WHERE questions.id = $1
+const deleteRatingAnswer = `-- name: DeleteRatingAnswer :exec
+DELETE FROM rating_answers
+WHERE rating_id = $1 AND user_id = $2
+`
+
+func (q *Queries) DeleteRatingAnswer(ctx context.Context, ratingID uuid.UUID, userID string) error {
+	_, err := q.db.Exec(ctx, deleteRatingAnswer, ratingID, userID)
+	return err
+}
+
+const dropRemovedQuestionRevisionImages = `-- name: DropRemovedQuestionRevisionImages :exec
+UPDATE question_revisions r
+SET image_urls = ARRAY(SELECT url FROM unnest(r.image_urls) AS url WHERE url = ANY(q.image_urls))
+FROM questions q
+WHERE q.id = r.question_id AND r.question_id = $1 AND NOT (r.image_urls <@ COALESCE(q.image_urls, '{}'))
 `
 
-func (q *Queries) DeleteQuestion(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteQuestion, id)
+// the images removed from the question are deleted, so its revisions only keep the images the question still has
+func (q *Queries) DropRemovedQuestionRevisionImages(ctx context.Context, questionID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, dropRemovedQuestionRevisionImages, questionID)
 	return err
 }
 
@@ -171,15 +359,22 @@ WITH edited_question AS (
         title = $1,
         body = $2,
         category = $3,
-        edited_at = current_timestamp
-    WHERE questions.id = $4
-    RETURNING id, author_id, content_type, title, body, image_urls, category, num_responses, created_at, edited_at, expired_at
+        -- a null list keeps the current images
+        image_urls = coalesce($4::text[], image_urls),
+        edited_at = current_timestamp,
+        edit_count = edit_count + 1
+    WHERE questions.id = $5
+    RETURNING id, author_id, content_type, title, body, image_urls, category, num_responses, created_at, edited_at, expired_at, num_extensions, reopened_at, status, summary, deleted_at, edit_count, mentions
 )
 SELECT
-    eq.id, eq.author_id, eq.content_type, eq.title, eq.body, eq.image_urls, eq.category, eq.num_responses, eq.created_at, eq.edited_at, eq.expired_at,
+    eq.id, eq.author_id, eq.content_type, eq.title, eq.body, eq.image_urls, eq.category, eq.num_responses, eq.created_at, eq.edited_at, eq.expired_at, eq.num_extensions, eq.reopened_at, eq.status, eq.summary, eq.deleted_at, eq.edit_count, eq.mentions,
     l.id, l.question_id, l.location, l.name, l.address,
-    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.expo_push_token, u.last_known_location,
-    TRUE AS is_owned
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    TRUE AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = eq.id AND bm.user_id = eq.author_id
+    ) AS is_bookmarked
 FROM
     edited_question eq
         JOIN users u ON eq.author_id = u.id
@@ -187,27 +382,36 @@ FROM
 `
 
 type EditQuestionRow struct {
-	ID           uuid.UUID
-	AuthorID     string
-	ContentType  string
-	Title        string
-	Body         *string
-	ImageUrls    []string
-	Category     string
-	NumResponses int
-	CreatedAt    time.Time
-	EditedAt     time.Time
-	ExpiredAt    time.Time
-	Location     Location
-	User         User
-	IsOwned      bool
+	ID            uuid.UUID
+	AuthorID      string
+	ContentType   string
+	Title         string
+	Body          *string
+	ImageUrls     []string
+	Category      string
+	NumResponses  int
+	CreatedAt     time.Time
+	EditedAt      time.Time
+	ExpiredAt     time.Time
+	NumExtensions int
+	ReopenedAt    *time.Time
+	Status        string
+	Summary       *string
+	DeletedAt     *time.Time
+	EditCount     int
+	Mentions      []byte
+	Location      Location
+	User          User
+	IsOwned       bool
+	IsBookmarked  bool
 }
 
-func (q *Queries) EditQuestion(ctx context.Context, title string, body *string, category string, iD uuid.UUID) (EditQuestionRow, error) {
+func (q *Queries) EditQuestion(ctx context.Context, title string, body *string, category string, imageUrls []string, iD uuid.UUID) (EditQuestionRow, error) {
 	row := q.db.QueryRow(ctx, editQuestion,
 		title,
 		body,
 		category,
+		imageUrls,
 		iD,
 	)
 	var i EditQuestionRow
@@ -223,6 +427,13 @@ func (q *Queries) EditQuestion(ctx context.Context, title string, body *string,
 		&i.CreatedAt,
 		&i.EditedAt,
 		&i.ExpiredAt,
+		&i.NumExtensions,
+		&i.ReopenedAt,
+		&i.Status,
+		&i.Summary,
+		&i.DeletedAt,
+		&i.EditCount,
+		&i.Mentions,
 		&i.Location.ID,
 		&i.Location.QuestionID,
 		&i.Location.Location,
@@ -236,58 +447,162 @@ func (q *Queries) EditQuestion(ctx context.Context, title string, body *string,
 		&i.User.AboutMe,
 		&i.User.AvatarUrl,
 		&i.User.CreatedAt,
-		&i.User.ExpoPushToken,
 		&i.User.LastKnownLocation,
+		&i.User.UsernameChangedAt,
 		&i.IsOwned,
+		&i.IsBookmarked,
 	)
 	return i, err
 }
 
-const getPollByQuestionID = `-- name: GetPollByQuestionID :one
-SELECT p.id, p.question_id, p.created_at
-FROM polls p
-WHERE p.question_id = $1
+const expireQuestion = `-- name: ExpireQuestion :execrows
+UPDATE questions
+SET
+    status = 'Expired',
+    summary = $2
+WHERE id = $1 AND status = 'Active' AND expired_at <= now()
 `
 
-type GetPollByQuestionIDRow struct {
-	Poll Poll
+// does nothing if another instance already expired the question, or it was extended or reopened meanwhile
+func (q *Queries) ExpireQuestion(ctx context.Context, iD uuid.UUID, summary *string) (int64, error) {
+	result, err := q.db.Exec(ctx, expireQuestion, iD, summary)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-func (q *Queries) GetPollByQuestionID(ctx context.Context, questionID uuid.UUID) (GetPollByQuestionIDRow, error) {
-	row := q.db.QueryRow(ctx, getPollByQuestionID, questionID)
-	var i GetPollByQuestionIDRow
-	err := row.Scan(&i.Poll.ID, &i.Poll.QuestionID, &i.Poll.CreatedAt)
-	return i, err
+const extendQuestion = `-- name: ExtendQuestion :execrows
+UPDATE questions
+SET
+    expired_at = expired_at + make_interval(secs => $1::float8),
+    num_extensions = num_extensions + 1
+WHERE
+    id = $2 AND
+    num_extensions < $3::int AND
+    expired_at > now() AND
+    deleted_at IS NULL AND
+    expired_at + make_interval(secs => $1::float8) <=
+        COALESCE(reopened_at, created_at) + make_interval(secs => $4::float8)
+`
+
+// the limits are checked by the update itself, so that concurrent extensions cannot exceed them
+func (q *Queries) ExtendQuestion(ctx context.Context, durationSecs float64, questionID uuid.UUID, maxExtensions int, maxLifetimeSecs float64) (int64, error) {
+	result, err := q.db.Exec(ctx, extendQuestion,
+		durationSecs,
+		questionID,
+		maxExtensions,
+		maxLifetimeSecs,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const getPollOptions = `-- name: GetPollOptions :many
-SELECT po.id, po.poll_id, po.label, po.index
-FROM
-    polls p
-    JOIN poll_options po ON p.id = po.poll_id
-WHERE p.id = $1
-GROUP BY po.id
-ORDER BY po.index
+const getBookmarkSubscriberIDs = `-- name: GetBookmarkSubscriberIDs :many
+SELECT user_id
+FROM bookmarks
+WHERE question_id = $1 AND notify
 `
 
-type GetPollOptionsRow struct {
-	PollOption PollOption
+// the users that bookmarked the question and asked to be notified about it
+func (q *Queries) GetBookmarkSubscriberIDs(ctx context.Context, questionID uuid.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, getBookmarkSubscriberIDs, questionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var user_id string
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) GetPollOptions(ctx context.Context, id uuid.UUID) ([]GetPollOptionsRow, error) {
-	rows, err := q.db.Query(ctx, getPollOptions, id)
+const getBookmarkedQuestions = `-- name: GetBookmarkedQuestions :many
+SELECT
+    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at, q.num_extensions, q.reopened_at, q.status, q.summary, q.deleted_at, q.edit_count, q.mentions,
+    l.id, l.question_id, l.location, l.name, l.address,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    q.author_id = $1 AS is_owned,
+    TRUE AS is_bookmarked
+FROM bookmarks bm
+    JOIN questions q ON bm.question_id = q.id
+    JOIN users u ON q.author_id = u.id
+    JOIN locations l ON q.id = l.question_id
+WHERE
+    bm.user_id = $1 AND q.deleted_at IS NULL AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = $1 AND b.blocked_id = q.author_id)
+           OR (b.blocker_id = q.author_id AND b.blocked_id = $1)
+    )
+ORDER BY bm.created_at DESC, q.id DESC
+LIMIT $3 OFFSET $2
+`
+
+type GetBookmarkedQuestionsRow struct {
+	Question     Question
+	Location     Location
+	User         User
+	IsOwned      bool
+	IsBookmarked bool
+}
+
+// bookmarks outlive the feed, so expired questions are included
+func (q *Queries) GetBookmarkedQuestions(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetBookmarkedQuestionsRow, error) {
+	rows, err := q.db.Query(ctx, getBookmarkedQuestions, userID, offsetNum, limitNum)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetPollOptionsRow{}
+	items := []GetBookmarkedQuestionsRow{}
 	for rows.Next() {
-		var i GetPollOptionsRow
+		var i GetBookmarkedQuestionsRow
 		if err := rows.Scan(
-			&i.PollOption.ID,
-			&i.PollOption.PollID,
-			&i.PollOption.Label,
-			&i.PollOption.Index,
+			&i.Question.ID,
+			&i.Question.AuthorID,
+			&i.Question.ContentType,
+			&i.Question.Title,
+			&i.Question.Body,
+			&i.Question.ImageUrls,
+			&i.Question.Category,
+			&i.Question.NumResponses,
+			&i.Question.CreatedAt,
+			&i.Question.EditedAt,
+			&i.Question.ExpiredAt,
+			&i.Question.NumExtensions,
+			&i.Question.ReopenedAt,
+			&i.Question.Status,
+			&i.Question.Summary,
+			&i.Question.DeletedAt,
+			&i.Question.EditCount,
+			&i.Question.Mentions,
+			&i.Location.ID,
+			&i.Location.QuestionID,
+			&i.Location.Location,
+			&i.Location.Name,
+			&i.Location.Address,
+			&i.User.ID,
+			&i.User.Username,
+			&i.User.Email,
+			&i.User.DisplayName,
+			&i.User.Role,
+			&i.User.AboutMe,
+			&i.User.AvatarUrl,
+			&i.User.CreatedAt,
+			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
+			&i.IsOwned,
+			&i.IsBookmarked,
 		); err != nil {
 			return nil, err
 		}
@@ -299,33 +614,859 @@ func (q *Queries) GetPollOptions(ctx context.Context, id uuid.UUID) ([]GetPollOp
 	return items, nil
 }
 
-const getPollVotes = `-- name: GetPollVotes :many
+const getConfirmAnswer = `-- name: GetConfirmAnswer :one
+SELECT is_yes
+FROM confirm_answers
+WHERE confirm_id = $1 AND user_id = $2
+`
+
+func (q *Queries) GetConfirmAnswer(ctx context.Context, confirmID uuid.UUID, userID string) (bool, error) {
+	row := q.db.QueryRow(ctx, getConfirmAnswer, confirmID, userID)
+	var is_yes bool
+	err := row.Scan(&is_yes)
+	return is_yes, err
+}
+
+const getConfirmByQuestionID = `-- name: GetConfirmByQuestionID :one
+SELECT c.id, c.question_id, c.created_at
+FROM confirms c
+WHERE c.question_id = $1
+`
+
+type GetConfirmByQuestionIDRow struct {
+	Confirm Confirm
+}
+
+func (q *Queries) GetConfirmByQuestionID(ctx context.Context, questionID uuid.UUID) (GetConfirmByQuestionIDRow, error) {
+	row := q.db.QueryRow(ctx, getConfirmByQuestionID, questionID)
+	var i GetConfirmByQuestionIDRow
+	err := row.Scan(&i.Confirm.ID, &i.Confirm.QuestionID, &i.Confirm.CreatedAt)
+	return i, err
+}
+
+const getConfirmTally = `-- name: GetConfirmTally :one
 SELECT
-    po.index,
-    COUNT(pv.user_id)         AS num_votes,
-    BOOL_OR(pv.user_id = $2)  AS is_selected
-FROM poll_options po
-    JOIN poll_votes pv ON pv.option_id = po.id
-WHERE po.poll_id = $1
-GROUP BY po.id
+    COUNT(*) FILTER (WHERE is_yes)     AS num_yes,
+    COUNT(*) FILTER (WHERE NOT is_yes) AS num_no,
+    COALESCE(SUM(power(0.5::float8, EXTRACT(EPOCH FROM now() - updated_at)::float8 / $1::float8)) FILTER (WHERE is_yes), 0)::float8     AS yes_weight,
+    COALESCE(SUM(power(0.5::float8, EXTRACT(EPOCH FROM now() - updated_at)::float8 / $1::float8)) FILTER (WHERE NOT is_yes), 0)::float8 AS no_weight
+FROM confirm_answers
+WHERE confirm_id = $2
 `
 
-type GetPollVotesRow struct {
-	Index      int
-	NumVotes   int
-	IsSelected bool
+type GetConfirmTallyRow struct {
+	NumYes    int
+	NumNo     int
+	YesWeight float64
+	NoWeight  float64
 }
 
-func (q *Queries) GetPollVotes(ctx context.Context, pollID uuid.UUID, userID string) ([]GetPollVotesRow, error) {
-	rows, err := q.db.Query(ctx, getPollVotes, pollID, userID)
+// every answer weighs 0.5^(age / half life), so that recent answers count more
+func (q *Queries) GetConfirmTally(ctx context.Context, halfLifeSeconds float64, confirmID uuid.UUID) (GetConfirmTallyRow, error) {
+	row := q.db.QueryRow(ctx, getConfirmTally, halfLifeSeconds, confirmID)
+	var i GetConfirmTallyRow
+	err := row.Scan(
+		&i.NumYes,
+		&i.NumNo,
+		&i.YesWeight,
+		&i.NoWeight,
+	)
+	return i, err
+}
+
+const getDeletedQuestionByID = `-- name: GetDeletedQuestionByID :one
+SELECT id, author_id, image_urls, deleted_at::timestamptz AS deleted_at
+FROM questions
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+type GetDeletedQuestionByIDRow struct {
+	ID        uuid.UUID
+	AuthorID  string
+	ImageUrls []string
+	DeletedAt time.Time
+}
+
+func (q *Queries) GetDeletedQuestionByID(ctx context.Context, id uuid.UUID) (GetDeletedQuestionByIDRow, error) {
+	row := q.db.QueryRow(ctx, getDeletedQuestionByID, id)
+	var i GetDeletedQuestionByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.ImageUrls,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getPollBallots = `-- name: GetPollBallots :many
+SELECT user_id, option_id::uuid AS option_id
+FROM poll_votes
+WHERE poll_id = $1 AND option_id IS NOT NULL
+ORDER BY user_id, rank
+`
+
+type GetPollBallotsRow struct {
+	UserID   string
+	OptionID uuid.UUID
+}
+
+// the ranked options of every voter, in order of preference
+func (q *Queries) GetPollBallots(ctx context.Context, pollID uuid.UUID) ([]GetPollBallotsRow, error) {
+	rows, err := q.db.Query(ctx, getPollBallots, pollID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetPollVotesRow{}
+	items := []GetPollBallotsRow{}
 	for rows.Next() {
-		var i GetPollVotesRow
-		if err := rows.Scan(&i.Index, &i.NumVotes, &i.IsSelected); err != nil {
+		var i GetPollBallotsRow
+		if err := rows.Scan(&i.UserID, &i.OptionID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollByQuestionID = `-- name: GetPollByQuestionID :one
+SELECT p.id, p.question_id, p.created_at, p.type, p.max_selections, p.allow_other, p.results_visibility, p.is_anonymous, p.closes_at, p.closed_at, p.final_results
+FROM polls p
+WHERE p.question_id = $1
+`
+
+type GetPollByQuestionIDRow struct {
+	Poll Poll
+}
+
+func (q *Queries) GetPollByQuestionID(ctx context.Context, questionID uuid.UUID) (GetPollByQuestionIDRow, error) {
+	row := q.db.QueryRow(ctx, getPollByQuestionID, questionID)
+	var i GetPollByQuestionIDRow
+	err := row.Scan(
+		&i.Poll.ID,
+		&i.Poll.QuestionID,
+		&i.Poll.CreatedAt,
+		&i.Poll.Type,
+		&i.Poll.MaxSelections,
+		&i.Poll.AllowOther,
+		&i.Poll.ResultsVisibility,
+		&i.Poll.IsAnonymous,
+		&i.Poll.ClosesAt,
+		&i.Poll.ClosedAt,
+		&i.Poll.FinalResults,
+	)
+	return i, err
+}
+
+const getPollIDsToFinalize = `-- name: GetPollIDsToFinalize :many
+SELECT p.id
+FROM
+    polls p
+    JOIN questions q ON q.id = p.question_id
+WHERE p.final_results IS NULL AND q.deleted_at IS NULL AND (p.closed_at IS NOT NULL OR LEAST(p.closes_at, q.expired_at) <= now())
+ORDER BY p.closes_at NULLS LAST
+LIMIT $1
+`
+
+func (q *Queries) GetPollIDsToFinalize(ctx context.Context, limit int32) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, getPollIDsToFinalize, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollMaxBallotSize = `-- name: GetPollMaxBallotSize :one
+SELECT COALESCE(MAX(b.num_selections), 0)::int AS max_ballot_size
+FROM (
+    SELECT COUNT(*) AS num_selections
+    FROM poll_votes
+    WHERE poll_id = $1
+    GROUP BY user_id
+) b
+`
+
+// the most selections any single voter made on the poll
+func (q *Queries) GetPollMaxBallotSize(ctx context.Context, pollID uuid.UUID) (int, error) {
+	row := q.db.QueryRow(ctx, getPollMaxBallotSize, pollID)
+	var max_ballot_size int
+	err := row.Scan(&max_ballot_size)
+	return max_ballot_size, err
+}
+
+const getPollNumVoters = `-- name: GetPollNumVoters :one
+SELECT COUNT(DISTINCT user_id)
+FROM poll_votes
+WHERE poll_id = $1
+`
+
+func (q *Queries) GetPollNumVoters(ctx context.Context, pollID uuid.UUID) (int, error) {
+	row := q.db.QueryRow(ctx, getPollNumVoters, pollID)
+	var count int
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getPollOptionVoters = `-- name: GetPollOptionVoters :many
+SELECT
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    pv.rank
+FROM poll_votes pv
+    JOIN users u ON pv.user_id = u.id
+WHERE pv.option_id = $1
+ORDER BY pv.created_at DESC, u.id
+LIMIT $2 OFFSET $3
+`
+
+type GetPollOptionVotersRow struct {
+	User User
+	Rank *int
+}
+
+func (q *Queries) GetPollOptionVoters(ctx context.Context, optionID *uuid.UUID, limit int32, offset int32) ([]GetPollOptionVotersRow, error) {
+	rows, err := q.db.Query(ctx, getPollOptionVoters, optionID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPollOptionVotersRow{}
+	for rows.Next() {
+		var i GetPollOptionVotersRow
+		if err := rows.Scan(
+			&i.User.ID,
+			&i.User.Username,
+			&i.User.Email,
+			&i.User.DisplayName,
+			&i.User.Role,
+			&i.User.AboutMe,
+			&i.User.AvatarUrl,
+			&i.User.CreatedAt,
+			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollOptions = `-- name: GetPollOptions :many
+SELECT po.id, po.poll_id, po.label, po.index
+FROM
+    polls p
+    JOIN poll_options po ON p.id = po.poll_id
+WHERE p.id = $1
+GROUP BY po.id
+ORDER BY po.index
+`
+
+type GetPollOptionsRow struct {
+	PollOption PollOption
+}
+
+func (q *Queries) GetPollOptions(ctx context.Context, id uuid.UUID) ([]GetPollOptionsRow, error) {
+	rows, err := q.db.Query(ctx, getPollOptions, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPollOptionsRow{}
+	for rows.Next() {
+		var i GetPollOptionsRow
+		if err := rows.Scan(
+			&i.PollOption.ID,
+			&i.PollOption.PollID,
+			&i.PollOption.Label,
+			&i.PollOption.Index,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollOtherVotes = `-- name: GetPollOtherVotes :many
+SELECT
+    other_text::text       AS other_text,
+    user_id = $2           AS is_own
+FROM poll_votes
+WHERE poll_id = $1 AND option_id IS NULL
+ORDER BY created_at DESC
+`
+
+type GetPollOtherVotesRow struct {
+	OtherText string
+	IsOwn     bool
+}
+
+func (q *Queries) GetPollOtherVotes(ctx context.Context, pollID uuid.UUID, userID string) ([]GetPollOtherVotesRow, error) {
+	rows, err := q.db.Query(ctx, getPollOtherVotes, pollID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPollOtherVotesRow{}
+	for rows.Next() {
+		var i GetPollOtherVotesRow
+		if err := rows.Scan(&i.OtherText, &i.IsOwn); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollVoterIDs = `-- name: GetPollVoterIDs :many
+SELECT DISTINCT user_id
+FROM poll_votes
+WHERE poll_id = $1
+`
+
+func (q *Queries) GetPollVoterIDs(ctx context.Context, pollID uuid.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, getPollVoterIDs, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var user_id string
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollVotes = `-- name: GetPollVotes :many
+SELECT
+    po.index,
+    COUNT(pv.user_id) FILTER (WHERE pv.rank IS NULL OR pv.rank = 1) AS num_votes,
+    BOOL_OR(pv.user_id = $2)                                        AS is_selected,
+    COALESCE(MIN(pv.rank) FILTER (WHERE pv.user_id = $2), 0)::int   AS user_rank
+FROM poll_options po
+    JOIN poll_votes pv ON pv.option_id = po.id
+WHERE po.poll_id = $1
+GROUP BY po.id
+`
+
+type GetPollVotesRow struct {
+	Index      int
+	NumVotes   int
+	IsSelected bool
+	UserRank   int
+}
+
+// for RankedChoice polls, only first preferences are counted as votes
+func (q *Queries) GetPollVotes(ctx context.Context, pollID uuid.UUID, userID string) ([]GetPollVotesRow, error) {
+	rows, err := q.db.Query(ctx, getPollVotes, pollID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPollVotesRow{}
+	for rows.Next() {
+		var i GetPollVotesRow
+		if err := rows.Scan(
+			&i.Index,
+			&i.NumVotes,
+			&i.IsSelected,
+			&i.UserRank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublicQuestionsByAuthorID = `-- name: GetPublicQuestionsByAuthorID :many
+SELECT
+    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at, q.num_extensions, q.reopened_at, q.status, q.summary, q.deleted_at, q.edit_count, q.mentions,
+    l.id, l.question_id, l.location, l.name, l.address,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    q.author_id = $1 AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = $1
+    ) AS is_bookmarked
+FROM questions q
+    JOIN users u ON q.author_id = u.id
+    JOIN locations l ON q.id = l.question_id
+WHERE
+    q.author_id = $2 AND q.deleted_at IS NULL AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = $1 AND b.blocked_id = q.author_id)
+           OR (b.blocker_id = q.author_id AND b.blocked_id = $1)
+    )
+ORDER BY q.created_at DESC, q.id DESC
+LIMIT $4 OFFSET $3
+`
+
+type GetPublicQuestionsByAuthorIDRow struct {
+	Question     Question
+	Location     Location
+	User         User
+	IsOwned      bool
+	IsBookmarked bool
+}
+
+// the questions of another user, hidden if either of them blocks the other
+func (q *Queries) GetPublicQuestionsByAuthorID(ctx context.Context, userID string, authorID string, offsetNum int32, limitNum int32) ([]GetPublicQuestionsByAuthorIDRow, error) {
+	rows, err := q.db.Query(ctx, getPublicQuestionsByAuthorID,
+		userID,
+		authorID,
+		offsetNum,
+		limitNum,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPublicQuestionsByAuthorIDRow{}
+	for rows.Next() {
+		var i GetPublicQuestionsByAuthorIDRow
+		if err := rows.Scan(
+			&i.Question.ID,
+			&i.Question.AuthorID,
+			&i.Question.ContentType,
+			&i.Question.Title,
+			&i.Question.Body,
+			&i.Question.ImageUrls,
+			&i.Question.Category,
+			&i.Question.NumResponses,
+			&i.Question.CreatedAt,
+			&i.Question.EditedAt,
+			&i.Question.ExpiredAt,
+			&i.Question.NumExtensions,
+			&i.Question.ReopenedAt,
+			&i.Question.Status,
+			&i.Question.Summary,
+			&i.Question.DeletedAt,
+			&i.Question.EditCount,
+			&i.Question.Mentions,
+			&i.Location.ID,
+			&i.Location.QuestionID,
+			&i.Location.Location,
+			&i.Location.Name,
+			&i.Location.Address,
+			&i.User.ID,
+			&i.User.Username,
+			&i.User.Email,
+			&i.User.DisplayName,
+			&i.User.Role,
+			&i.User.AboutMe,
+			&i.User.AvatarUrl,
+			&i.User.CreatedAt,
+			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
+			&i.IsOwned,
+			&i.IsBookmarked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuestionByID = `-- name: GetQuestionByID :one
+SELECT
+    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at, q.num_extensions, q.reopened_at, q.status, q.summary, q.deleted_at, q.edit_count, q.mentions,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    l.id, l.question_id, l.location, l.name, l.address,
+    q.author_id = $2 AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = $2
+    ) AS is_bookmarked
+FROM
+    questions q
+    JOIN users u ON q.author_id = u.id
+    JOIN locations l ON q.id = l.question_id
+WHERE
+    q.id = $1 AND q.deleted_at IS NULL AND
+    -- the question is hidden from users its author blocked
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = q.author_id AND b.blocked_id = $2
+    )
+    LIMIT 1
+`
+
+type GetQuestionByIDRow struct {
+	Question     Question
+	User         User
+	Location     Location
+	IsOwned      bool
+	IsBookmarked bool
+}
+
+func (q *Queries) GetQuestionByID(ctx context.Context, iD uuid.UUID, authorID string) (GetQuestionByIDRow, error) {
+	row := q.db.QueryRow(ctx, getQuestionByID, iD, authorID)
+	var i GetQuestionByIDRow
+	err := row.Scan(
+		&i.Question.ID,
+		&i.Question.AuthorID,
+		&i.Question.ContentType,
+		&i.Question.Title,
+		&i.Question.Body,
+		&i.Question.ImageUrls,
+		&i.Question.Category,
+		&i.Question.NumResponses,
+		&i.Question.CreatedAt,
+		&i.Question.EditedAt,
+		&i.Question.ExpiredAt,
+		&i.Question.NumExtensions,
+		&i.Question.ReopenedAt,
+		&i.Question.Status,
+		&i.Question.Summary,
+		&i.Question.DeletedAt,
+		&i.Question.EditCount,
+		&i.Question.Mentions,
+		&i.User.ID,
+		&i.User.Username,
+		&i.User.Email,
+		&i.User.DisplayName,
+		&i.User.Role,
+		&i.User.AboutMe,
+		&i.User.AvatarUrl,
+		&i.User.CreatedAt,
+		&i.User.LastKnownLocation,
+		&i.User.UsernameChangedAt,
+		&i.Location.ID,
+		&i.Location.QuestionID,
+		&i.Location.Location,
+		&i.Location.Name,
+		&i.Location.Address,
+		&i.IsOwned,
+		&i.IsBookmarked,
+	)
+	return i, err
+}
+
+const getQuestionIDByConfirmID = `-- name: GetQuestionIDByConfirmID :one
+SELECT question_id
+FROM confirms
+WHERE id = $1
+`
+
+func (q *Queries) GetQuestionIDByConfirmID(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getQuestionIDByConfirmID, id)
+	var question_id uuid.UUID
+	err := row.Scan(&question_id)
+	return question_id, err
+}
+
+const getQuestionIDByPollID = `-- name: GetQuestionIDByPollID :one
+SELECT question_id
+FROM polls
+WHERE id = $1
+`
+
+func (q *Queries) GetQuestionIDByPollID(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getQuestionIDByPollID, id)
+	var question_id uuid.UUID
+	err := row.Scan(&question_id)
+	return question_id, err
+}
+
+const getQuestionIDByRatingID = `-- name: GetQuestionIDByRatingID :one
+SELECT question_id
+FROM ratings
+WHERE id = $1
+`
+
+func (q *Queries) GetQuestionIDByRatingID(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getQuestionIDByRatingID, id)
+	var question_id uuid.UUID
+	err := row.Scan(&question_id)
+	return question_id, err
+}
+
+const getQuestionIDsToExpire = `-- name: GetQuestionIDsToExpire :many
+SELECT id
+FROM questions
+WHERE status = 'Active' AND expired_at <= now() AND deleted_at IS NULL
+ORDER BY expired_at
+LIMIT $1
+`
+
+func (q *Queries) GetQuestionIDsToExpire(ctx context.Context, limit int32) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, getQuestionIDsToExpire, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuestionMentions = `-- name: GetQuestionMentions :one
+SELECT mentions
+FROM questions
+WHERE id = $1
+`
+
+func (q *Queries) GetQuestionMentions(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	row := q.db.QueryRow(ctx, getQuestionMentions, id)
+	var mentions []byte
+	err := row.Scan(&mentions)
+	return mentions, err
+}
+
+const getQuestionResponderIDs = `-- name: GetQuestionResponderIDs :many
+SELECT DISTINCT r.author_id
+FROM
+    responses r
+    JOIN questions q ON q.id = r.question_id
+WHERE r.question_id = $1 AND r.author_id <> q.author_id AND r.deleted_at IS NULL
+`
+
+func (q *Queries) GetQuestionResponderIDs(ctx context.Context, questionID uuid.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, getQuestionResponderIDs, questionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var author_id string
+		if err := rows.Scan(&author_id); err != nil {
+			return nil, err
+		}
+		items = append(items, author_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuestionResponseImageURLs = `-- name: GetQuestionResponseImageURLs :many
+SELECT unnest(image_urls)::text AS image_url
+FROM responses
+WHERE question_id = $1
+`
+
+func (q *Queries) GetQuestionResponseImageURLs(ctx context.Context, questionID uuid.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, getQuestionResponseImageURLs, questionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var image_url string
+		if err := rows.Scan(&image_url); err != nil {
+			return nil, err
+		}
+		items = append(items, image_url)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuestionRevisions = `-- name: GetQuestionRevisions :many
+SELECT id, question_id, title, body, category, image_urls, edited_at, created_at
+FROM question_revisions
+WHERE question_id = $1
+ORDER BY created_at DESC
+LIMIT $3 OFFSET $2
+`
+
+func (q *Queries) GetQuestionRevisions(ctx context.Context, questionID uuid.UUID, offsetNum int32, limitNum int32) ([]QuestionRevision, error) {
+	rows, err := q.db.Query(ctx, getQuestionRevisions, questionID, offsetNum, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []QuestionRevision{}
+	for rows.Next() {
+		var i QuestionRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.QuestionID,
+			&i.Title,
+			&i.Body,
+			&i.Category,
+			&i.ImageUrls,
+			&i.EditedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuestionsByUserID = `-- name: GetQuestionsByUserID :many
+SELECT
+    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at, q.num_extensions, q.reopened_at, q.status, q.summary, q.deleted_at, q.edit_count, q.mentions,
+    l.id, l.question_id, l.location, l.name, l.address,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    q.author_id = $1 AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = $1
+    ) AS is_bookmarked
+FROM questions q
+         JOIN users u ON q.author_id = u.id
+         JOIN locations l ON q.id = l.question_id
+WHERE
+    q.author_id = $1 AND q.deleted_at IS NULL
+ORDER BY q.created_at DESC, q.id DESC
+LIMIT $3 OFFSET $2
+`
+
+type GetQuestionsByUserIDRow struct {
+	Question     Question
+	Location     Location
+	User         User
+	IsOwned      bool
+	IsBookmarked bool
+}
+
+func (q *Queries) GetQuestionsByUserID(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetQuestionsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getQuestionsByUserID, userID, offsetNum, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetQuestionsByUserIDRow{}
+	for rows.Next() {
+		var i GetQuestionsByUserIDRow
+		if err := rows.Scan(
+			&i.Question.ID,
+			&i.Question.AuthorID,
+			&i.Question.ContentType,
+			&i.Question.Title,
+			&i.Question.Body,
+			&i.Question.ImageUrls,
+			&i.Question.Category,
+			&i.Question.NumResponses,
+			&i.Question.CreatedAt,
+			&i.Question.EditedAt,
+			&i.Question.ExpiredAt,
+			&i.Question.NumExtensions,
+			&i.Question.ReopenedAt,
+			&i.Question.Status,
+			&i.Question.Summary,
+			&i.Question.DeletedAt,
+			&i.Question.EditCount,
+			&i.Question.Mentions,
+			&i.Location.ID,
+			&i.Location.QuestionID,
+			&i.Location.Location,
+			&i.Location.Name,
+			&i.Location.Address,
+			&i.User.ID,
+			&i.User.Username,
+			&i.User.Email,
+			&i.User.DisplayName,
+			&i.User.Role,
+			&i.User.AboutMe,
+			&i.User.AvatarUrl,
+			&i.User.CreatedAt,
+			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
+			&i.IsOwned,
+			&i.IsBookmarked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuestionsDeletedBefore = `-- name: GetQuestionsDeletedBefore :many
+SELECT id, author_id, image_urls, deleted_at::timestamptz AS deleted_at
+FROM questions
+WHERE deleted_at <= $1::timestamptz
+ORDER BY deleted_at
+LIMIT $2
+`
+
+type GetQuestionsDeletedBeforeRow struct {
+	ID        uuid.UUID
+	AuthorID  string
+	ImageUrls []string
+	DeletedAt time.Time
+}
+
+func (q *Queries) GetQuestionsDeletedBefore(ctx context.Context, deletedBefore time.Time, limitNum int32) ([]GetQuestionsDeletedBeforeRow, error) {
+	rows, err := q.db.Query(ctx, getQuestionsDeletedBefore, deletedBefore, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetQuestionsDeletedBeforeRow{}
+	for rows.Next() {
+		var i GetQuestionsDeletedBeforeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.ImageUrls,
+			&i.DeletedAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -336,94 +1477,90 @@ func (q *Queries) GetPollVotes(ctx context.Context, pollID uuid.UUID, userID str
 	return items, nil
 }
 
-const getQuestionByID = `-- name: GetQuestionByID :one
-SELECT
-    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at,
-    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.expo_push_token, u.last_known_location,
-    l.id, l.question_id, l.location, l.name, l.address,
-    q.author_id = $2 AS is_owned
+const getQuestionsExpiredBetween = `-- name: GetQuestionsExpiredBetween :many
+SELECT q.id, q.expired_at, l.id, l.question_id, l.location, l.name, l.address
 FROM
     questions q
-    JOIN users u ON q.author_id = u.id
     JOIN locations l ON q.id = l.question_id
-WHERE
-    q.id = $1
-    LIMIT 1
+WHERE q.expired_at > $1 AND q.expired_at <= $2 AND q.deleted_at IS NULL
 `
 
-type GetQuestionByIDRow struct {
-	Question Question
-	User     User
-	Location Location
-	IsOwned  bool
+type GetQuestionsExpiredBetweenRow struct {
+	ID        uuid.UUID
+	ExpiredAt time.Time
+	Location  Location
 }
 
-func (q *Queries) GetQuestionByID(ctx context.Context, iD uuid.UUID, authorID string) (GetQuestionByIDRow, error) {
-	row := q.db.QueryRow(ctx, getQuestionByID, iD, authorID)
-	var i GetQuestionByIDRow
-	err := row.Scan(
-		&i.Question.ID,
-		&i.Question.AuthorID,
-		&i.Question.ContentType,
-		&i.Question.Title,
-		&i.Question.Body,
-		&i.Question.ImageUrls,
-		&i.Question.Category,
-		&i.Question.NumResponses,
-		&i.Question.CreatedAt,
-		&i.Question.EditedAt,
-		&i.Question.ExpiredAt,
-		&i.User.ID,
-		&i.User.Username,
-		&i.User.Email,
-		&i.User.DisplayName,
-		&i.User.Role,
-		&i.User.AboutMe,
-		&i.User.AvatarUrl,
-		&i.User.CreatedAt,
-		&i.User.ExpoPushToken,
-		&i.User.LastKnownLocation,
-		&i.Location.ID,
-		&i.Location.QuestionID,
-		&i.Location.Location,
-		&i.Location.Name,
-		&i.Location.Address,
-		&i.IsOwned,
-	)
-	return i, err
+func (q *Queries) GetQuestionsExpiredBetween(ctx context.Context, expiredAfter time.Time, expiredUntil time.Time) ([]GetQuestionsExpiredBetweenRow, error) {
+	rows, err := q.db.Query(ctx, getQuestionsExpiredBetween, expiredAfter, expiredUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetQuestionsExpiredBetweenRow{}
+	for rows.Next() {
+		var i GetQuestionsExpiredBetweenRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ExpiredAt,
+			&i.Location.ID,
+			&i.Location.QuestionID,
+			&i.Location.Location,
+			&i.Location.Name,
+			&i.Location.Address,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getQuestionsByUserID = `-- name: GetQuestionsByUserID :many
+const getQuestionsFromFollowedUsers = `-- name: GetQuestionsFromFollowedUsers :many
 SELECT
-    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at,
+    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at, q.num_extensions, q.reopened_at, q.status, q.summary, q.deleted_at, q.edit_count, q.mentions,
     l.id, l.question_id, l.location, l.name, l.address,
-    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.expo_push_token, u.last_known_location,
-    q.author_id = $1 AS is_owned
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    FALSE AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = $1
+    ) AS is_bookmarked
 FROM questions q
-         JOIN users u ON q.author_id = u.id
-         JOIN locations l ON q.id = l.question_id
+    JOIN follows f ON f.followee_id = q.author_id
+    JOIN users u ON q.author_id = u.id
+    JOIN locations l ON q.id = l.question_id
 WHERE
-    q.author_id = $1
+    f.follower_id = $1 AND q.deleted_at IS NULL AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = $1 AND b.blocked_id = q.author_id)
+           OR (b.blocker_id = q.author_id AND b.blocked_id = $1)
+    )
 ORDER BY q.created_at DESC, q.id DESC
 LIMIT $3 OFFSET $2
 `
 
-type GetQuestionsByUserIDRow struct {
-	Question Question
-	Location Location
-	User     User
-	IsOwned  bool
+type GetQuestionsFromFollowedUsersRow struct {
+	Question     Question
+	Location     Location
+	User         User
+	IsOwned      bool
+	IsBookmarked bool
 }
 
-func (q *Queries) GetQuestionsByUserID(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetQuestionsByUserIDRow, error) {
-	rows, err := q.db.Query(ctx, getQuestionsByUserID, userID, offsetNum, limitNum)
+func (q *Queries) GetQuestionsFromFollowedUsers(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetQuestionsFromFollowedUsersRow, error) {
+	rows, err := q.db.Query(ctx, getQuestionsFromFollowedUsers, userID, offsetNum, limitNum)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetQuestionsByUserIDRow{}
+	items := []GetQuestionsFromFollowedUsersRow{}
 	for rows.Next() {
-		var i GetQuestionsByUserIDRow
+		var i GetQuestionsFromFollowedUsersRow
 		if err := rows.Scan(
 			&i.Question.ID,
 			&i.Question.AuthorID,
@@ -436,6 +1573,13 @@ func (q *Queries) GetQuestionsByUserID(ctx context.Context, userID string, offse
 			&i.Question.CreatedAt,
 			&i.Question.EditedAt,
 			&i.Question.ExpiredAt,
+			&i.Question.NumExtensions,
+			&i.Question.ReopenedAt,
+			&i.Question.Status,
+			&i.Question.Summary,
+			&i.Question.DeletedAt,
+			&i.Question.EditCount,
+			&i.Question.Mentions,
 			&i.Location.ID,
 			&i.Location.QuestionID,
 			&i.Location.Location,
@@ -449,9 +1593,10 @@ func (q *Queries) GetQuestionsByUserID(ctx context.Context, userID string, offse
 			&i.User.AboutMe,
 			&i.User.AvatarUrl,
 			&i.User.CreatedAt,
-			&i.User.ExpoPushToken,
 			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
 			&i.IsOwned,
+			&i.IsBookmarked,
 		); err != nil {
 			return nil, err
 		}
@@ -465,14 +1610,23 @@ func (q *Queries) GetQuestionsByUserID(ctx context.Context, userID string, offse
 
 const getQuestionsInRadiusFeed = `-- name: GetQuestionsInRadiusFeed :many
 SELECT
-    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at,
+    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at, q.num_extensions, q.reopened_at, q.status, q.summary, q.deleted_at, q.edit_count, q.mentions,
     l.id, l.question_id, l.location, l.name, l.address,
-    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.expo_push_token, u.last_known_location,
-    q.author_id = $1 AS is_owned
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    q.author_id = $1 AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = $1
+    ) AS is_bookmarked
 FROM questions q
          JOIN users u ON q.author_id = u.id
          JOIN locations l ON q.id = l.question_id
-WHERE q.expired_at > now() AND
+WHERE q.expired_at > now() AND q.deleted_at IS NULL AND
+      NOT EXISTS (
+          SELECT 1 FROM user_blocks b
+          WHERE (b.blocker_id = $1 AND b.blocked_id = q.author_id)
+             OR (b.blocker_id = q.author_id AND b.blocked_id = $1)
+      ) AND
       ST_DWithin(
               l.location::geography,
               ST_SetSRID(
@@ -498,12 +1652,14 @@ type GetQuestionsInRadiusFeedParams struct {
 }
 
 type GetQuestionsInRadiusFeedRow struct {
-	Question Question
-	Location Location
-	User     User
-	IsOwned  bool
+	Question     Question
+	Location     Location
+	User         User
+	IsOwned      bool
+	IsBookmarked bool
 }
 
+// questions of users blocked by or blocking the viewer are hidden
 func (q *Queries) GetQuestionsInRadiusFeed(ctx context.Context, arg GetQuestionsInRadiusFeedParams) ([]GetQuestionsInRadiusFeedRow, error) {
 	rows, err := q.db.Query(ctx, getQuestionsInRadiusFeed,
 		arg.UserID,
@@ -532,6 +1688,13 @@ func (q *Queries) GetQuestionsInRadiusFeed(ctx context.Context, arg GetQuestions
 			&i.Question.CreatedAt,
 			&i.Question.EditedAt,
 			&i.Question.ExpiredAt,
+			&i.Question.NumExtensions,
+			&i.Question.ReopenedAt,
+			&i.Question.Status,
+			&i.Question.Summary,
+			&i.Question.DeletedAt,
+			&i.Question.EditCount,
+			&i.Question.Mentions,
 			&i.Location.ID,
 			&i.Location.QuestionID,
 			&i.Location.Location,
@@ -545,9 +1708,10 @@ func (q *Queries) GetQuestionsInRadiusFeed(ctx context.Context, arg GetQuestions
 			&i.User.AboutMe,
 			&i.User.AvatarUrl,
 			&i.User.CreatedAt,
-			&i.User.ExpoPushToken,
 			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
 			&i.IsOwned,
+			&i.IsBookmarked,
 		); err != nil {
 			return nil, err
 		}
@@ -561,16 +1725,26 @@ func (q *Queries) GetQuestionsInRadiusFeed(ctx context.Context, arg GetQuestions
 
 const getQuestionsInRadiusFeedByCategory = `-- name: GetQuestionsInRadiusFeedByCategory :many
 SELECT
-    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at,
+    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at, q.num_extensions, q.reopened_at, q.status, q.summary, q.deleted_at, q.edit_count, q.mentions,
     l.id, l.question_id, l.location, l.name, l.address,
-    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.expo_push_token, u.last_known_location,
-    q.author_id = $1 AS is_owned
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    q.author_id = $1 AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = $1
+    ) AS is_bookmarked
 FROM questions q
          JOIN users u ON q.author_id = u.id
          JOIN locations l ON q.id = l.question_id
 WHERE
     q.category = $2 AND
     q.expired_at > now() AND
+    q.deleted_at IS NULL AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = $1 AND b.blocked_id = q.author_id)
+           OR (b.blocker_id = q.author_id AND b.blocked_id = $1)
+    ) AND
     ST_DWithin(
               l.location::geography,
               ST_SetSRID(
@@ -597,10 +1771,11 @@ type GetQuestionsInRadiusFeedByCategoryParams struct {
 }
 
 type GetQuestionsInRadiusFeedByCategoryRow struct {
-	Question Question
-	Location Location
-	User     User
-	IsOwned  bool
+	Question     Question
+	Location     Location
+	User         User
+	IsOwned      bool
+	IsBookmarked bool
 }
 
 func (q *Queries) GetQuestionsInRadiusFeedByCategory(ctx context.Context, arg GetQuestionsInRadiusFeedByCategoryParams) ([]GetQuestionsInRadiusFeedByCategoryRow, error) {
@@ -632,6 +1807,13 @@ func (q *Queries) GetQuestionsInRadiusFeedByCategory(ctx context.Context, arg Ge
 			&i.Question.CreatedAt,
 			&i.Question.EditedAt,
 			&i.Question.ExpiredAt,
+			&i.Question.NumExtensions,
+			&i.Question.ReopenedAt,
+			&i.Question.Status,
+			&i.Question.Summary,
+			&i.Question.DeletedAt,
+			&i.Question.EditCount,
+			&i.Question.Mentions,
 			&i.Location.ID,
 			&i.Location.QuestionID,
 			&i.Location.Location,
@@ -645,9 +1827,94 @@ func (q *Queries) GetQuestionsInRadiusFeedByCategory(ctx context.Context, arg Ge
 			&i.User.AboutMe,
 			&i.User.AvatarUrl,
 			&i.User.CreatedAt,
-			&i.User.ExpoPushToken,
 			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
 			&i.IsOwned,
+			&i.IsBookmarked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuestionsToArchive = `-- name: GetQuestionsToArchive :many
+SELECT id, status, expired_at, num_responses, image_urls
+FROM questions
+WHERE status = 'Expired' AND expired_at <= $1 AND deleted_at IS NULL
+ORDER BY expired_at
+LIMIT $2
+`
+
+type GetQuestionsToArchiveRow struct {
+	ID           uuid.UUID
+	Status       string
+	ExpiredAt    time.Time
+	NumResponses int
+	ImageUrls    []string
+}
+
+func (q *Queries) GetQuestionsToArchive(ctx context.Context, expiredBefore time.Time, limitNum int32) ([]GetQuestionsToArchiveRow, error) {
+	rows, err := q.db.Query(ctx, getQuestionsToArchive, expiredBefore, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetQuestionsToArchiveRow{}
+	for rows.Next() {
+		var i GetQuestionsToArchiveRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.ExpiredAt,
+			&i.NumResponses,
+			&i.ImageUrls,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuestionsToPurge = `-- name: GetQuestionsToPurge :many
+SELECT id, status, expired_at, num_responses, image_urls
+FROM questions
+WHERE status = 'Archived' AND expired_at <= $1 AND deleted_at IS NULL
+ORDER BY expired_at
+LIMIT $2
+`
+
+type GetQuestionsToPurgeRow struct {
+	ID           uuid.UUID
+	Status       string
+	ExpiredAt    time.Time
+	NumResponses int
+	ImageUrls    []string
+}
+
+func (q *Queries) GetQuestionsToPurge(ctx context.Context, expiredBefore time.Time, limitNum int32) ([]GetQuestionsToPurgeRow, error) {
+	rows, err := q.db.Query(ctx, getQuestionsToPurge, expiredBefore, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetQuestionsToPurgeRow{}
+	for rows.Next() {
+		var i GetQuestionsToPurgeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.ExpiredAt,
+			&i.NumResponses,
+			&i.ImageUrls,
 		); err != nil {
 			return nil, err
 		}
@@ -659,6 +1926,82 @@ func (q *Queries) GetQuestionsInRadiusFeedByCategory(ctx context.Context, arg Ge
 	return items, nil
 }
 
+const getRatingByQuestionID = `-- name: GetRatingByQuestionID :one
+SELECT r.id, r.question_id, r.min_value, r.max_value, r.min_label, r.max_label, r.created_at
+FROM ratings r
+WHERE r.question_id = $1
+`
+
+type GetRatingByQuestionIDRow struct {
+	Rating Rating
+}
+
+func (q *Queries) GetRatingByQuestionID(ctx context.Context, questionID uuid.UUID) (GetRatingByQuestionIDRow, error) {
+	row := q.db.QueryRow(ctx, getRatingByQuestionID, questionID)
+	var i GetRatingByQuestionIDRow
+	err := row.Scan(
+		&i.Rating.ID,
+		&i.Rating.QuestionID,
+		&i.Rating.MinValue,
+		&i.Rating.MaxValue,
+		&i.Rating.MinLabel,
+		&i.Rating.MaxLabel,
+		&i.Rating.CreatedAt,
+	)
+	return i, err
+}
+
+const getRatingHistogram = `-- name: GetRatingHistogram :many
+SELECT
+    value,
+    COUNT(user_id)           AS num_answers,
+    BOOL_OR(user_id = $2)    AS is_selected
+FROM rating_answers
+WHERE rating_id = $1
+GROUP BY value
+ORDER BY value
+`
+
+type GetRatingHistogramRow struct {
+	Value      int
+	NumAnswers int
+	IsSelected bool
+}
+
+func (q *Queries) GetRatingHistogram(ctx context.Context, ratingID uuid.UUID, userID string) ([]GetRatingHistogramRow, error) {
+	rows, err := q.db.Query(ctx, getRatingHistogram, ratingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetRatingHistogramRow{}
+	for rows.Next() {
+		var i GetRatingHistogramRow
+		if err := rows.Scan(&i.Value, &i.NumAnswers, &i.IsSelected); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const hardDeleteQuestion = `-- name: HardDeleteQuestion :execrows
+DELETE FROM questions
+WHERE id = $1 AND deleted_at <= $2::timestamptz
+`
+
+// responses, locations and question content are deleted along with the question
+func (q *Queries) HardDeleteQuestion(ctx context.Context, iD uuid.UUID, deletedBefore time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, hardDeleteQuestion, iD, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const incrementResponseAmount = `-- name: IncrementResponseAmount :exec
 UPDATE questions
 SET num_responses = num_responses + 1
@@ -671,13 +2014,14 @@ func (q *Queries) IncrementResponseAmount(ctx context.Context, id uuid.UUID) err
 }
 
 const isPollExpired = `-- name: IsPollExpired :one
-SELECT q.expired_at < now() AS is_expired
+SELECT (p.closed_at IS NOT NULL OR LEAST(p.closes_at, q.expired_at) < now())::boolean AS is_expired
 FROM
     polls p
     JOIN questions q ON q.id = p.question_id
 WHERE p.id = $1
 `
 
+// a poll closes early if it was closed by its owner or its closes_at is before the question's expiry
 func (q *Queries) IsPollExpired(ctx context.Context, id uuid.UUID) (bool, error) {
 	row := q.db.QueryRow(ctx, isPollExpired, id)
 	var is_expired bool
@@ -685,13 +2029,250 @@ func (q *Queries) IsPollExpired(ctx context.Context, id uuid.UUID) (bool, error)
 	return is_expired, err
 }
 
-const setQuestionContentType = `-- name: SetQuestionContentType :exec
+const lockOpenPoll = `-- name: LockOpenPoll :one
+SELECT id
+FROM polls
+WHERE id = $1 AND closed_at IS NULL
+FOR SHARE
+`
+
+// keeps the poll from being edited or closed until the end of the transaction
+func (q *Queries) LockOpenPoll(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, lockOpenPoll, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const lockPollForEdit = `-- name: LockPollForEdit :one
+SELECT
+    p.id, p.question_id, p.created_at, p.type, p.max_selections, p.allow_other, p.results_visibility, p.is_anonymous, p.closes_at, p.closed_at, p.final_results,
+    (SELECT COUNT(*) FROM poll_votes pv WHERE pv.poll_id = p.id)::int AS num_votes
+FROM polls p
+WHERE p.id = $1
+FOR UPDATE
+`
+
+type LockPollForEditRow struct {
+	Poll     Poll
+	NumVotes int
+}
+
+func (q *Queries) LockPollForEdit(ctx context.Context, id uuid.UUID) (LockPollForEditRow, error) {
+	row := q.db.QueryRow(ctx, lockPollForEdit, id)
+	var i LockPollForEditRow
+	err := row.Scan(
+		&i.Poll.ID,
+		&i.Poll.QuestionID,
+		&i.Poll.CreatedAt,
+		&i.Poll.Type,
+		&i.Poll.MaxSelections,
+		&i.Poll.AllowOther,
+		&i.Poll.ResultsVisibility,
+		&i.Poll.IsAnonymous,
+		&i.Poll.ClosesAt,
+		&i.Poll.ClosedAt,
+		&i.Poll.FinalResults,
+		&i.NumVotes,
+	)
+	return i, err
+}
+
+const purgeQuestion = `-- name: PurgeQuestion :execrows
+DELETE FROM questions
+WHERE id = $1 AND status = 'Archived' AND expired_at <= $2
+`
+
+// responses, locations and question content are deleted along with the question
+func (q *Queries) PurgeQuestion(ctx context.Context, iD uuid.UUID, expiredBefore time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeQuestion, iD, expiredBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reopenExpiredPoll = `-- name: ReopenExpiredPoll :exec
+UPDATE polls p
+SET
+    closed_at = NULL,
+    final_results = NULL
+FROM questions q
+WHERE
+    q.id = p.question_id AND
+    p.question_id = $1 AND
+    (p.closed_at IS NULL OR p.closed_at >= q.expired_at) AND
+    (p.closes_at IS NULL OR p.closes_at > now())
+`
+
+// opens the poll of a question that is about to be reopened again, if it only closed because the question expired.
+// a poll closed by its owner, or by its own closing time, stays closed
+func (q *Queries) ReopenExpiredPoll(ctx context.Context, questionID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, reopenExpiredPoll, questionID)
+	return err
+}
+
+const reopenQuestion = `-- name: ReopenQuestion :execrows
+UPDATE questions
+SET
+    expired_at = now() + make_interval(secs => $1::float8),
+    reopened_at = now(),
+    status = 'Active',
+    summary = NULL
+WHERE
+    id = $2 AND
+    reopened_at IS NULL AND
+    expired_at <= now() AND
+    status <> 'Archived' AND
+    deleted_at IS NULL
+`
+
+// the summary is generated again when the reopened question expires.
+// a question can only be reopened once, which is checked by the update itself, so that concurrent requests cannot both reopen it
+func (q *Queries) ReopenQuestion(ctx context.Context, durationSecs float64, questionID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, reopenQuestion, durationSecs, questionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreQuestion = `-- name: RestoreQuestion :execrows
+UPDATE questions
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at > $2::timestamptz
+`
+
+func (q *Queries) RestoreQuestion(ctx context.Context, iD uuid.UUID, deletedAfter time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreQuestion, iD, deletedAfter)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setPollFinalResults = `-- name: SetPollFinalResults :execrows
+UPDATE polls
+SET final_results = $2
+WHERE id = $1 AND final_results IS NULL
+`
+
+func (q *Queries) SetPollFinalResults(ctx context.Context, iD uuid.UUID, finalResults []byte) (int64, error) {
+	result, err := q.db.Exec(ctx, setPollFinalResults, iD, finalResults)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setQuestionContentType = `-- name: SetQuestionContentType :execrows
+UPDATE questions
+SET content_type = $1
+WHERE id = $2 AND content_type = 'None'
+`
+
+// only a question without content can get content, so that concurrent requests cannot both add content
+func (q *Queries) SetQuestionContentType(ctx context.Context, contentType string, questionID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, setQuestionContentType, contentType, questionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setQuestionMentions = `-- name: SetQuestionMentions :exec
 UPDATE questions
-SET content_type = $2
+SET mentions = $2
 WHERE id = $1
 `
 
-func (q *Queries) SetQuestionContentType(ctx context.Context, iD uuid.UUID, contentType string) error {
-	_, err := q.db.Exec(ctx, setQuestionContentType, iD, contentType)
+func (q *Queries) SetQuestionMentions(ctx context.Context, iD uuid.UUID, mentions []byte) error {
+	_, err := q.db.Exec(ctx, setQuestionMentions, iD, mentions)
+	return err
+}
+
+const softDeleteQuestion = `-- name: SoftDeleteQuestion :execrows
+UPDATE questions
+SET deleted_at = current_timestamp
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) SoftDeleteQuestion(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteQuestion, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const unbookmarkQuestion = `-- name: UnbookmarkQuestion :exec
+DELETE FROM bookmarks
+WHERE user_id = $1 AND question_id = $2
+`
+
+func (q *Queries) UnbookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, unbookmarkQuestion, userID, questionID)
+	return err
+}
+
+const updatePollSettings = `-- name: UpdatePollSettings :exec
+UPDATE polls
+SET
+    max_selections = $1,
+    closes_at = $2
+WHERE id = $3
+`
+
+func (q *Queries) UpdatePollSettings(ctx context.Context, maxSelections int, closesAt *time.Time, iD uuid.UUID) error {
+	_, err := q.db.Exec(ctx, updatePollSettings, maxSelections, closesAt, iD)
 	return err
 }
+
+const upsertConfirmAnswer = `-- name: UpsertConfirmAnswer :execrows
+INSERT INTO confirm_answers (confirm_id, user_id, is_yes)
+SELECT $1, $2, $3
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM confirms c
+        JOIN questions q ON q.id = c.question_id
+        JOIN user_blocks b ON b.blocker_id = q.author_id
+    WHERE c.id = $1 AND b.blocked_id = $2
+)
+ON CONFLICT (confirm_id, user_id) DO UPDATE
+SET
+    is_yes = EXCLUDED.is_yes,
+    updated_at = now()
+`
+
+// nothing is inserted if the question's author blocked the user
+func (q *Queries) UpsertConfirmAnswer(ctx context.Context, confirmID uuid.UUID, userID string, isYes bool) (int64, error) {
+	result, err := q.db.Exec(ctx, upsertConfirmAnswer, confirmID, userID, isYes)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertRatingAnswer = `-- name: UpsertRatingAnswer :execrows
+INSERT INTO rating_answers (rating_id, user_id, value)
+SELECT $1, $2, $3
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM ratings r
+        JOIN questions q ON q.id = r.question_id
+        JOIN user_blocks b ON b.blocker_id = q.author_id
+    WHERE r.id = $1 AND b.blocked_id = $2
+)
+ON CONFLICT (rating_id, user_id) DO UPDATE
+SET
+    value = EXCLUDED.value,
+    updated_at = now()
+`
+
+// nothing is inserted if the question's author blocked the user
+func (q *Queries) UpsertRatingAnswer(ctx context.Context, ratingID uuid.UUID, userID string, value int) (int64, error) {
+	result, err := q.db.Exec(ctx, upsertRatingAnswer, ratingID, userID, value)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -4,6 +4,42 @@ import (
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 )
 
+func (row GetPollOptionVotersRow) ToDomainModel() model.PollVoter {
+	return model.PollVoter{
+		User: toDomainUser(row.User),
+		Rank: row.Rank,
+	}
+}
+
+// toDomainQuestion converts the embedded question, location and author of a row
+func toDomainQuestion(question Question, location Location, user User, isOwned bool, isBookmarked bool) model.Question {
+	return model.Question{
+		ID:       question.ID,
+		Author:   toDomainUser(user),
+		Title:    question.Title,
+		Body:     question.Body,
+		Category: model.Category(question.Category),
+		Content: model.QuestionContent{
+			Type: model.ContentType(question.ContentType),
+			// NOTE: Content data will need to be populated elsewhere
+		},
+		Location:        toDomainLocation(location),
+		ImageURLs:       question.ImageUrls,
+		IsOwned:         isOwned,
+		IsBookmarked:    isBookmarked,
+		ResponsesAmount: question.NumResponses,
+		CreatedAt:       question.CreatedAt,
+		EditedAt:        question.EditedAt,
+		EditCount:       question.EditCount,
+		Mentions:        toDomainMentions(question.Mentions),
+		ExpiredAt:       question.ExpiredAt,
+		NumExtensions:   question.NumExtensions,
+		ReopenedAt:      question.ReopenedAt,
+		Status:          model.QuestionStatus(question.Status),
+		Summary:         question.Summary,
+	}
+}
+
 func (row EditQuestionRow) ToDomainModel() model.Question {
 	return model.Question{
 		ID:       row.ID,
@@ -18,72 +54,93 @@ func (row EditQuestionRow) ToDomainModel() model.Question {
 		Location:        toDomainLocation(row.Location),
 		ImageURLs:       row.ImageUrls,
 		IsOwned:         row.IsOwned,
+		IsBookmarked:    row.IsBookmarked,
 		ResponsesAmount: row.NumResponses,
 		CreatedAt:       row.CreatedAt,
 		EditedAt:        row.EditedAt,
+		EditCount:       row.EditCount,
+		Mentions:        toDomainMentions(row.Mentions),
 		ExpiredAt:       row.ExpiredAt,
+		NumExtensions:   row.NumExtensions,
+		ReopenedAt:      row.ReopenedAt,
+		Status:          model.QuestionStatus(row.Status),
+		Summary:         row.Summary,
 	}
 }
 
 func (row GetQuestionByIDRow) ToDomainModel() model.Question {
-	return model.Question{
-		ID:       row.Question.ID,
-		Author:   toDomainUser(row.User),
-		Title:    row.Question.Title,
-		Body:     row.Question.Body,
-		Category: model.Category(row.Question.Category),
-		Content: model.QuestionContent{
-			Type: model.ContentType(row.Question.ContentType),
-			// NOTE: Content data will need to be populated elsewhere
-		},
-		Location:        toDomainLocation(row.Location),
-		ImageURLs:       row.Question.ImageUrls,
-		IsOwned:         row.IsOwned,
-		ResponsesAmount: row.Question.NumResponses,
-		CreatedAt:       row.Question.CreatedAt,
-		EditedAt:        row.Question.EditedAt,
-		ExpiredAt:       row.Question.ExpiredAt,
-	}
+	return toDomainQuestion(row.Question, row.Location, row.User, row.IsOwned, row.IsBookmarked)
 }
 
 func (row GetQuestionsInRadiusFeedRow) ToDomainModel() model.Question {
-	return model.Question{
-		ID:       row.Question.ID,
-		Author:   toDomainUser(row.User),
-		Title:    row.Question.Title,
-		Body:     row.Question.Body,
-		Category: model.Category(row.Question.Category),
-		Content: model.QuestionContent{
-			Type: model.ContentType(row.Question.ContentType),
-			// NOTE: Content data will need to be populated elsewhere
-		},
-		Location:        toDomainLocation(row.Location),
-		ImageURLs:       row.Question.ImageUrls,
-		IsOwned:         row.IsOwned,
-		ResponsesAmount: row.Question.NumResponses,
-		CreatedAt:       row.Question.CreatedAt,
-		EditedAt:        row.Question.EditedAt,
-		ExpiredAt:       row.Question.ExpiredAt,
-	}
+	return toDomainQuestion(row.Question, row.Location, row.User, row.IsOwned, row.IsBookmarked)
 }
 
 func (row GetQuestionsInRadiusFeedByCategoryRow) ToDomainModel() model.Question {
-	return model.Question{
-		ID:       row.Question.ID,
-		Author:   toDomainUser(row.User),
-		Title:    row.Question.Title,
-		Body:     row.Question.Body,
-		Category: model.Category(row.Question.Category),
-		Content: model.QuestionContent{
-			Type: model.ContentType(row.Question.ContentType),
-			// NOTE: Content data will need to be populated elsewhere
-		},
-		Location:        toDomainLocation(row.Location),
-		ImageURLs:       row.Question.ImageUrls,
-		IsOwned:         row.IsOwned,
-		ResponsesAmount: row.Question.NumResponses,
-		CreatedAt:       row.Question.CreatedAt,
-		EditedAt:        row.Question.EditedAt,
-		ExpiredAt:       row.Question.ExpiredAt,
+	return toDomainQuestion(row.Question, row.Location, row.User, row.IsOwned, row.IsBookmarked)
+}
+
+func (row GetQuestionsToArchiveRow) ToDomainModel() model.RetentionCandidate {
+	return model.RetentionCandidate{
+		QuestionID:   row.ID,
+		Status:       model.QuestionStatus(row.Status),
+		ExpiredAt:    row.ExpiredAt,
+		NumResponses: row.NumResponses,
+		ImageURLs:    row.ImageUrls,
+	}
+}
+
+func (row GetQuestionsToPurgeRow) ToDomainModel() model.RetentionCandidate {
+	return model.RetentionCandidate{
+		QuestionID:   row.ID,
+		Status:       model.QuestionStatus(row.Status),
+		ExpiredAt:    row.ExpiredAt,
+		NumResponses: row.NumResponses,
+		ImageURLs:    row.ImageUrls,
+	}
+}
+
+func (row GetDeletedQuestionByIDRow) ToDomainModel() model.DeletedContent {
+	return model.DeletedContent{
+		ID:         row.ID,
+		AuthorID:   row.AuthorID,
+		QuestionID: row.ID,
+		ImageURLs:  row.ImageUrls,
+		DeletedAt:  row.DeletedAt,
+	}
+}
+
+func (row GetQuestionsDeletedBeforeRow) ToDomainModel() model.DeletedContent {
+	return model.DeletedContent{
+		ID:         row.ID,
+		AuthorID:   row.AuthorID,
+		QuestionID: row.ID,
+		ImageURLs:  row.ImageUrls,
+		DeletedAt:  row.DeletedAt,
+	}
+}
+
+func (row QuestionRevision) ToDomainModel() model.QuestionRevision {
+	return model.QuestionRevision{
+		ID:         row.ID,
+		QuestionID: row.QuestionID,
+		Title:      row.Title,
+		Body:       row.Body,
+		Category:   model.Category(row.Category),
+		ImageURLs:  row.ImageUrls,
+		EditedAt:   row.EditedAt,
+		CreatedAt:  row.CreatedAt,
 	}
 }
+
+func (row GetQuestionsFromFollowedUsersRow) ToDomainModel() model.Question {
+	return toDomainQuestion(row.Question, row.Location, row.User, row.IsOwned, row.IsBookmarked)
+}
+
+func (row GetBookmarkedQuestionsRow) ToDomainModel() model.Question {
+	return toDomainQuestion(row.Question, row.Location, row.User, row.IsOwned, row.IsBookmarked)
+}
+
+func (row GetPublicQuestionsByAuthorIDRow) ToDomainModel() model.Question {
+	return toDomainQuestion(row.Question, row.Location, row.User, row.IsOwned, row.IsBookmarked)
+}
@@ -20,12 +20,19 @@ WITH new_response AS (
         body,
         image_urls
     )
-    VALUES ($1, $2, $3, $4)
-    RETURNING id, author_id, question_id, body, image_urls, created_at, edited_at
+    -- nothing is inserted if the question's author blocked the responder
+    SELECT $1, $2, $3, $4
+    WHERE NOT EXISTS (
+        SELECT 1
+        FROM questions q
+            JOIN user_blocks b ON b.blocker_id = q.author_id
+        WHERE q.id = $2 AND b.blocked_id = $1
+    )
+    RETURNING id, author_id, question_id, body, image_urls, created_at, edited_at, deleted_at, edit_count, num_replies, mentions
 )
 SELECT
-    nr.id, nr.author_id, nr.question_id, nr.body, nr.image_urls, nr.created_at, nr.edited_at,
-    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.expo_push_token, u.last_known_location,
+    nr.id, nr.author_id, nr.question_id, nr.body, nr.image_urls, nr.created_at, nr.edited_at, nr.deleted_at, nr.edit_count, nr.num_replies, nr.mentions,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
     TRUE AS is_owned
 FROM
     new_response nr
@@ -40,6 +47,10 @@ type CreateResponseRow struct {
 	ImageUrls  []string
 	CreatedAt  time.Time
 	EditedAt   time.Time
+	DeletedAt  *time.Time
+	EditCount  int
+	NumReplies int
+	Mentions   []byte
 	User       User
 	IsOwned    bool
 }
@@ -60,6 +71,10 @@ func (q *Queries) CreateResponse(ctx context.Context, authorID string, questionI
 		&i.ImageUrls,
 		&i.CreatedAt,
 		&i.EditedAt,
+		&i.DeletedAt,
+		&i.EditCount,
+		&i.NumReplies,
+		&i.Mentions,
 		&i.User.ID,
 		&i.User.Username,
 		&i.User.Email,
@@ -68,19 +83,40 @@ func (q *Queries) CreateResponse(ctx context.Context, authorID string, questionI
 		&i.User.AboutMe,
 		&i.User.AvatarUrl,
 		&i.User.CreatedAt,
-		&i.User.ExpoPushToken,
 		&i.User.LastKnownLocation,
+		&i.User.UsernameChangedAt,
 		&i.IsOwned,
 	)
 	return i, err
 }
 
-const deleteResponse = `-- name: DeleteResponse :exec
-DELETE FROM responses WHERE id = $1
+const createResponseRevision = `-- name: CreateResponseRevision :one
+INSERT INTO response_revisions (response_id, body, image_urls, edited_at)
+SELECT id, body, image_urls, edited_at
+FROM responses
+WHERE responses.id = $1
+FOR UPDATE
+RETURNING image_urls
+`
+
+// snapshots the current content before it is edited, the row stays locked until the end of the transaction
+func (q *Queries) CreateResponseRevision(ctx context.Context, id uuid.UUID) ([]string, error) {
+	row := q.db.QueryRow(ctx, createResponseRevision, id)
+	var image_urls []string
+	err := row.Scan(&image_urls)
+	return image_urls, err
+}
+
+const dropRemovedResponseRevisionImages = `-- name: DropRemovedResponseRevisionImages :exec
+UPDATE response_revisions rr
+SET image_urls = ARRAY(SELECT url FROM unnest(rr.image_urls) AS url WHERE url = ANY(r.image_urls))
+FROM responses r
+WHERE r.id = rr.response_id AND rr.response_id = $1 AND NOT (rr.image_urls <@ COALESCE(r.image_urls, '{}'))
 `
 
-func (q *Queries) DeleteResponse(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteResponse, id)
+// the images removed from the response are deleted, so its revisions only keep the images the response still has
+func (q *Queries) DropRemovedResponseRevisionImages(ctx context.Context, responseID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, dropRemovedResponseRevisionImages, responseID)
 	return err
 }
 
@@ -89,13 +125,16 @@ WITH edited_response AS (
     UPDATE responses
     SET
         body = $1,
-        edited_at = current_timestamp
-    WHERE responses.id = $2
-    RETURNING id, author_id, question_id, body, image_urls, created_at, edited_at
+        -- a null list keeps the current images
+        image_urls = coalesce($2::text[], image_urls),
+        edited_at = current_timestamp,
+        edit_count = edit_count + 1
+    WHERE responses.id = $3
+    RETURNING id, author_id, question_id, body, image_urls, created_at, edited_at, deleted_at, edit_count, num_replies, mentions
 )
 SELECT
-    er.id, er.author_id, er.question_id, er.body, er.image_urls, er.created_at, er.edited_at,
-    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.expo_push_token, u.last_known_location,
+    er.id, er.author_id, er.question_id, er.body, er.image_urls, er.created_at, er.edited_at, er.deleted_at, er.edit_count, er.num_replies, er.mentions,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
     TRUE AS is_owned
 FROM
     edited_response er
@@ -110,12 +149,16 @@ type EditResponseRow struct {
 	ImageUrls  []string
 	CreatedAt  time.Time
 	EditedAt   time.Time
+	DeletedAt  *time.Time
+	EditCount  int
+	NumReplies int
+	Mentions   []byte
 	User       User
 	IsOwned    bool
 }
 
-func (q *Queries) EditResponse(ctx context.Context, body string, iD uuid.UUID) (EditResponseRow, error) {
-	row := q.db.QueryRow(ctx, editResponse, body, iD)
+func (q *Queries) EditResponse(ctx context.Context, body string, imageUrls []string, iD uuid.UUID) (EditResponseRow, error) {
+	row := q.db.QueryRow(ctx, editResponse, body, imageUrls, iD)
 	var i EditResponseRow
 	err := row.Scan(
 		&i.ID,
@@ -125,6 +168,10 @@ func (q *Queries) EditResponse(ctx context.Context, body string, iD uuid.UUID) (
 		&i.ImageUrls,
 		&i.CreatedAt,
 		&i.EditedAt,
+		&i.DeletedAt,
+		&i.EditCount,
+		&i.NumReplies,
+		&i.Mentions,
 		&i.User.ID,
 		&i.User.Username,
 		&i.User.Email,
@@ -133,34 +180,142 @@ func (q *Queries) EditResponse(ctx context.Context, body string, iD uuid.UUID) (
 		&i.User.AboutMe,
 		&i.User.AvatarUrl,
 		&i.User.CreatedAt,
-		&i.User.ExpoPushToken,
 		&i.User.LastKnownLocation,
+		&i.User.UsernameChangedAt,
 		&i.IsOwned,
 	)
 	return i, err
 }
 
+const getDeletedResponseByID = `-- name: GetDeletedResponseByID :one
+SELECT id, author_id, question_id, image_urls, deleted_at::timestamptz AS deleted_at
+FROM responses
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+type GetDeletedResponseByIDRow struct {
+	ID         uuid.UUID
+	AuthorID   string
+	QuestionID uuid.UUID
+	ImageUrls  []string
+	DeletedAt  time.Time
+}
+
+func (q *Queries) GetDeletedResponseByID(ctx context.Context, id uuid.UUID) (GetDeletedResponseByIDRow, error) {
+	row := q.db.QueryRow(ctx, getDeletedResponseByID, id)
+	var i GetDeletedResponseByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.QuestionID,
+		&i.ImageUrls,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getPublicResponsesByAuthorID = `-- name: GetPublicResponsesByAuthorID :many
+SELECT
+    r.id, r.author_id, r.question_id, r.body, r.image_urls, r.created_at, r.edited_at, r.deleted_at, r.edit_count, r.num_replies, r.mentions,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    r.author_id = $1 AS is_owned
+FROM
+    responses r
+    JOIN users u ON r.author_id = u.id
+    JOIN questions q ON r.question_id = q.id
+WHERE
+    r.author_id = $2 AND r.deleted_at IS NULL AND q.deleted_at IS NULL AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = $1 AND b.blocked_id = r.author_id)
+           OR (b.blocker_id = r.author_id AND b.blocked_id = $1)
+           OR (b.blocker_id = q.author_id AND b.blocked_id = $1)
+    )
+ORDER BY r.created_at DESC, r.id DESC
+LIMIT $4 OFFSET $3
+`
+
+type GetPublicResponsesByAuthorIDRow struct {
+	Response Response
+	User     User
+	IsOwned  bool
+}
+
+// the responses of another user, hidden if either of them blocks the other, or if the question's author blocks the viewer
+func (q *Queries) GetPublicResponsesByAuthorID(ctx context.Context, userID string, authorID string, offsetNum int32, limitNum int32) ([]GetPublicResponsesByAuthorIDRow, error) {
+	rows, err := q.db.Query(ctx, getPublicResponsesByAuthorID,
+		userID,
+		authorID,
+		offsetNum,
+		limitNum,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPublicResponsesByAuthorIDRow{}
+	for rows.Next() {
+		var i GetPublicResponsesByAuthorIDRow
+		if err := rows.Scan(
+			&i.Response.ID,
+			&i.Response.AuthorID,
+			&i.Response.QuestionID,
+			&i.Response.Body,
+			&i.Response.ImageUrls,
+			&i.Response.CreatedAt,
+			&i.Response.EditedAt,
+			&i.Response.DeletedAt,
+			&i.Response.EditCount,
+			&i.Response.NumReplies,
+			&i.Response.Mentions,
+			&i.User.ID,
+			&i.User.Username,
+			&i.User.Email,
+			&i.User.DisplayName,
+			&i.User.Role,
+			&i.User.AboutMe,
+			&i.User.AvatarUrl,
+			&i.User.CreatedAt,
+			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
+			&i.IsOwned,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getQuestionsRespondedByUserID = `-- name: GetQuestionsRespondedByUserID :many
 SELECT
-    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at,
+    q.id, q.author_id, q.content_type, q.title, q.body, q.image_urls, q.category, q.num_responses, q.created_at, q.edited_at, q.expired_at, q.num_extensions, q.reopened_at, q.status, q.summary, q.deleted_at, q.edit_count, q.mentions,
     l.id, l.question_id, l.location, l.name, l.address,
-    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.expo_push_token, u.last_known_location,
-    q.author_id = $1 AS is_owned
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
+    q.author_id = $1 AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = $1
+    ) AS is_bookmarked
 FROM questions q
     JOIN responses r ON r.question_id = q.id
     JOIN users u ON q.author_id = u.id
     JOIN locations l ON q.id = l.question_id
 WHERE
-    r.author_id = $1
+    r.author_id = $1 AND r.deleted_at IS NULL AND q.deleted_at IS NULL
 ORDER BY r.created_at DESC, q.created_at DESC, q.id DESC
 LIMIT $3 OFFSET $2
 `
 
 type GetQuestionsRespondedByUserIDRow struct {
-	Question Question
-	Location Location
-	User     User
-	IsOwned  bool
+	Question     Question
+	Location     Location
+	User         User
+	IsOwned      bool
+	IsBookmarked bool
 }
 
 func (q *Queries) GetQuestionsRespondedByUserID(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetQuestionsRespondedByUserIDRow, error) {
@@ -184,6 +339,13 @@ func (q *Queries) GetQuestionsRespondedByUserID(ctx context.Context, userID stri
 			&i.Question.CreatedAt,
 			&i.Question.EditedAt,
 			&i.Question.ExpiredAt,
+			&i.Question.NumExtensions,
+			&i.Question.ReopenedAt,
+			&i.Question.Status,
+			&i.Question.Summary,
+			&i.Question.DeletedAt,
+			&i.Question.EditCount,
+			&i.Question.Mentions,
 			&i.Location.ID,
 			&i.Location.QuestionID,
 			&i.Location.Location,
@@ -197,9 +359,10 @@ func (q *Queries) GetQuestionsRespondedByUserID(ctx context.Context, userID stri
 			&i.User.AboutMe,
 			&i.User.AvatarUrl,
 			&i.User.CreatedAt,
-			&i.User.ExpoPushToken,
 			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
 			&i.IsOwned,
+			&i.IsBookmarked,
 		); err != nil {
 			return nil, err
 		}
@@ -213,14 +376,14 @@ func (q *Queries) GetQuestionsRespondedByUserID(ctx context.Context, userID stri
 
 const getResponseByID = `-- name: GetResponseByID :one
 SELECT
-    r.id, r.author_id, r.question_id, r.body, r.image_urls, r.created_at, r.edited_at,
-    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.expo_push_token, u.last_known_location,
+    r.id, r.author_id, r.question_id, r.body, r.image_urls, r.created_at, r.edited_at, r.deleted_at, r.edit_count, r.num_replies, r.mentions,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
     r.author_id = $1 AS is_owned
 FROM
     responses r
     JOIN users u ON r.author_id = u.id
 WHERE
-    r.id = $2
+    r.id = $2 AND r.deleted_at IS NULL
     LIMIT 1
 `
 
@@ -241,6 +404,10 @@ func (q *Queries) GetResponseByID(ctx context.Context, userID string, iD uuid.UU
 		&i.Response.ImageUrls,
 		&i.Response.CreatedAt,
 		&i.Response.EditedAt,
+		&i.Response.DeletedAt,
+		&i.Response.EditCount,
+		&i.Response.NumReplies,
+		&i.Response.Mentions,
 		&i.User.ID,
 		&i.User.Username,
 		&i.User.Email,
@@ -249,23 +416,78 @@ func (q *Queries) GetResponseByID(ctx context.Context, userID string, iD uuid.UU
 		&i.User.AboutMe,
 		&i.User.AvatarUrl,
 		&i.User.CreatedAt,
-		&i.User.ExpoPushToken,
 		&i.User.LastKnownLocation,
+		&i.User.UsernameChangedAt,
 		&i.IsOwned,
 	)
 	return i, err
 }
 
+const getResponseMentions = `-- name: GetResponseMentions :one
+SELECT mentions
+FROM responses
+WHERE id = $1
+`
+
+func (q *Queries) GetResponseMentions(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	row := q.db.QueryRow(ctx, getResponseMentions, id)
+	var mentions []byte
+	err := row.Scan(&mentions)
+	return mentions, err
+}
+
+const getResponseRevisions = `-- name: GetResponseRevisions :many
+SELECT id, response_id, body, image_urls, edited_at, created_at
+FROM response_revisions
+WHERE response_id = $1
+ORDER BY created_at DESC
+LIMIT $3 OFFSET $2
+`
+
+func (q *Queries) GetResponseRevisions(ctx context.Context, responseID uuid.UUID, offsetNum int32, limitNum int32) ([]ResponseRevision, error) {
+	rows, err := q.db.Query(ctx, getResponseRevisions, responseID, offsetNum, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ResponseRevision{}
+	for rows.Next() {
+		var i ResponseRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResponseID,
+			&i.Body,
+			&i.ImageUrls,
+			&i.EditedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getResponsesByQuestionID = `-- name: GetResponsesByQuestionID :many
 SELECT
-    r.id, r.author_id, r.question_id, r.body, r.image_urls, r.created_at, r.edited_at,
-    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.expo_push_token, u.last_known_location,
+    r.id, r.author_id, r.question_id, r.body, r.image_urls, r.created_at, r.edited_at, r.deleted_at, r.edit_count, r.num_replies, r.mentions,
+    u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at,
     r.author_id = $1 AS is_owned
 FROM
     responses r
     JOIN users u ON r.author_id = u.id
+    JOIN questions q ON r.question_id = q.id
 WHERE
-    r.question_id = $2
+    r.question_id = $2 AND r.deleted_at IS NULL AND q.deleted_at IS NULL AND
+    -- responses of users blocked by or blocking the viewer are hidden
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = $1 AND b.blocked_id = r.author_id)
+           OR (b.blocker_id = r.author_id AND b.blocked_id = $1)
+    )
 ORDER BY r.created_at DESC
 LIMIT $4 OFFSET $3
 `
@@ -298,6 +520,10 @@ func (q *Queries) GetResponsesByQuestionID(ctx context.Context, userID string, q
 			&i.Response.ImageUrls,
 			&i.Response.CreatedAt,
 			&i.Response.EditedAt,
+			&i.Response.DeletedAt,
+			&i.Response.EditCount,
+			&i.Response.NumReplies,
+			&i.Response.Mentions,
 			&i.User.ID,
 			&i.User.Username,
 			&i.User.Email,
@@ -306,8 +532,8 @@ func (q *Queries) GetResponsesByQuestionID(ctx context.Context, userID string, q
 			&i.User.AboutMe,
 			&i.User.AvatarUrl,
 			&i.User.CreatedAt,
-			&i.User.ExpoPushToken,
 			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
 			&i.IsOwned,
 		); err != nil {
 			return nil, err
@@ -319,3 +545,97 @@ func (q *Queries) GetResponsesByQuestionID(ctx context.Context, userID string, q
 	}
 	return items, nil
 }
+
+const getResponsesDeletedBefore = `-- name: GetResponsesDeletedBefore :many
+SELECT id, author_id, question_id, image_urls, deleted_at::timestamptz AS deleted_at
+FROM responses
+WHERE deleted_at <= $1::timestamptz
+ORDER BY deleted_at
+LIMIT $2
+`
+
+type GetResponsesDeletedBeforeRow struct {
+	ID         uuid.UUID
+	AuthorID   string
+	QuestionID uuid.UUID
+	ImageUrls  []string
+	DeletedAt  time.Time
+}
+
+func (q *Queries) GetResponsesDeletedBefore(ctx context.Context, deletedBefore time.Time, limitNum int32) ([]GetResponsesDeletedBeforeRow, error) {
+	rows, err := q.db.Query(ctx, getResponsesDeletedBefore, deletedBefore, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetResponsesDeletedBeforeRow{}
+	for rows.Next() {
+		var i GetResponsesDeletedBeforeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.QuestionID,
+			&i.ImageUrls,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const hardDeleteResponse = `-- name: HardDeleteResponse :execrows
+DELETE FROM responses
+WHERE id = $1 AND deleted_at <= $2::timestamptz
+`
+
+func (q *Queries) HardDeleteResponse(ctx context.Context, iD uuid.UUID, deletedBefore time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, hardDeleteResponse, iD, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreResponse = `-- name: RestoreResponse :execrows
+UPDATE responses
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at > $2::timestamptz
+`
+
+func (q *Queries) RestoreResponse(ctx context.Context, iD uuid.UUID, deletedAfter time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreResponse, iD, deletedAfter)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setResponseMentions = `-- name: SetResponseMentions :exec
+UPDATE responses
+SET mentions = $2
+WHERE id = $1
+`
+
+func (q *Queries) SetResponseMentions(ctx context.Context, iD uuid.UUID, mentions []byte) error {
+	_, err := q.db.Exec(ctx, setResponseMentions, iD, mentions)
+	return err
+}
+
+const softDeleteResponse = `-- name: SoftDeleteResponse :execrows
+UPDATE responses
+SET deleted_at = current_timestamp
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) SoftDeleteResponse(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteResponse, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
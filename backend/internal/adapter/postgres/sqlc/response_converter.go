@@ -14,6 +14,9 @@ func (row CreateResponseRow) ToDomainModel() model.Response {
 		ImageURLs:  row.ImageUrls,
 		CreatedAt:  row.CreatedAt,
 		EditedAt:   row.EditedAt,
+		EditCount:  row.EditCount,
+		ReplyCount: row.NumReplies,
+		Mentions:   toDomainMentions(row.Mentions),
 	}
 }
 
@@ -27,6 +30,9 @@ func (row GetResponsesByQuestionIDRow) ToDomainModel() model.Response {
 		ImageURLs:  row.Response.ImageUrls,
 		CreatedAt:  row.Response.CreatedAt,
 		EditedAt:   row.Response.EditedAt,
+		EditCount:  row.Response.EditCount,
+		ReplyCount: row.Response.NumReplies,
+		Mentions:   toDomainMentions(row.Response.Mentions),
 	}
 }
 
@@ -40,6 +46,9 @@ func (row EditResponseRow) ToDomainModel() model.Response {
 		ImageURLs:  row.ImageUrls,
 		CreatedAt:  row.CreatedAt,
 		EditedAt:   row.EditedAt,
+		EditCount:  row.EditCount,
+		ReplyCount: row.NumReplies,
+		Mentions:   toDomainMentions(row.Mentions),
 	}
 }
 
@@ -53,5 +62,55 @@ func (row GetResponseByIDRow) ToDomainModel() model.Response {
 		ImageURLs:  row.Response.ImageUrls,
 		CreatedAt:  row.Response.CreatedAt,
 		EditedAt:   row.Response.EditedAt,
+		EditCount:  row.Response.EditCount,
+		ReplyCount: row.Response.NumReplies,
+		Mentions:   toDomainMentions(row.Response.Mentions),
+	}
+}
+
+func (row GetDeletedResponseByIDRow) ToDomainModel() model.DeletedContent {
+	return model.DeletedContent{
+		ID:         row.ID,
+		AuthorID:   row.AuthorID,
+		QuestionID: row.QuestionID,
+		ImageURLs:  row.ImageUrls,
+		DeletedAt:  row.DeletedAt,
+	}
+}
+
+func (row GetResponsesDeletedBeforeRow) ToDomainModel() model.DeletedContent {
+	return model.DeletedContent{
+		ID:         row.ID,
+		AuthorID:   row.AuthorID,
+		QuestionID: row.QuestionID,
+		ImageURLs:  row.ImageUrls,
+		DeletedAt:  row.DeletedAt,
+	}
+}
+
+func (row ResponseRevision) ToDomainModel() model.ResponseRevision {
+	return model.ResponseRevision{
+		ID:         row.ID,
+		ResponseID: row.ResponseID,
+		Body:       row.Body,
+		ImageURLs:  row.ImageUrls,
+		EditedAt:   row.EditedAt,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+func (row GetPublicResponsesByAuthorIDRow) ToDomainModel() model.Response {
+	return model.Response{
+		ID:         row.Response.ID,
+		QuestionID: row.Response.QuestionID,
+		Author:     toDomainUser(row.User),
+		Body:       row.Response.Body,
+		IsOwned:    row.IsOwned,
+		ImageURLs:  row.Response.ImageUrls,
+		CreatedAt:  row.Response.CreatedAt,
+		EditedAt:   row.Response.EditedAt,
+		EditCount:  row.Response.EditCount,
+		ReplyCount: row.Response.NumReplies,
+		Mentions:   toDomainMentions(row.Response.Mentions),
 	}
 }
@@ -8,14 +8,23 @@ package sqlc
 import (
 	"context"
 	"time"
-
-	go_postgis "github.com/cridenour/go-postgis"
 )
 
+const blockUser = `-- name: BlockUser :exec
+INSERT INTO user_blocks (blocker_id, blocked_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+func (q *Queries) BlockUser(ctx context.Context, blockerID string, blockedID string) error {
+	_, err := q.db.Exec(ctx, blockUser, blockerID, blockedID)
+	return err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (id, username, email, display_name, avatar_url, role)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, username, email, display_name, role, about_me, avatar_url, created_at, expo_push_token, last_known_location
+RETURNING id, username, email, display_name, role, about_me, avatar_url, created_at, last_known_location, username_changed_at
 `
 
 type CreateUserParams struct {
@@ -46,25 +55,425 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.AboutMe,
 		&i.AvatarUrl,
 		&i.CreatedAt,
-		&i.ExpoPushToken,
 		&i.LastKnownLocation,
+		&i.UsernameChangedAt,
 	)
 	return i, err
 }
 
-const deleteUserPushToken = `-- name: DeleteUserPushToken :exec
-UPDATE users
-SET expo_push_token = NULL
+const decrementReplyCountsByAuthorID = `-- name: DecrementReplyCountsByAuthorID :exec
+UPDATE responses r
+SET num_replies = GREATEST(r.num_replies - c.num, 0)
+FROM (
+    SELECT response_id, COUNT(*) AS num
+    FROM comments
+    WHERE author_id = $1
+    GROUP BY response_id
+) c
+WHERE r.id = c.response_id AND r.author_id <> $1
+`
+
+// the comments of the author on other users' responses are deleted along with the author
+func (q *Queries) DecrementReplyCountsByAuthorID(ctx context.Context, authorID string) error {
+	_, err := q.db.Exec(ctx, decrementReplyCountsByAuthorID, authorID)
+	return err
+}
+
+const decrementResponseCountsByAuthorID = `-- name: DecrementResponseCountsByAuthorID :exec
+UPDATE questions q
+SET num_responses = GREATEST(q.num_responses - r.num, 0)
+FROM (
+    SELECT question_id, COUNT(*) AS num
+    FROM responses
+    WHERE author_id = $1 AND deleted_at IS NULL
+    GROUP BY question_id
+) r
+WHERE q.id = r.question_id AND q.author_id <> $1
+`
+
+// the responses of the author to other users' questions are deleted along with the author
+func (q *Queries) DecrementResponseCountsByAuthorID(ctx context.Context, authorID string) error {
+	_, err := q.db.Exec(ctx, decrementResponseCountsByAuthorID, authorID)
+	return err
+}
+
+const deleteAllUserDevices = `-- name: DeleteAllUserDevices :exec
+DELETE FROM user_devices
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteAllUserDevices(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, deleteAllUserDevices, userID)
+	return err
+}
+
+const deleteFollowsBetween = `-- name: DeleteFollowsBetween :exec
+DELETE FROM follows
+WHERE (follower_id = $1 AND followee_id = $2)
+   OR (follower_id = $2 AND followee_id = $1)
+`
+
+func (q *Queries) DeleteFollowsBetween(ctx context.Context, followerID string, followeeID string) error {
+	_, err := q.db.Exec(ctx, deleteFollowsBetween, followerID, followeeID)
+	return err
+}
+
+const deleteUser = `-- name: DeleteUser :execrows
+DELETE FROM users
 WHERE id = $1
 `
 
-func (q *Queries) DeleteUserPushToken(ctx context.Context, id string) error {
-	_, err := q.db.Exec(ctx, deleteUserPushToken, id)
+func (q *Queries) DeleteUser(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteUserDevice = `-- name: DeleteUserDevice :exec
+DELETE FROM user_devices
+WHERE user_id = $1 AND token = $2
+`
+
+func (q *Queries) DeleteUserDevice(ctx context.Context, userID string, token string) error {
+	_, err := q.db.Exec(ctx, deleteUserDevice, userID, token)
+	return err
+}
+
+const editUser = `-- name: EditUser :one
+UPDATE users
+SET
+    display_name = coalesce($1::text, display_name),
+    username = coalesce($2::text, username),
+    username_changed_at = CASE
+        WHEN $2::text <> username THEN current_timestamp
+        ELSE username_changed_at
+    END,
+    about_me = CASE
+        WHEN $3::text IS NULL THEN about_me
+        ELSE nullif($3::text, '')
+    END,
+    avatar_url = CASE
+        WHEN $4::text IS NULL THEN avatar_url
+        ELSE nullif($4::text, '')
+    END
+WHERE id = $5
+RETURNING id, username, email, display_name, role, about_me, avatar_url, created_at, last_known_location, username_changed_at
+`
+
+// null fields are left unchanged, an empty about me or avatar url clears it
+func (q *Queries) EditUser(ctx context.Context, displayName *string, username *string, aboutMe *string, avatarUrl *string, userID string) (User, error) {
+	row := q.db.QueryRow(ctx, editUser,
+		displayName,
+		username,
+		aboutMe,
+		avatarUrl,
+		userID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.DisplayName,
+		&i.Role,
+		&i.AboutMe,
+		&i.AvatarUrl,
+		&i.CreatedAt,
+		&i.LastKnownLocation,
+		&i.UsernameChangedAt,
+	)
+	return i, err
+}
+
+const followUser = `-- name: FollowUser :exec
+INSERT INTO follows (follower_id, followee_id)
+SELECT $1, $2
+WHERE NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE (b.blocker_id = $1 AND b.blocked_id = $2)
+       OR (b.blocker_id = $2 AND b.blocked_id = $1)
+)
+ON CONFLICT DO NOTHING
+`
+
+// users cannot follow each other while either of them blocks the other
+func (q *Queries) FollowUser(ctx context.Context, followerID string, followeeID string) error {
+	_, err := q.db.Exec(ctx, followUser, followerID, followeeID)
 	return err
 }
 
+const getBlockedUsers = `-- name: GetBlockedUsers :many
+SELECT u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at
+FROM user_blocks b
+    JOIN users u ON b.blocked_id = u.id
+WHERE b.blocker_id = $1
+ORDER BY b.created_at DESC
+LIMIT $3 OFFSET $2
+`
+
+type GetBlockedUsersRow struct {
+	User User
+}
+
+func (q *Queries) GetBlockedUsers(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetBlockedUsersRow, error) {
+	rows, err := q.db.Query(ctx, getBlockedUsers, userID, offsetNum, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetBlockedUsersRow{}
+	for rows.Next() {
+		var i GetBlockedUsersRow
+		if err := rows.Scan(
+			&i.User.ID,
+			&i.User.Username,
+			&i.User.Email,
+			&i.User.DisplayName,
+			&i.User.Role,
+			&i.User.AboutMe,
+			&i.User.AvatarUrl,
+			&i.User.CreatedAt,
+			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFollowerIDs = `-- name: GetFollowerIDs :many
+SELECT follower_id FROM follows
+WHERE followee_id = $1
+`
+
+func (q *Queries) GetFollowerIDs(ctx context.Context, followeeID string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getFollowerIDs, followeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var follower_id string
+		if err := rows.Scan(&follower_id); err != nil {
+			return nil, err
+		}
+		items = append(items, follower_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMutedUsers = `-- name: GetMutedUsers :many
+SELECT u.id, u.username, u.email, u.display_name, u.role, u.about_me, u.avatar_url, u.created_at, u.last_known_location, u.username_changed_at
+FROM user_mutes m
+    JOIN users u ON m.muted_id = u.id
+WHERE m.muter_id = $1
+ORDER BY m.created_at DESC
+LIMIT $3 OFFSET $2
+`
+
+type GetMutedUsersRow struct {
+	User User
+}
+
+func (q *Queries) GetMutedUsers(ctx context.Context, userID string, offsetNum int32, limitNum int32) ([]GetMutedUsersRow, error) {
+	rows, err := q.db.Query(ctx, getMutedUsers, userID, offsetNum, limitNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMutedUsersRow{}
+	for rows.Next() {
+		var i GetMutedUsersRow
+		if err := rows.Scan(
+			&i.User.ID,
+			&i.User.Username,
+			&i.User.Email,
+			&i.User.DisplayName,
+			&i.User.Role,
+			&i.User.AboutMe,
+			&i.User.AvatarUrl,
+			&i.User.CreatedAt,
+			&i.User.LastKnownLocation,
+			&i.User.UsernameChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNotifiableUserIDs = `-- name: GetNotifiableUserIDs :many
+SELECT id
+FROM users
+WHERE id = ANY($1::text[]) AND
+    NOT EXISTS (
+        SELECT 1 FROM user_mutes m
+        WHERE m.muter_id = users.id AND m.muted_id = $2
+    ) AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = users.id AND b.blocked_id = $2
+    )
+`
+
+// drops the users that muted or blocked the actor
+func (q *Queries) GetNotifiableUserIDs(ctx context.Context, userIds []string, actorID string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getNotifiableUserIDs, userIds, actorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOtherUsersResponseImageURLs = `-- name: GetOtherUsersResponseImageURLs :many
+SELECT url::text
+FROM responses r
+    JOIN questions q ON r.question_id = q.id,
+    unnest(r.image_urls) AS url
+WHERE q.author_id = $1 AND r.author_id <> $1
+`
+
+// the images of other users' responses to the user's questions, these responses are deleted along with the user
+func (q *Queries) GetOtherUsersResponseImageURLs(ctx context.Context, authorID string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getOtherUsersResponseImageURLs, authorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, err
+		}
+		items = append(items, url)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublicUserByID = `-- name: GetPublicUserByID :one
+SELECT id, username, email, display_name, role, about_me, avatar_url, created_at, last_known_location, username_changed_at
+FROM users
+WHERE id = $1 AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = users.id AND b.blocked_id = $2
+    )
+LIMIT 1
+`
+
+// users that blocked the viewer cannot be looked up by them
+func (q *Queries) GetPublicUserByID(ctx context.Context, userID string, viewerID string) (User, error) {
+	row := q.db.QueryRow(ctx, getPublicUserByID, userID, viewerID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.DisplayName,
+		&i.Role,
+		&i.AboutMe,
+		&i.AvatarUrl,
+		&i.CreatedAt,
+		&i.LastKnownLocation,
+		&i.UsernameChangedAt,
+	)
+	return i, err
+}
+
+const getPublicUserByUsername = `-- name: GetPublicUserByUsername :one
+SELECT id, username, email, display_name, role, about_me, avatar_url, created_at, last_known_location, username_changed_at
+FROM users
+WHERE lower(username) = lower($1) AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = users.id AND b.blocked_id = $2
+    )
+LIMIT 1
+`
+
+func (q *Queries) GetPublicUserByUsername(ctx context.Context, username string, viewerID string) (User, error) {
+	row := q.db.QueryRow(ctx, getPublicUserByUsername, username, viewerID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.DisplayName,
+		&i.Role,
+		&i.AboutMe,
+		&i.AvatarUrl,
+		&i.CreatedAt,
+		&i.LastKnownLocation,
+		&i.UsernameChangedAt,
+	)
+	return i, err
+}
+
+const getPushRecipients = `-- name: GetPushRecipients :many
+SELECT user_id, token
+FROM user_devices
+WHERE user_id = ANY($1::text[])
+AND last_seen_at >= $2
+`
+
+type GetPushRecipientsRow struct {
+	UserID string
+	Token  string
+}
+
+func (q *Queries) GetPushRecipients(ctx context.Context, userIds []string, activeSince time.Time) ([]GetPushRecipientsRow, error) {
+	rows, err := q.db.Query(ctx, getPushRecipients, userIds, activeSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPushRecipientsRow{}
+	for rows.Next() {
+		var i GetPushRecipientsRow
+		if err := rows.Scan(&i.UserID, &i.Token); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, username, email, display_name, role, about_me, avatar_url, created_at, expo_push_token, last_known_location
+SELECT id, username, email, display_name, role, about_me, avatar_url, created_at, last_known_location, username_changed_at
 FROM users
 WHERE id = $1 LIMIT 1
 `
@@ -81,15 +490,69 @@ func (q *Queries) GetUserByID(ctx context.Context, id string) (User, error) {
 		&i.AboutMe,
 		&i.AvatarUrl,
 		&i.CreatedAt,
-		&i.ExpoPushToken,
 		&i.LastKnownLocation,
+		&i.UsernameChangedAt,
 	)
 	return i, err
 }
 
+const getUserFollowerCount = `-- name: GetUserFollowerCount :one
+SELECT COUNT(*) FROM follows
+WHERE followee_id = $1
+`
+
+func (q *Queries) GetUserFollowerCount(ctx context.Context, followeeID string) (int, error) {
+	row := q.db.QueryRow(ctx, getUserFollowerCount, followeeID)
+	var count int
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getUserFollowingCount = `-- name: GetUserFollowingCount :one
+SELECT COUNT(*) FROM follows
+WHERE follower_id = $1
+`
+
+func (q *Queries) GetUserFollowingCount(ctx context.Context, followerID string) (int, error) {
+	row := q.db.QueryRow(ctx, getUserFollowingCount, followerID)
+	var count int
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getUserIDsInRadius = `-- name: GetUserIDsInRadius :many
+SELECT id
+FROM users
+WHERE ST_DWithin(
+    last_known_location,
+    ST_SetSRID(ST_MakePoint($1::float8, $2::float8), 4326)::geography,
+    $3::float8
+)
+`
+
+func (q *Queries) GetUserIDsInRadius(ctx context.Context, longitude float64, latitude float64, radiusMeters float64) ([]string, error) {
+	rows, err := q.db.Query(ctx, getUserIDsInRadius, longitude, latitude, radiusMeters)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserQuestionCount = `-- name: GetUserQuestionCount :one
 SELECT COUNT(*) FROM questions
-WHERE author_id = $1
+WHERE author_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUserQuestionCount(ctx context.Context, authorID string) (int, error) {
@@ -101,7 +564,7 @@ func (q *Queries) GetUserQuestionCount(ctx context.Context, authorID string) (in
 
 const getUserResponseCount = `-- name: GetUserResponseCount :one
 SELECT COUNT(*) FROM responses
-WHERE author_id = $1
+WHERE author_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUserResponseCount(ctx context.Context, authorID string) (int, error) {
@@ -111,32 +574,32 @@ func (q *Queries) GetUserResponseCount(ctx context.Context, authorID string) (in
 	return count, err
 }
 
-const getUsersInRadius = `-- name: GetUsersInRadius :many
-SELECT id, expo_push_token
+const getUsersByUsernames = `-- name: GetUsersByUsernames :many
+SELECT id, username
 FROM users
-WHERE ST_DWithin(
-    last_known_location,
-    ST_SetSRID(ST_MakePoint($1::float8, $2::float8), 4326)::geography,
-    $3::float8
-)
-AND expo_push_token IS NOT NULL
+WHERE lower(username) = ANY($1::text[]) AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = users.id AND b.blocked_id = $2
+    )
 `
 
-type GetUsersInRadiusRow struct {
-	ID            string
-	ExpoPushToken *string
+type GetUsersByUsernamesRow struct {
+	ID       string
+	Username string
 }
 
-func (q *Queries) GetUsersInRadius(ctx context.Context, longitude float64, latitude float64, radiusMeters float64) ([]GetUsersInRadiusRow, error) {
-	rows, err := q.db.Query(ctx, getUsersInRadius, longitude, latitude, radiusMeters)
+// users that blocked the author cannot be mentioned by them, usernames are expected in lower case
+func (q *Queries) GetUsersByUsernames(ctx context.Context, usernames []string, authorID string) ([]GetUsersByUsernamesRow, error) {
+	rows, err := q.db.Query(ctx, getUsersByUsernames, usernames, authorID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetUsersInRadiusRow{}
+	items := []GetUsersByUsernamesRow{}
 	for rows.Next() {
-		var i GetUsersInRadiusRow
-		if err := rows.Scan(&i.ID, &i.ExpoPushToken); err != nil {
+		var i GetUsersByUsernamesRow
+		if err := rows.Scan(&i.ID, &i.Username); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -147,42 +610,60 @@ func (q *Queries) GetUsersInRadius(ctx context.Context, longitude float64, latit
 	return items, nil
 }
 
-const updateUserDisplayName = `-- name: UpdateUserDisplayName :one
-UPDATE users
-SET display_name = $1
-WHERE id = $2
-RETURNING id, username, email, display_name, role, about_me, avatar_url, created_at, last_known_location, expo_push_token
-`
-
-type UpdateUserDisplayNameRow struct {
-	ID                string
-	Username          string
-	Email             string
-	DisplayName       string
-	Role              string
-	AboutMe           *string
-	AvatarUrl         *string
-	CreatedAt         time.Time
-	LastKnownLocation *go_postgis.PointS
-	ExpoPushToken     *string
-}
-
-func (q *Queries) UpdateUserDisplayName(ctx context.Context, displayName string, iD string) (UpdateUserDisplayNameRow, error) {
-	row := q.db.QueryRow(ctx, updateUserDisplayName, displayName, iD)
-	var i UpdateUserDisplayNameRow
-	err := row.Scan(
-		&i.ID,
-		&i.Username,
-		&i.Email,
-		&i.DisplayName,
-		&i.Role,
-		&i.AboutMe,
-		&i.AvatarUrl,
-		&i.CreatedAt,
-		&i.LastKnownLocation,
-		&i.ExpoPushToken,
-	)
-	return i, err
+const isUsernameTaken = `-- name: IsUsernameTaken :one
+SELECT EXISTS (
+    SELECT 1 FROM users
+    WHERE lower(username) = lower($1) AND id <> $2
+)
+`
+
+// usernames are compared case-insensitively, so that mentions are not ambiguous to readers
+func (q *Queries) IsUsernameTaken(ctx context.Context, username string, userID string) (bool, error) {
+	row := q.db.QueryRow(ctx, isUsernameTaken, username, userID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const muteUser = `-- name: MuteUser :exec
+INSERT INTO user_mutes (muter_id, muted_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+func (q *Queries) MuteUser(ctx context.Context, muterID string, mutedID string) error {
+	_, err := q.db.Exec(ctx, muteUser, muterID, mutedID)
+	return err
+}
+
+const unblockUser = `-- name: UnblockUser :exec
+DELETE FROM user_blocks
+WHERE blocker_id = $1 AND blocked_id = $2
+`
+
+func (q *Queries) UnblockUser(ctx context.Context, blockerID string, blockedID string) error {
+	_, err := q.db.Exec(ctx, unblockUser, blockerID, blockedID)
+	return err
+}
+
+const unfollowUser = `-- name: UnfollowUser :exec
+DELETE FROM follows
+WHERE follower_id = $1 AND followee_id = $2
+`
+
+func (q *Queries) UnfollowUser(ctx context.Context, followerID string, followeeID string) error {
+	_, err := q.db.Exec(ctx, unfollowUser, followerID, followeeID)
+	return err
+}
+
+const unmuteUser = `-- name: UnmuteUser :exec
+DELETE FROM user_mutes
+WHERE muter_id = $1 AND muted_id = $2
+`
+
+func (q *Queries) UnmuteUser(ctx context.Context, muterID string, mutedID string) error {
+	_, err := q.db.Exec(ctx, unmuteUser, muterID, mutedID)
+	return err
 }
 
 const updateUserLocation = `-- name: UpdateUserLocation :exec
@@ -196,13 +677,23 @@ func (q *Queries) UpdateUserLocation(ctx context.Context, iD string, longitude f
 	return err
 }
 
-const updateUserPushToken = `-- name: UpdateUserPushToken :exec
-UPDATE users
-SET expo_push_token = $2
-WHERE id = $1
+const upsertUserDevice = `-- name: UpsertUserDevice :exec
+INSERT INTO user_devices (user_id, token, platform, app_version)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (token) DO UPDATE
+SET
+    user_id = EXCLUDED.user_id,
+    platform = EXCLUDED.platform,
+    app_version = EXCLUDED.app_version,
+    last_seen_at = now()
 `
 
-func (q *Queries) UpdateUserPushToken(ctx context.Context, iD string, expoPushToken *string) error {
-	_, err := q.db.Exec(ctx, updateUserPushToken, iD, expoPushToken)
+func (q *Queries) UpsertUserDevice(ctx context.Context, userID string, token string, platform *string, appVersion *string) error {
+	_, err := q.db.Exec(ctx, upsertUserDevice,
+		userID,
+		token,
+		platform,
+		appVersion,
+	)
 	return err
 }
@@ -8,27 +8,10 @@ func (row User) ToDomainModel() model.AuthUser {
 	return toDomainAuthUser(row)
 }
 
-func (row UpdateUserDisplayNameRow) ToDomainModel() model.AuthUser {
-	var loc *model.GeoPoint
-	if row.LastKnownLocation != nil {
-		loc = &model.GeoPoint{
-			Latitude:  row.LastKnownLocation.Y,
-			Longitude: row.LastKnownLocation.X,
-		}
-	}
+func (row GetBlockedUsersRow) ToDomainModel() model.User {
+	return toDomainUser(row.User)
+}
 
-	return model.AuthUser{
-		User: model.User{
-			ID:                row.ID,
-			Username:          row.Username,
-			AvatarURL:         row.AvatarUrl,
-			AboutMe:           row.AboutMe,
-			DisplayName:       row.DisplayName,
-			Role:              model.Role(row.Role),
-			CreatedAt:         row.CreatedAt,
-			ExpoPushToken:     row.ExpoPushToken,
-			LastKnownLocation: loc,
-		},
-		Email: row.Email,
-	}
+func (row GetMutedUsersRow) ToDomainModel() model.User {
+	return toDomainUser(row.User)
 }
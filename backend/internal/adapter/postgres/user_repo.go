@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/ksha23/CS407-FactSnap/internal/adapter/postgres/sqlc"
@@ -46,6 +47,24 @@ func (r *userRepo) GetAuthUserByID(ctx context.Context, clerkID string) (model.A
 	return authUser.ToDomainModel(), nil
 }
 
+func (r *userRepo) GetPublicUserByID(ctx context.Context, viewerID, userID string) (model.User, error) {
+	user, err := r.query.GetPublicUserByID(ctx, userID, viewerID)
+	if err != nil {
+		return model.User{}, fmt.Errorf("UserRepo::GetPublicUserByID: %w", wrapError(err))
+	}
+
+	return user.ToDomainModel().User, nil
+}
+
+func (r *userRepo) GetPublicUserByUsername(ctx context.Context, viewerID, username string) (model.User, error) {
+	user, err := r.query.GetPublicUserByUsername(ctx, username, viewerID)
+	if err != nil {
+		return model.User{}, fmt.Errorf("UserRepo::GetPublicUserByUsername: %w", wrapError(err))
+	}
+
+	return user.ToDomainModel().User, nil
+}
+
 func (r *userRepo) GetUserQuestionCount(ctx context.Context, userID string) (int, error) {
 	count, err := r.query.GetUserQuestionCount(ctx, userID)
 	if err != nil {
@@ -69,43 +88,197 @@ func (r *userRepo) UpdateLocation(ctx context.Context, userID string, lat, long
 	return nil
 }
 
-func (r *userRepo) UpdatePushToken(ctx context.Context, userID, token string) error {
-	err := r.query.UpdateUserPushToken(ctx, userID, &token)
+func (r *userRepo) UpdatePushToken(ctx context.Context, userID string, params model.RegisterDeviceParams) error {
+	err := r.query.UpsertUserDevice(ctx, userID, params.Token, params.Platform, params.AppVersion)
 	if err != nil {
 		return fmt.Errorf("UserRepo::UpdatePushToken: %w", wrapError(err))
 	}
 	return nil
 }
 
-func (r *userRepo) DeletePushToken(ctx context.Context, userID string) error {
-	err := r.query.DeleteUserPushToken(ctx, userID)
+func (r *userRepo) DeletePushToken(ctx context.Context, userID, token string) error {
+	var err error
+	if token == "" {
+		err = r.query.DeleteAllUserDevices(ctx, userID)
+	} else {
+		err = r.query.DeleteUserDevice(ctx, userID, token)
+	}
 	if err != nil {
 		return fmt.Errorf("UserRepo::DeletePushToken: %w", wrapError(err))
 	}
 	return nil
 }
 
-func (r *userRepo) GetUsersInRadius(ctx context.Context, lat, long, radius float64) ([]model.User, error) {
-	users, err := r.query.GetUsersInRadius(ctx, lat, long, radius)
+func (r *userRepo) GetUserIDsInRadius(ctx context.Context, lat, long, radius float64) ([]string, error) {
+	userIDs, err := r.query.GetUserIDsInRadius(ctx, lat, long, radius)
 	if err != nil {
-		return nil, fmt.Errorf("UserRepo::GetUsersInRadius: %w", wrapError(err))
+		return nil, fmt.Errorf("UserRepo::GetUserIDsInRadius: %w", wrapError(err))
+	}
+	return userIDs, nil
+}
+
+func (r *userRepo) GetPushRecipients(ctx context.Context, userIDs []string, activeSince time.Time) ([]model.PushRecipient, error) {
+	rows, err := r.query.GetPushRecipients(ctx, userIDs, activeSince)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo::GetPushRecipients: %w", wrapError(err))
 	}
 
-	var domainUsers []model.User
-	for _, u := range users {
-		domainUsers = append(domainUsers, model.User{
-			ID:            u.ID,
-			ExpoPushToken: u.ExpoPushToken,
+	recipients := make([]model.PushRecipient, 0, len(rows))
+	for _, row := range rows {
+		recipients = append(recipients, model.PushRecipient{
+			UserID: row.UserID,
+			Token:  row.Token,
 		})
 	}
-	return domainUsers, nil
+	return recipients, nil
 }
 
+func (r *userRepo) EditUser(ctx context.Context, params model.EditUserParams) (model.AuthUser, error) {
+	row, err := r.query.EditUser(ctx, params.DisplayName, params.Username, params.AboutMe, params.AvatarURL, params.UserID)
+	if err != nil {
+		return model.AuthUser{}, fmt.Errorf("UserRepo::EditUser: %w", wrapError(err))
+	}
+	return row.ToDomainModel(), nil
+}
+
+func (r *userRepo) IsUsernameTaken(ctx context.Context, userID string, username string) (bool, error) {
+	taken, err := r.query.IsUsernameTaken(ctx, username, userID)
+	if err != nil {
+		return false, fmt.Errorf("UserRepo::IsUsernameTaken: %w", wrapError(err))
+	}
+	return taken, nil
+}
 
-func (r *userRepo) UpdateDisplayName(ctx context.Context, userID string, displayName string) (model.AuthUser, error) {
-    row, err := r.query.UpdateUserDisplayName(ctx, displayName, userID)
-    if err != nil {
-        return model.AuthUser{}, fmt.Errorf("UserRepo::UpdateDisplayName: %w", wrapError(err))
-    }
-    return row.ToDomainModel(), nil
-}
\ No newline at end of file
+func (r *userRepo) FollowUser(ctx context.Context, followerID, followeeID string) error {
+	err := r.query.FollowUser(ctx, followerID, followeeID)
+	if err != nil {
+		return fmt.Errorf("UserRepo::FollowUser: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *userRepo) UnfollowUser(ctx context.Context, followerID, followeeID string) error {
+	err := r.query.UnfollowUser(ctx, followerID, followeeID)
+	if err != nil {
+		return fmt.Errorf("UserRepo::UnfollowUser: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *userRepo) GetUserFollowerCount(ctx context.Context, userID string) (int, error) {
+	count, err := r.query.GetUserFollowerCount(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("UserRepo::GetUserFollowerCount: %w", wrapError(err))
+	}
+	return count, nil
+}
+
+func (r *userRepo) GetUserFollowingCount(ctx context.Context, userID string) (int, error) {
+	count, err := r.query.GetUserFollowingCount(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("UserRepo::GetUserFollowingCount: %w", wrapError(err))
+	}
+	return count, nil
+}
+
+func (r *userRepo) GetFollowerIDs(ctx context.Context, userID string) ([]string, error) {
+	followerIDs, err := r.query.GetFollowerIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo::GetFollowerIDs: %w", wrapError(err))
+	}
+	return followerIDs, nil
+}
+
+// BlockUser blocks the user and removes the follows between both users
+func (r *userRepo) BlockUser(ctx context.Context, blockerID, blockedID string) error {
+	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		if err := query.BlockUser(ctx, blockerID, blockedID); err != nil {
+			return fmt.Errorf("BlockUser: %w", wrapError(err))
+		}
+		if err := query.DeleteFollowsBetween(ctx, blockerID, blockedID); err != nil {
+			return fmt.Errorf("DeleteFollowsBetween: %w", wrapError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("UserRepo::BlockUser: %w", err)
+	}
+	return nil
+}
+
+func (r *userRepo) UnblockUser(ctx context.Context, blockerID, blockedID string) error {
+	err := r.query.UnblockUser(ctx, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("UserRepo::UnblockUser: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *userRepo) GetBlockedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.User, error) {
+	rows, err := r.query.GetBlockedUsers(ctx, userID, int32(page.Offset), int32(page.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo::GetBlockedUsers: %w", wrapError(err))
+	}
+	return convertRowsToDomain(rows), nil
+}
+
+func (r *userRepo) MuteUser(ctx context.Context, muterID, mutedID string) error {
+	err := r.query.MuteUser(ctx, muterID, mutedID)
+	if err != nil {
+		return fmt.Errorf("UserRepo::MuteUser: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *userRepo) UnmuteUser(ctx context.Context, muterID, mutedID string) error {
+	err := r.query.UnmuteUser(ctx, muterID, mutedID)
+	if err != nil {
+		return fmt.Errorf("UserRepo::UnmuteUser: %w", wrapError(err))
+	}
+	return nil
+}
+
+func (r *userRepo) GetMutedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.User, error) {
+	rows, err := r.query.GetMutedUsers(ctx, userID, int32(page.Offset), int32(page.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo::GetMutedUsers: %w", wrapError(err))
+	}
+	return convertRowsToDomain(rows), nil
+}
+
+func (r *userRepo) GetNotifiableUserIDs(ctx context.Context, userIDs []string, actorID string) ([]string, error) {
+	notifiableIDs, err := r.query.GetNotifiableUserIDs(ctx, userIDs, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo::GetNotifiableUserIDs: %w", wrapError(err))
+	}
+	return notifiableIDs, nil
+}
+
+func (r *userRepo) DeleteUser(ctx context.Context, userID string) ([]string, error) {
+	var orphanedMediaURLs []string
+	err := execTx(ctx, r.db, func(query *sqlc.Queries) error {
+		// the rows of the user cascade, so the counters of other users' content must be corrected first
+		if err := query.DecrementResponseCountsByAuthorID(ctx, userID); err != nil {
+			return fmt.Errorf("DecrementResponseCountsByAuthorID: %w", wrapError(err))
+		}
+		if err := query.DecrementReplyCountsByAuthorID(ctx, userID); err != nil {
+			return fmt.Errorf("DecrementReplyCountsByAuthorID: %w", wrapError(err))
+		}
+
+		// the responses of other users to the user's questions cascade too, along with their images
+		urls, err := query.GetOtherUsersResponseImageURLs(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("GetOtherUsersResponseImageURLs: %w", wrapError(err))
+		}
+		orphanedMediaURLs = urls
+
+		if _, err := query.DeleteUser(ctx, userID); err != nil {
+			return fmt.Errorf("DeleteUser: %w", wrapError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo::DeleteUser: %w", err)
+	}
+	return orphanedMediaURLs, nil
+}
@@ -15,6 +15,7 @@ import (
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/google/uuid"
 
 	"github.com/ksha23/CS407-FactSnap/internal/config"
@@ -216,6 +217,96 @@ func (c *Client) DeleteMany(ctx context.Context, urls []string) error {
 	return nil
 }
 
+// DeleteByUploader deletes every object stored under the uploader's prefix
+func (c *Client) DeleteByUploader(ctx context.Context, uploader string) error {
+	uploaderPrefix := path.Join(defaultKeyPrefix, sanitizeKeySegment(uploader)) + "/"
+
+	// a page holds at most 1000 keys, which is also the limit of a single DeleteObjects request
+	paginator := s3.NewListObjectsV2Paginator(c.s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.bucket),
+		Prefix: aws.String(uploaderPrefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("S3Client::DeleteByUploader: listing objects under %s failed: %w", uploaderPrefix, err)
+		}
+		// the prefix is lowercased, so only the objects whose metadata names the uploader are theirs
+		objects := make([]types.ObjectIdentifier, 0, len(page.Contents))
+		for _, object := range page.Contents {
+			headOutput, err := c.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+				Bucket: aws.String(c.bucket),
+				Key:    object.Key,
+			})
+			if err != nil {
+				err = c.handleS3Error(err, "media asset not found", "fetching media metadata failed")
+				if errs.ErrType(err) == errs.TypeNotFound {
+					continue
+				}
+				return fmt.Errorf("S3Client::DeleteByUploader: %w", err)
+			}
+			if headOutput.Metadata[metadataUploaderKey] == uploader {
+				objects = append(objects, types.ObjectIdentifier{Key: object.Key})
+			}
+		}
+		if len(objects) == 0 {
+			continue
+		}
+
+		output, err := c.s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(c.bucket),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("S3Client::DeleteByUploader: deleting objects under %s failed: %w", uploaderPrefix, err)
+		}
+		if len(output.Errors) > 0 {
+			return fmt.Errorf("S3Client::DeleteByUploader: could not delete %d objects under %s, first error: %s",
+				len(output.Errors), uploaderPrefix, aws.ToString(output.Errors[0].Message))
+		}
+	}
+
+	return nil
+}
+
+// PresignURL returns a temporary link to download the object, even if a CDN is configured
+func (c *Client) PresignURL(ctx context.Context, key string) (string, time.Time, error) {
+	url, err := c.presignGetURL(ctx, strings.TrimPrefix(key, "/"))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("S3Client::PresignURL: %w", err)
+	}
+
+	return url, time.Now().Add(c.presignDuration), nil
+}
+
+// IsUploadedBy reports whether the media at the url was uploaded by the uploader and still exists
+func (c *Client) IsUploadedBy(ctx context.Context, url string, uploader string) (bool, error) {
+	objectKey, err := extractObjectKey(url, c.cdnBaseURL)
+	if err != nil {
+		return false, nil
+	}
+
+	// uploads are stored under the uploader's own prefix
+	uploaderPrefix := path.Join(defaultKeyPrefix, sanitizeKeySegment(uploader)) + "/"
+	if !strings.HasPrefix(objectKey, uploaderPrefix) {
+		return false, nil
+	}
+
+	headOutput, err := c.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(objectKey),
+	})
+	if err != nil {
+		err = c.handleS3Error(err, "media asset not found", "fetching media metadata failed")
+		if errs.ErrType(err) == errs.TypeNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("S3Client::IsUploadedBy: %w", err)
+	}
+
+	return headOutput.Metadata[metadataUploaderKey] == uploader, nil
+}
+
 func (c *Client) presignGetURL(ctx context.Context, key string) (string, error) {
 	presigned, err := c.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(c.bucket),
@@ -29,13 +29,31 @@ type App struct {
 	UserService         port.UserService
 	QuestionService     port.QuestionService
 	ResponseService     port.ResponseService
+	CommentService      port.CommentService
 	MediaService        port.MediaService
 	NotificationService port.NotificationService
+	InboxService        port.InboxService
 
 	// repos
-	UserRepo     port.UserRepository
-	QuestionRepo port.QuestionRepo
-	ResponseRepo port.ResponseRepo
+	UserRepo         port.UserRepository
+	QuestionRepo     port.QuestionRepo
+	ResponseRepo     port.ResponseRepo
+	CommentRepo      port.CommentRepo
+	NotificationRepo port.NotificationRepo
+	DataExportRepo   port.DataExportRepo
+
+	// background workers
+	OutboxWorker          port.OutboxWorker
+	PushReceiptPoller     port.PushReceiptPoller
+	QuestionExpiryWatcher port.QuestionExpiryWatcher
+	PollCloser            port.PollCloser
+	QuestionLifecycle     port.QuestionLifecycleScheduler
+	RetentionJob          port.RetentionJob
+	DeletedContentPurger  port.DeletedContentPurger
+	DataExporter          port.DataExporter
+
+	// realtime
+	EventBroker port.EventBroker
 
 	// clients
 	MediaClient port.MediaClient
@@ -96,6 +114,78 @@ func (app *App) Run(ctx context.Context) error {
 		return nil
 	})
 
+	// start notification outbox worker
+	grouper.Go(func() error {
+		if err := app.OutboxWorker.Run(gCtx); err != nil {
+			return fmt.Errorf("error has occurred while running notification outbox worker: %w", err)
+		}
+		return nil
+	})
+
+	// start push receipt poller
+	grouper.Go(func() error {
+		if err := app.PushReceiptPoller.Run(gCtx); err != nil {
+			return fmt.Errorf("error has occurred while running push receipt poller: %w", err)
+		}
+		return nil
+	})
+
+	// start realtime event listener
+	grouper.Go(func() error {
+		if err := app.EventBroker.Run(gCtx); err != nil {
+			return fmt.Errorf("error has occurred while running realtime event listener: %w", err)
+		}
+		return nil
+	})
+
+	// start question expiry watcher
+	grouper.Go(func() error {
+		if err := app.QuestionExpiryWatcher.Run(gCtx); err != nil {
+			return fmt.Errorf("error has occurred while running question expiry watcher: %w", err)
+		}
+		return nil
+	})
+
+	// start poll closer
+	grouper.Go(func() error {
+		if err := app.PollCloser.Run(gCtx); err != nil {
+			return fmt.Errorf("error has occurred while running poll closer: %w", err)
+		}
+		return nil
+	})
+
+	// start question lifecycle scheduler
+	grouper.Go(func() error {
+		if err := app.QuestionLifecycle.Run(gCtx); err != nil {
+			return fmt.Errorf("error has occurred while running question lifecycle scheduler: %w", err)
+		}
+		return nil
+	})
+
+	// start retention job
+	grouper.Go(func() error {
+		if err := app.RetentionJob.Run(gCtx); err != nil {
+			return fmt.Errorf("error has occurred while running retention job: %w", err)
+		}
+		return nil
+	})
+
+	// start deleted content purger
+	grouper.Go(func() error {
+		if err := app.DeletedContentPurger.Run(gCtx); err != nil {
+			return fmt.Errorf("error has occurred while running deleted content purger: %w", err)
+		}
+		return nil
+	})
+
+	// start data exporter
+	grouper.Go(func() error {
+		if err := app.DataExporter.Run(gCtx); err != nil {
+			return fmt.Errorf("error has occurred while running data exporter: %w", err)
+		}
+		return nil
+	})
+
 	if err := grouper.Wait(); err != nil {
 		return err
 	}
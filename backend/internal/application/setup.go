@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,6 +15,7 @@ import (
 	"github.com/ksha23/CS407-FactSnap/internal/adapter/s3"
 	"github.com/ksha23/CS407-FactSnap/internal/clerk"
 	"github.com/ksha23/CS407-FactSnap/internal/config"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 	"github.com/ksha23/CS407-FactSnap/internal/core/service"
 	"github.com/ksha23/CS407-FactSnap/internal/logger"
 	"github.com/lmittmann/tint"
@@ -73,14 +75,56 @@ func (app *App) initDependencies() error {
 	app.UserRepo = postgres.NewUserRepo(app.PostgresDB)
 	app.QuestionRepo = postgres.NewQuestionRepo(app.PostgresDB)
 	app.ResponseRepo = postgres.NewResponseRepo(app.PostgresDB)
+	app.CommentRepo = postgres.NewCommentRepo(app.PostgresDB)
+	app.NotificationRepo = postgres.NewNotificationRepo(app.PostgresDB)
+	app.DataExportRepo = postgres.NewDataExportRepo(app.PostgresDB)
+
+	// register realtime event broker
+	app.EventBroker = postgres.NewEventBus(app.PostgresDB)
 
 	// register services
 	app.AuthService = service.NewAuthService(app.ClerkClient, app.UserRepo)
-	app.UserService = service.NewUserService(app.UserRepo)
 	app.MediaService = service.NewMediaService(app.MediaClient)
-	app.NotificationService = service.NewExpoNotificationService()
-	app.QuestionService = service.NewQuestionService(app.QuestionRepo, app.MediaService, app.NotificationService, app.UserRepo)
-	app.ResponseService = service.NewResponseService(app.QuestionService, app.MediaService, app.ResponseRepo, app.AIClient)
+	app.UserService = service.NewUserService(app.UserRepo, app.DataExportRepo, app.MediaService, app.ClerkClient)
+	expoNotificationService, err := service.NewExpoNotificationService(app.Config.Expo, app.NotificationRepo, app.UserRepo)
+	if err != nil {
+		return fmt.Errorf("error initializing Expo notification service: %w", err)
+	}
+	app.NotificationService = expoNotificationService
+	app.InboxService = service.NewInboxService(app.NotificationRepo, app.UserRepo, app.NotificationService)
+	questionService, err := service.NewQuestionService(app.QuestionRepo, app.MediaService, app.EventBroker, app.Config.QuestionPolicy, app.Config.SoftDelete)
+	if err != nil {
+		return fmt.Errorf("error initializing question service: %w", err)
+	}
+	app.QuestionService = questionService
+	responseService, err := service.NewResponseService(app.QuestionService, app.MediaService, app.ResponseRepo, app.AIClient, app.EventBroker, app.Config.SoftDelete)
+	if err != nil {
+		return fmt.Errorf("error initializing response service: %w", err)
+	}
+	app.ResponseService = responseService
+	app.CommentService = service.NewCommentService(app.CommentRepo, app.ResponseService, app.QuestionService)
+
+	// register background workers
+	outboxWorker, err := service.NewOutboxWorker(app.Config.Notification, app.NotificationRepo, app.UserRepo, app.QuestionRepo, app.InboxService)
+	if err != nil {
+		return fmt.Errorf("error initializing notification outbox worker: %w", err)
+	}
+	app.OutboxWorker = outboxWorker
+	app.PushReceiptPoller = expoNotificationService
+	app.QuestionExpiryWatcher = service.NewQuestionExpiryWatcher(app.QuestionRepo, app.EventBroker)
+	app.PollCloser = service.NewPollCloser(app.QuestionRepo, app.EventBroker)
+	app.QuestionLifecycle = service.NewQuestionLifecycleScheduler(app.QuestionRepo, app.ResponseRepo, app.AIClient, app.EventBroker)
+	retentionJob, err := service.NewRetentionJob(app.Config.Retention, app.QuestionRepo, app.MediaService)
+	if err != nil {
+		return fmt.Errorf("error initializing retention job: %w", err)
+	}
+	app.RetentionJob = retentionJob
+	deletedContentPurger, err := service.NewDeletedContentPurger(app.Config.SoftDelete, app.QuestionRepo, app.ResponseRepo, app.MediaService)
+	if err != nil {
+		return fmt.Errorf("error initializing deleted content purger: %w", err)
+	}
+	app.DeletedContentPurger = deletedContentPurger
+	app.DataExporter = service.NewDataExporter(app.DataExportRepo, app.UserRepo, app.QuestionRepo, app.ResponseRepo, app.MediaService, app.InboxService)
 
 	return nil
 }
@@ -100,6 +144,10 @@ func (app *App) initGinServer() error {
 
 	baseUrl := fmt.Sprintf("/%s", app.Config.Server.BaseURL)
 	port := app.Config.Server.Port
+	// full path of the WebSocket gateway, it is long-lived and accepts the token as a query param
+	webSocketRoute := path.Join(baseUrl, ginhttp.WebSocketRoute)
+	// full path of the poll tally stream, it is long-lived
+	pollTallyStreamRoute := path.Join(baseUrl, ginhttp.PollTallyStreamRoute)
 
 	// register logger context keys
 	logger.AddContextKey(
@@ -111,9 +159,14 @@ func (app *App) initGinServer() error {
 	mainHandler := ginhttp.NewMainHandler()
 	authHandler := ginhttp.NewAuthHandler(app.AuthService)
 	userHandler := ginhttp.NewUserHandler(app.UserService)
-	questionHandler := ginhttp.NewQuestionHandler(app.QuestionService)
+	questionHandler := ginhttp.NewQuestionHandler(app.QuestionService, app.EventBroker)
 	responseHandler := ginhttp.NewResponseHandler(app.ResponseService)
+	commentHandler := ginhttp.NewCommentHandler(app.CommentService)
 	mediaHandler := ginhttp.NewMediaHandler(app.MediaService)
+	notificationHandler := ginhttp.NewNotificationHandler(app.InboxService)
+	realtimeHandler := ginhttp.NewRealtimeHandler(app.QuestionService, app.EventBroker)
+	metaHandler := ginhttp.NewMetaHandler(app.QuestionService)
+	adminHandler := ginhttp.NewAdminHandler(app.RetentionJob)
 
 	// register router
 	router := gin.New()
@@ -123,8 +176,8 @@ func (app *App) initGinServer() error {
 	router.Use(middleware.Logger())
 	router.Use(middleware.Recovery())
 	router.Use(middleware.Error())
-	router.Use(middleware.Timeout(requestTimeoutDuration))
-	router.Use(middleware.ClerkAuth(app.AuthService)) // all routes will be protected
+	router.Use(middleware.Timeout(requestTimeoutDuration, webSocketRoute, pollTallyStreamRoute))
+	router.Use(middleware.ClerkAuth(app.AuthService, webSocketRoute)) // all routes will be protected
 
 	// setup no router handler
 	router.NoRoute(mainHandler.NoRoute)
@@ -141,7 +194,12 @@ func (app *App) initGinServer() error {
 	userHandler.RegisterRoutes(baseRouter)
 	questionHandler.RegisterRoutes(baseRouter)
 	responseHandler.RegisterRoutes(baseRouter)
+	commentHandler.RegisterRoutes(baseRouter)
 	mediaHandler.RegisterRoutes(baseRouter)
+	notificationHandler.RegisterRoutes(baseRouter)
+	realtimeHandler.RegisterRoutes(baseRouter)
+	metaHandler.RegisterRoutes(baseRouter)
+	adminHandler.RegisterRoutes(baseRouter.Group("", middleware.RequireRole(app.AuthService, model.RoleAdmin)))
 
 	// init gin server
 	server, err := ginhttp.NewServer(baseUrl, port, router)
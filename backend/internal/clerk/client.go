@@ -2,12 +2,14 @@ package clerk
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/clerk/clerk-sdk-go/v2"
 	"github.com/clerk/clerk-sdk-go/v2/jwks"
 	"github.com/clerk/clerk-sdk-go/v2/jwt"
 	"github.com/clerk/clerk-sdk-go/v2/user"
 	"github.com/jftuga/TtlMap"
+	"net/http"
 	"time"
 )
 
@@ -18,6 +20,8 @@ const (
 type Client interface {
 	VerifyToken(ctx context.Context, token string) (string, error)
 	GetUser(ctx context.Context, clerkUserID string) (*clerk.User, error)
+	// DeleteUser deletes the Clerk user, a user that doesn't exist (anymore) is not an error
+	DeleteUser(ctx context.Context, clerkUserID string) error
 }
 
 type client struct {
@@ -72,6 +76,19 @@ func (c *client) GetUser(ctx context.Context, clerkUserID string) (*clerk.User,
 	return clerkUser, nil
 }
 
+func (c *client) DeleteUser(ctx context.Context, clerkUserID string) error {
+	_, err := user.Delete(ctx, clerkUserID)
+	if err != nil {
+		var apiErr *clerk.APIErrorResponse
+		if errors.As(err, &apiErr) && apiErr.HTTPStatusCode == http.StatusNotFound {
+			return nil
+		}
+		return fmt.Errorf("ClerkClient::DeleteUser: %w", err)
+	}
+
+	return nil
+}
+
 func (c *client) getJWK(ctx context.Context, keyID string) (*clerk.JSONWebKey, error) {
 	// check if in cache
 	jwk, ok := c.cache.Get(jwkCacheKey).(*clerk.JSONWebKey)
@@ -7,13 +7,18 @@ import (
 )
 
 type Config struct {
-	Name     string   `mapstructure:"name"`
-	Env      Env      `mapstructure:"environment"`
-	Server   Server   `mapstructure:"server"`
-	Postgres Postgres `mapstructure:"postgres"`
-	Clerk    Clerk    `mapstructure:"clerk"`
-	S3       S3       `mapstructure:"s3"`
-	OpenAI   OpenAI   `mapstructure:"openai"`
+	Name           string         `mapstructure:"name"`
+	Env            Env            `mapstructure:"environment"`
+	Server         Server         `mapstructure:"server"`
+	Postgres       Postgres       `mapstructure:"postgres"`
+	Clerk          Clerk          `mapstructure:"clerk"`
+	S3             S3             `mapstructure:"s3"`
+	OpenAI         OpenAI         `mapstructure:"openai"`
+	Notification   Notification   `mapstructure:"notification"`
+	Expo           Expo           `mapstructure:"expo"`
+	QuestionPolicy QuestionPolicy `mapstructure:"questionPolicy"`
+	Retention      Retention      `mapstructure:"retention"`
+	SoftDelete     SoftDelete     `mapstructure:"softDelete"`
 }
 
 type Server struct {
@@ -50,6 +55,45 @@ type OpenAI struct {
 	APIKey string `mapstructure:"apiKey"`
 }
 
+type Notification struct {
+	OutboxPollInterval string `mapstructure:"outboxPollInterval"`
+	OutboxBatchSize    int    `mapstructure:"outboxBatchSize"`
+	OutboxMaxAttempts  int    `mapstructure:"outboxMaxAttempts"`
+}
+
+type Expo struct {
+	BaseURL             string `mapstructure:"baseUrl"`
+	AccessToken         string `mapstructure:"accessToken"`
+	ReceiptPollInterval string `mapstructure:"receiptPollInterval"`
+}
+
+type QuestionPolicy struct {
+	MaxExtension  string                      `mapstructure:"maxExtension"`
+	MaxExtensions int                         `mapstructure:"maxExtensions"`
+	MaxLifetime   string                      `mapstructure:"maxLifetime"`
+	Durations     map[string]QuestionDuration `mapstructure:"durations"` // keyed by category
+}
+
+type QuestionDuration struct {
+	Min     string `mapstructure:"min"`
+	Max     string `mapstructure:"max"`
+	Default string `mapstructure:"default"`
+}
+
+type Retention struct {
+	Interval     string `mapstructure:"interval"`
+	BatchSize    int    `mapstructure:"batchSize"`
+	ArchiveAfter string `mapstructure:"archiveAfter"`
+	DeleteAfter  string `mapstructure:"deleteAfter"`
+	DryRun       bool   `mapstructure:"dryRun"`
+}
+
+type SoftDelete struct {
+	GracePeriod    string `mapstructure:"gracePeriod"`
+	PurgeInterval  string `mapstructure:"purgeInterval"`
+	PurgeBatchSize int    `mapstructure:"purgeBatchSize"`
+}
+
 func Load(path string) (*Config, error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigName(".env")
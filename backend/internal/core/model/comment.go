@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateCommentParams struct {
+	ResponseID uuid.UUID
+	Body       string
+}
+
+type EditCommentParams struct {
+	CommentID uuid.UUID
+	Body      string
+}
+
+// Comment is a reply to a response
+type Comment struct {
+	ID         uuid.UUID `json:"id"`
+	ResponseID uuid.UUID `json:"response_id"`
+	Author     User      `json:"author"`
+	Body       string    `json:"body"`
+	IsOwned    bool      `json:"is_owned"`
+	CreatedAt  time.Time `json:"created_at"`
+	EditedAt   time.Time `json:"edited_at"`
+}
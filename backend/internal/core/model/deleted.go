@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeletedContent is a soft-deleted question or response, it can be restored until its grace period has passed
+type DeletedContent struct {
+	ID       uuid.UUID
+	AuthorID string
+	// QuestionID is the question of a response, or the question itself
+	QuestionID uuid.UUID
+	// ImageURLs are the images of the content and, for questions, of their responses
+	ImageURLs []string
+	DeletedAt time.Time
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventTypeResponseCreated  EventType = "ResponseCreated"
+	EventTypeResponseEdited   EventType = "ResponseEdited"
+	EventTypeResponseDeleted  EventType = "ResponseDeleted"
+	EventTypePollTallyUpdated EventType = "PollTallyUpdated"
+	EventTypeQuestionExpired  EventType = "QuestionExpired"
+	EventTypePollClosed       EventType = "PollClosed"
+)
+
+// Event is a realtime update about a question that is broadcast to the clients subscribed to it,
+// either directly (by question id) or through an area that contains the question's location.
+type Event struct {
+	Type       EventType `json:"type"`
+	QuestionID uuid.UUID `json:"question_id"`
+	Location   *GeoPoint `json:"location"`
+	Data       any       `json:"data"`
+	// Truncated is set if Data was dropped because the event was too large to broadcast.
+	// Clients should re-fetch the question instead.
+	Truncated bool      `json:"truncated,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewQuestionEvent(eventType EventType, question Question, data any) Event {
+	return Event{
+		Type:       eventType,
+		QuestionID: question.ID,
+		Location: &GeoPoint{
+			Latitude:  question.Location.Latitude,
+			Longitude: question.Location.Longitude,
+		},
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+}
+
+// ResponseDeletedEventData is the data of EventTypeResponseDeleted
+type ResponseDeletedEventData struct {
+	ResponseID uuid.UUID `json:"response_id"`
+}
+
+// PollTallyEventData is the data of EventTypePollTallyUpdated
+type PollTallyEventData struct {
+	PollID        uuid.UUID     `json:"poll_id"`
+	Options       []PollOption  `json:"options"`
+	OtherVotes    []PollOther   `json:"other_votes"`
+	NumTotalVotes int           `json:"num_total_votes"`
+	NumVoters     int           `json:"num_voters"`
+	Runoff        *RunoffResult `json:"runoff"`
+	ResultsHidden bool          `json:"results_hidden"`
+}
+
+// PollClosedEventData is the data of EventTypePollClosed
+type PollClosedEventData struct {
+	PollID  uuid.UUID   `json:"poll_id"`
+	Results PollResults `json:"results"`
+}
+
+// QuestionExpiry is a question that has just expired
+type QuestionExpiry struct {
+	QuestionID uuid.UUID
+	Location   GeoPoint
+	ExpiredAt  time.Time
+}
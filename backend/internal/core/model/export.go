@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DataExportStatus string
+
+const (
+	DataExportStatusPending    DataExportStatus = "Pending"
+	DataExportStatusProcessing DataExportStatus = "Processing"
+	DataExportStatusReady      DataExportStatus = "Ready"
+	DataExportStatusFailed     DataExportStatus = "Failed"
+)
+
+// DataExport is a copy of a user's data, requested by the user. It is built in the background and stored in the media bucket.
+type DataExport struct {
+	ID          uuid.UUID        `json:"id"`
+	UserID      string           `json:"-"`
+	Status      DataExportStatus `json:"status"`
+	ObjectKey   *string          `json:"-"`
+	CreatedAt   time.Time        `json:"created_at"`
+	CompletedAt *time.Time       `json:"completed_at"`
+	// URL is a presigned link to download the export, it is only set once the export is ready
+	URL          *string    `json:"url"`
+	URLExpiresAt *time.Time `json:"url_expires_at"`
+}
+
+// UserDataBundle is the content of a data export
+type UserDataBundle struct {
+	ExportedAt time.Time      `json:"exported_at"`
+	User       AuthUser       `json:"user"`
+	Questions  []Question     `json:"questions"`
+	Responses  []Response     `json:"responses"`
+	Votes      []ExportedVote `json:"votes"`
+	// MediaURLs are the images attached to the user's questions and responses, and their avatar
+	MediaURLs []string `json:"media_urls"`
+}
+
+// ExportedVote is an answer of the user to a poll, rating or confirm question
+type ExportedVote struct {
+	QuestionID    uuid.UUID   `json:"question_id"`
+	QuestionTitle string      `json:"question_title"`
+	Type          ContentType `json:"type"`
+	// Answer is the label of the poll option (or the write-in answer), the rating value, or yes/no
+	Answer    string    `json:"answer"`
+	Rank      *int      `json:"rank,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf16"
+)
+
+// mentionPattern matches "@username", the mention must not be preceded by a word character (e.g. an email address)
+var mentionPattern = regexp.MustCompile(`(^|[^\w@])@([\w.+-]{1,30})`)
+
+// Mention is a reference to a user in the body of a question or response.
+// Start and End are the range of "@username" in the body, in UTF-16 code units (as used by JavaScript strings).
+// End is exclusive.
+type Mention struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+}
+
+// ParseMentions returns the mentions in the text. They are not resolved yet, so their UserID is empty.
+func ParseMentions(text string) []Mention {
+	var mentions []Mention
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		// match[4]:match[5] is the username, the "@" is right before it
+		start, end := match[4]-1, match[5]
+		// a username may contain dots, but a trailing one ends the sentence
+		username := strings.TrimRight(text[match[4]:match[5]], ".")
+		if username == "" {
+			continue
+		}
+		end = match[4] + len(username)
+
+		mentions = append(mentions, Mention{
+			Username: username,
+			Start:    utf16Len(text[:start]),
+			End:      utf16Len(text[:end]),
+		})
+	}
+	return mentions
+}
+
+// MentionedUserIDs returns the distinct users that are mentioned
+func MentionedUserIDs(mentions []Mention) []string {
+	seen := make(map[string]struct{}, len(mentions))
+	var userIDs []string
+	for _, mention := range mentions {
+		if _, ok := seen[mention.UserID]; ok {
+			continue
+		}
+		seen[mention.UserID] = struct{}{}
+		userIDs = append(userIDs, mention.UserID)
+	}
+	return userIDs
+}
+
+func utf16Len(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	NotificationTypeNewQuestion NotificationType = "NewQuestion"
+	NotificationTypePollClosed  NotificationType = "PollClosed"
+	// NotificationTypeQuestionReopened is sent to users near a question that was reopened by its author
+	NotificationTypeQuestionReopened NotificationType = "QuestionReopened"
+	// NotificationTypeQuestionExpired is sent to the author and responders of a question that expired
+	NotificationTypeQuestionExpired NotificationType = "QuestionExpired"
+	// NotificationTypeFollowedUserQuestion is sent to the followers of a user that posted a new question
+	NotificationTypeFollowedUserQuestion NotificationType = "FollowedUserQuestion"
+	// NotificationTypeMention is sent to users that were mentioned in a question or response
+	NotificationTypeMention NotificationType = "Mention"
+	// NotificationTypeBookmarkedQuestionResponse is sent to users that bookmarked a question (with notify) that got a new response
+	NotificationTypeBookmarkedQuestionResponse NotificationType = "BookmarkedQuestionResponse"
+	// NotificationTypeDataExportReady is sent to a user once the export of their data can be downloaded
+	NotificationTypeDataExportReady NotificationType = "DataExportReady"
+)
+
+// Notification is an entry of a user's in-app notification inbox
+type Notification struct {
+	ID        uuid.UUID        `json:"id"`
+	Type      NotificationType `json:"type"`
+	Title     string           `json:"title"`
+	Body      string           `json:"body"`
+	Data      json.RawMessage  `json:"data"`
+	IsRead    bool             `json:"is_read"`
+	ReadAt    *time.Time       `json:"read_at"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+type OutboxMessageType string
+
+const (
+	OutboxMessageTypeNewQuestion OutboxMessageType = "NewQuestion"
+	OutboxMessageTypePollClosed  OutboxMessageType = "PollClosed"
+	// OutboxMessageTypeQuestionReopened has a NewQuestionPayload
+	OutboxMessageTypeQuestionReopened OutboxMessageType = "QuestionReopened"
+	OutboxMessageTypeQuestionExpired  OutboxMessageType = "QuestionExpired"
+	OutboxMessageTypeMention          OutboxMessageType = "Mention"
+	OutboxMessageTypeNewResponse      OutboxMessageType = "NewResponse"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending    OutboxStatus = "Pending"
+	OutboxStatusProcessing OutboxStatus = "Processing"
+	OutboxStatusDelivered  OutboxStatus = "Delivered"
+	OutboxStatusFailed     OutboxStatus = "Failed"
+)
+
+// OutboxMessage is a notification that was recorded in the same transaction as the domain change that caused it.
+// It is delivered (and retried) by the outbox worker.
+type OutboxMessage struct {
+	ID          uuid.UUID
+	Type        OutboxMessageType
+	Payload     []byte
+	Status      OutboxStatus
+	Attempts    int
+	LastError   *string
+	AvailableAt time.Time
+	CreatedAt   time.Time
+}
+
+// NewQuestionPayload is the outbox payload of OutboxMessageTypeNewQuestion
+type NewQuestionPayload struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	AuthorID   string    `json:"author_id"`
+	Title      string    `json:"title"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+}
+
+// PollClosedPayload is the outbox payload of OutboxMessageTypePollClosed
+type PollClosedPayload struct {
+	PollID     uuid.UUID `json:"poll_id"`
+	QuestionID uuid.UUID `json:"question_id"`
+	Title      string    `json:"title"`
+}
+
+// QuestionExpiredPayload is the outbox payload of OutboxMessageTypeQuestionExpired
+type QuestionExpiredPayload struct {
+	QuestionID   uuid.UUID `json:"question_id"`
+	AuthorID     string    `json:"author_id"`
+	Title        string    `json:"title"`
+	NumResponses int       `json:"num_responses"`
+	HasSummary   bool      `json:"has_summary"`
+}
+
+// MentionPayload is the outbox payload of OutboxMessageTypeMention
+type MentionPayload struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	// ResponseID is set if the mentions are in a response
+	ResponseID *uuid.UUID `json:"response_id"`
+	AuthorID   string     `json:"author_id"`
+	UserIDs    []string   `json:"user_ids"`
+}
+
+// NewResponsePayload is the outbox payload of OutboxMessageTypeNewResponse
+type NewResponsePayload struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	ResponseID uuid.UUID `json:"response_id"`
+	AuthorID   string    `json:"author_id"`
+}
+
+// PushRecipient is a single device a push notification is sent to
+type PushRecipient struct {
+	UserID string
+	Token  string
+}
+
+// PushTicket is the receipt handle the push provider returned for a sent notification.
+// It is kept until its delivery receipt has been checked.
+type PushTicket struct {
+	TicketID  string
+	UserID    string
+	Token     string
+	CreatedAt time.Time
+}
@@ -20,12 +20,78 @@ type CreateQuestionParams struct {
 	Category  Category
 	Location  Location
 	ImageURLs []string
-	ExpiresAt time.Time
+	// Duration is how long the question stays open, nil means the category's default
+	Duration *time.Duration
+}
+
+type RegisterDeviceParams struct {
+	Token      string
+	Platform   *string
+	AppVersion *string
+}
+
+type NotifyParams struct {
+	Type  NotificationType
+	Title string
+	Body  string
+	Data  map[string]any
+	// DedupeKey makes delivering the same notification to a user more than once a no-op
+	DedupeKey *string
+	// ActorID is the user that caused the notification, users that muted or blocked them are not notified
+	ActorID *string
 }
 
 type CreatePollParams struct {
-	QuestionID   uuid.UUID
-	OptionLabels []string
+	QuestionID        uuid.UUID
+	OptionLabels      []string
+	Type              PollType
+	MaxSelections     int
+	AllowOther        bool
+	ResultsVisibility PollResultsVisibility
+	IsAnonymous       bool
+	ClosesAt          *time.Time
+}
+
+type EditPollParams struct {
+	PollID uuid.UUID
+	// OptionLabels replace the options of the poll, which is only possible before the first vote. Nil keeps the options.
+	OptionLabels  []string
+	MaxSelections *int
+	// ClosesAt changes the closing time, nil keeps the current one unless ClearClosesAt is set
+	ClosesAt *time.Time
+	// ClearClosesAt removes the closing time, so that the poll stays open until its question expires
+	ClearClosesAt bool
+}
+
+type VotePollParams struct {
+	PollID uuid.UUID
+	// OptionIDs are in order of preference for RankedChoice polls, empty together with OtherText to remove the vote
+	OptionIDs []uuid.UUID
+	OtherText *string
+}
+
+type CreateRatingParams struct {
+	QuestionID uuid.UUID
+	MinValue   int
+	MaxValue   int
+	MinLabel   *string
+	MaxLabel   *string
+}
+
+type AnswerRatingParams struct {
+	RatingID uuid.UUID
+	// Value is nil to remove the answer
+	Value *int
+}
+
+type CreateConfirmParams struct {
+	QuestionID uuid.UUID
+}
+
+type AnswerConfirmParams struct {
+	ConfirmID uuid.UUID
+	// IsYes is nil to remove the answer
+	IsYes *bool
 }
 
 type GetQuestionsInRadiusFeedParams struct {
@@ -40,6 +106,8 @@ type EditQuestionParams struct {
 	Body       *string
 	Category   Category
 	Location   Location
+	// ImageURLs replaces the images of the question, nil keeps the current ones
+	ImageURLs []string
 }
 
 //
@@ -56,9 +124,12 @@ type EditQuestionParams struct {
 //	Data       any
 //	ImageURLs  []string
 //}
-//
-//type EditUserParams struct {
-//	UserID    string
-//	Username  string
-//	AvatarURL *string
-//}
+
+// EditUserParams leaves nil fields unchanged, an empty AboutMe or AvatarURL clears it
+type EditUserParams struct {
+	UserID      string
+	DisplayName *string
+	Username    *string
+	AboutMe     *string
+	AvatarURL   *string
+}
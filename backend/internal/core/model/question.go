@@ -17,23 +17,43 @@ type Question struct {
 	Location        Location        `json:"location"`
 	ImageURLs       []string        `json:"image_urls"`
 	IsOwned         bool            `json:"is_owned"`
+	IsBookmarked    bool            `json:"is_bookmarked"`
 	ResponsesAmount int             `json:"responses_amount"`
 	CreatedAt       time.Time       `json:"created_at"`
 	EditedAt        time.Time       `json:"edited_at"`
+	EditCount       int             `json:"edit_count"`
+	Mentions        []Mention       `json:"mentions"`
 	ExpiredAt       time.Time       `json:"expired_at"`
+	NumExtensions   int             `json:"num_extensions"`
+	ReopenedAt      *time.Time      `json:"reopened_at"`
+	Status          QuestionStatus  `json:"status"`
+	// Summary summarizes the responses, it is generated once the question expires
+	Summary *string `json:"summary"`
 }
 
+type QuestionStatus string
+
+const (
+	QuestionStatusActive   QuestionStatus = "Active"
+	QuestionStatusExpired  QuestionStatus = "Expired"
+	QuestionStatusArchived QuestionStatus = "Archived"
+)
+
 type ContentType string
 
 const (
 	ContentTypeUnknown ContentType = "Unknown"
 	ContentTypeNone    ContentType = "None"
 	ContentTypePoll    ContentType = "Poll"
+	ContentTypeRating  ContentType = "Rating"
+	ContentTypeConfirm ContentType = "Confirm"
 )
 
 var contentTypeEnumValues = map[string]ContentType{
-	"poll": ContentTypePoll,
-	"none": ContentTypeNone,
+	"poll":    ContentTypePoll,
+	"rating":  ContentTypeRating,
+	"confirm": ContentTypeConfirm,
+	"none":    ContentTypeNone,
 }
 
 func ParseContentType(str string) (ContentType, error) {
@@ -48,18 +68,350 @@ type QuestionContent struct {
 	Data any         `json:"data"`
 }
 
+type PollType string
+
+const (
+	PollTypeUnknown      PollType = "Unknown"
+	PollTypeSingleChoice PollType = "SingleChoice"
+	PollTypeMultiSelect  PollType = "MultiSelect"
+	PollTypeRankedChoice PollType = "RankedChoice"
+)
+
+var pollTypeEnumValues = map[string]PollType{
+	"singlechoice": PollTypeSingleChoice,
+	"multiselect":  PollTypeMultiSelect,
+	"rankedchoice": PollTypeRankedChoice,
+}
+
+func ParsePollType(str string) (PollType, error) {
+	if enum, ok := pollTypeEnumValues[strings.ToLower(str)]; ok {
+		return enum, nil
+	}
+	return PollTypeUnknown, fmt.Errorf("%s is not a valid poll type", str)
+}
+
+type PollResultsVisibility string
+
+const (
+	PollResultsVisibilityUnknown     PollResultsVisibility = "Unknown"
+	PollResultsVisibilityAlways      PollResultsVisibility = "Always"
+	PollResultsVisibilityAfterVote   PollResultsVisibility = "AfterVote"
+	PollResultsVisibilityAfterExpiry PollResultsVisibility = "AfterExpiry"
+)
+
+var pollResultsVisibilityEnumValues = map[string]PollResultsVisibility{
+	"always":      PollResultsVisibilityAlways,
+	"aftervote":   PollResultsVisibilityAfterVote,
+	"afterexpiry": PollResultsVisibilityAfterExpiry,
+}
+
+func ParsePollResultsVisibility(str string) (PollResultsVisibility, error) {
+	if enum, ok := pollResultsVisibilityEnumValues[strings.ToLower(str)]; ok {
+		return enum, nil
+	}
+	return PollResultsVisibilityUnknown, fmt.Errorf("%s is not a valid poll results visibility", str)
+}
+
 type Poll struct {
-	ID            uuid.UUID    `json:"id"`
-	QuestionID    uuid.UUID    `json:"question_id"`
+	ID         uuid.UUID `json:"id"`
+	QuestionID uuid.UUID `json:"question_id"`
+	Type       PollType  `json:"type"`
+	// MaxSelections is the max number of options a user may pick (MultiSelect) or rank (RankedChoice)
+	MaxSelections     int                   `json:"max_selections"`
+	AllowOther        bool                  `json:"allow_other"`
+	ResultsVisibility PollResultsVisibility `json:"results_visibility"`
+	// IsAnonymous hides who voted for which option
+	IsAnonymous bool `json:"is_anonymous"`
+	// ResultsHidden is set if the user may not see the vote counts yet, see Poll.CanSeeResults
+	ResultsHidden bool         `json:"results_hidden"`
 	Options       []PollOption `json:"options"`
-	NumTotalVotes int          `json:"num_total_votes"`
-	CreatedAt     time.Time    `json:"created_at"`
-	ExpiredAt     time.Time    `json:"expired_at"`
+	OtherVotes    []PollOther  `json:"other_votes"`
+	// NumTotalVotes counts every picked option (only first preferences of RankedChoice polls) and "other" answer
+	NumTotalVotes int           `json:"num_total_votes"`
+	NumVoters     int           `json:"num_voters"`
+	Runoff        *RunoffResult `json:"runoff"`
+	// ClosesAt is set if the poll closes before its question expires
+	ClosesAt *time.Time `json:"closes_at"`
+	ClosedAt *time.Time `json:"closed_at"`
+	// FinalResults is the snapshot of the results that was taken once the poll closed
+	FinalResults *PollResults `json:"final_results"`
+	CreatedAt    time.Time    `json:"created_at"`
+	// ExpiredAt is when voting ends, which is the earliest of ClosedAt, ClosesAt and the question's expiry
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// PollResults are the vote counts of a poll without the votes of any particular user
+type PollResults struct {
+	Options       []PollOption  `json:"options"`
+	OtherVotes    []PollOther   `json:"other_votes"`
+	NumTotalVotes int           `json:"num_total_votes"`
+	NumVoters     int           `json:"num_voters"`
+	Runoff        *RunoffResult `json:"runoff"`
+}
+
+// Results returns the vote counts of the poll, stripped of the selection of the user it was fetched for
+func (p Poll) Results() PollResults {
+	options := make([]PollOption, len(p.Options))
+	for i, option := range p.Options {
+		option.IsSelected = false
+		option.Rank = nil
+		options[i] = option
+	}
+	otherVotes := make([]PollOther, len(p.OtherVotes))
+	for i, other := range p.OtherVotes {
+		other.IsOwn = false
+		otherVotes[i] = other
+	}
+
+	return PollResults{
+		Options:       options,
+		OtherVotes:    otherVotes,
+		NumTotalVotes: p.NumTotalVotes,
+		NumVoters:     p.NumVoters,
+		Runoff:        p.Runoff,
+	}
+}
+
+// IsClosed reports whether voting on the poll has ended
+func (p Poll) IsClosed() bool {
+	return !time.Now().Before(p.ExpiredAt)
+}
+
+// MaxPollSelections returns the upper bound of Poll.MaxSelections for a poll of the given type
+func MaxPollSelections(pollType PollType, numOptions int, allowOther bool) int {
+	switch pollType {
+	case PollTypeMultiSelect:
+		if allowOther {
+			return numOptions + 1
+		}
+		return numOptions
+	case PollTypeRankedChoice:
+		return numOptions
+	default:
+		return 1
+	}
+}
+
+// HasVoted reports whether the user the poll was fetched for has voted
+func (p Poll) HasVoted() bool {
+	for _, option := range p.Options {
+		if option.IsSelected {
+			return true
+		}
+	}
+	for _, other := range p.OtherVotes {
+		if other.IsOwn {
+			return true
+		}
+	}
+	return false
+}
+
+// CanSeeResults reports whether the user the poll was fetched for may see its vote counts.
+// The owner of the question can always see them.
+func (p Poll) CanSeeResults(isOwner bool) bool {
+	if isOwner {
+		return true
+	}
+
+	isExpired := p.IsClosed()
+	switch p.ResultsVisibility {
+	case PollResultsVisibilityAfterVote:
+		return isExpired || p.HasVoted()
+	case PollResultsVisibilityAfterExpiry:
+		return isExpired
+	default:
+		return true
+	}
+}
+
+// HideResults strips all vote counts from the poll, only keeping the user's own votes
+func (p *Poll) HideResults() {
+	p.ResultsHidden = true
+	for i := range p.Options {
+		p.Options[i].NumVotes = 0
+	}
+
+	ownOtherVotes := []PollOther{}
+	for _, other := range p.OtherVotes {
+		if other.IsOwn {
+			ownOtherVotes = append(ownOtherVotes, other)
+		}
+	}
+	p.OtherVotes = ownOtherVotes
+
+	p.NumTotalVotes = 0
+	p.NumVoters = 0
+	p.Runoff = nil
 }
 
 type PollOption struct {
 	ID         uuid.UUID `json:"id"`
 	IsSelected bool      `json:"is_selected"`
-	Label      string    `json:"label"`
-	NumVotes   int       `json:"num_votes"`
+	// Rank is the 1-based preference the user gave this option in a RankedChoice poll
+	Rank     *int   `json:"rank"`
+	Label    string `json:"label"`
+	NumVotes int    `json:"num_votes"`
+}
+
+// PollVoter is a user who voted for an option of a public poll
+type PollVoter struct {
+	User User `json:"user"`
+	Rank *int `json:"rank"`
+}
+
+// PollOther is a free-text "other" answer to a poll
+type PollOther struct {
+	Text  string `json:"text"`
+	IsOwn bool   `json:"is_own"`
+}
+
+type RunoffResult struct {
+	Rounds []RunoffRound `json:"rounds"`
+	// WinnerOptionID is nil if no votes were cast or the last remaining options are tied
+	WinnerOptionID *uuid.UUID `json:"winner_option_id"`
+}
+
+type RunoffRound struct {
+	Tallies             map[uuid.UUID]int `json:"tallies"`
+	EliminatedOptionIDs []uuid.UUID       `json:"eliminated_option_ids"`
+}
+
+// InstantRunoff counts the ballots of a RankedChoice poll. Every round, each ballot counts toward its
+// highest ranked option that is still in the running, and the options with the fewest votes are
+// eliminated until one option holds a majority of the counted ballots.
+func InstantRunoff(optionIDs []uuid.UUID, ballots [][]uuid.UUID) RunoffResult {
+	active := make(map[uuid.UUID]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		active[id] = true
+	}
+
+	var result RunoffResult
+	for len(active) > 0 {
+		round := RunoffRound{Tallies: make(map[uuid.UUID]int, len(active))}
+		for id := range active {
+			round.Tallies[id] = 0
+		}
+
+		counted := 0
+		for _, ballot := range ballots {
+			for _, id := range ballot {
+				if active[id] {
+					round.Tallies[id]++
+					counted++
+					break
+				}
+			}
+		}
+		if counted == 0 {
+			result.Rounds = append(result.Rounds, round)
+			return result
+		}
+
+		fewest := counted
+		for id, votes := range round.Tallies {
+			if votes*2 > counted {
+				result.Rounds = append(result.Rounds, round)
+				winner := id
+				result.WinnerOptionID = &winner
+				return result
+			}
+			fewest = min(fewest, votes)
+		}
+
+		// keep iterating in the order of the options, so that rounds are deterministic
+		for _, id := range optionIDs {
+			if active[id] && round.Tallies[id] == fewest {
+				round.EliminatedOptionIDs = append(round.EliminatedOptionIDs, id)
+			}
+		}
+		result.Rounds = append(result.Rounds, round)
+
+		// every remaining option is tied
+		if len(round.EliminatedOptionIDs) == len(active) {
+			return result
+		}
+		for _, id := range round.EliminatedOptionIDs {
+			delete(active, id)
+		}
+	}
+
+	return result
+}
+
+// Rating asks users to rate something on a scale, e.g. "How busy is it, 1-5?"
+type Rating struct {
+	ID         uuid.UUID `json:"id"`
+	QuestionID uuid.UUID `json:"question_id"`
+	MinValue   int       `json:"min_value"`
+	MaxValue   int       `json:"max_value"`
+	MinLabel   *string   `json:"min_label"`
+	MaxLabel   *string   `json:"max_label"`
+	// Average is nil if nobody has answered yet
+	Average    *float64 `json:"average"`
+	NumAnswers int      `json:"num_answers"`
+	// Histogram has a bucket for every value of the scale, in ascending order
+	Histogram []RatingBucket `json:"histogram"`
+	// UserValue is the value the user answered with
+	UserValue *int      `json:"user_value"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+type RatingBucket struct {
+	Value      int `json:"value"`
+	NumAnswers int `json:"num_answers"`
+}
+
+// TallyAnswers sets the histogram, the number of answers and the average of the rating
+// from the number of answers per value
+func (r *Rating) TallyAnswers(answers []RatingBucket) {
+	// every value of the scale gets a bucket, even without answers
+	r.Histogram = make([]RatingBucket, 0, r.MaxValue-r.MinValue+1)
+	for value := r.MinValue; value <= r.MaxValue; value++ {
+		r.Histogram = append(r.Histogram, RatingBucket{Value: value})
+	}
+
+	r.NumAnswers = 0
+	r.Average = nil
+	sum := 0
+	for _, answer := range answers {
+		if answer.Value >= r.MinValue && answer.Value <= r.MaxValue {
+			r.Histogram[answer.Value-r.MinValue].NumAnswers = answer.NumAnswers
+		}
+		r.NumAnswers += answer.NumAnswers
+		sum += answer.Value * answer.NumAnswers
+	}
+	if r.NumAnswers > 0 {
+		average := float64(sum) / float64(r.NumAnswers)
+		r.Average = &average
+	}
+}
+
+// ConfirmHalfLife is the age at which a confirmation counts half as much as a fresh one
+const ConfirmHalfLife = time.Hour
+
+// Confirm asks users to confirm a yes/no statement, e.g. "Is the store open?".
+// Situations like these change over time, so recent answers weigh more, see ConfirmHalfLife.
+type Confirm struct {
+	ID         uuid.UUID `json:"id"`
+	QuestionID uuid.UUID `json:"question_id"`
+	NumYes     int       `json:"num_yes"`
+	NumNo      int       `json:"num_no"`
+	// YesConfidence is the time-decayed share of "yes" answers between 0 and 1, nil if nobody has answered yet
+	YesConfidence *float64 `json:"yes_confidence"`
+	// UserAnswer is the answer of the user
+	UserAnswer *bool     `json:"user_answer"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiredAt  time.Time `json:"expired_at"`
+}
+
+// ConfirmConfidence returns the share of the (time-decayed) answer weight that is "yes", nil if there is none
+func ConfirmConfidence(yesWeight, noWeight float64) *float64 {
+	totalWeight := yesWeight + noWeight
+	if totalWeight <= 0 {
+		return nil
+	}
+	confidence := yesWeight / totalWeight
+	return &confidence
 }
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// QuestionPolicy limits how long questions stay open
+type QuestionPolicy struct {
+	// Durations holds how long a question of each category may be open for when created
+	Durations []QuestionDurationLimits
+	// MaxExtension is how far a single extension (or reopening) may push a question's expiry out
+	MaxExtension  time.Duration
+	MaxExtensions int
+	// MaxLifetime is the max time between creating (or reopening) a question and its expiry
+	MaxLifetime time.Duration
+}
+
+type QuestionDurationLimits struct {
+	Category Category
+	Min      time.Duration
+	Max      time.Duration
+	Default  time.Duration
+}
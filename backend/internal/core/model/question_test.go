@@ -0,0 +1,196 @@
+package model
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var (
+	optionA = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	optionB = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	optionC = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+)
+
+func TestInstantRunoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		optionIDs  []uuid.UUID
+		ballots    [][]uuid.UUID
+		wantRounds []RunoffRound
+		wantWinner *uuid.UUID
+	}{
+		{
+			name:      "no ballots",
+			optionIDs: []uuid.UUID{optionA, optionB},
+			ballots:   nil,
+			wantRounds: []RunoffRound{
+				{Tallies: map[uuid.UUID]int{optionA: 0, optionB: 0}},
+			},
+			wantWinner: nil,
+		},
+		{
+			name:      "majority in the first round",
+			optionIDs: []uuid.UUID{optionA, optionB, optionC},
+			ballots:   [][]uuid.UUID{{optionA, optionB}, {optionA}, {optionB, optionA}},
+			wantRounds: []RunoffRound{
+				{Tallies: map[uuid.UUID]int{optionA: 2, optionB: 1, optionC: 0}},
+			},
+			wantWinner: &optionA,
+		},
+		{
+			name:      "eliminated option transfers to the next preference",
+			optionIDs: []uuid.UUID{optionA, optionB, optionC},
+			ballots: [][]uuid.UUID{
+				{optionA}, {optionA},
+				{optionB}, {optionB},
+				{optionC, optionB},
+			},
+			wantRounds: []RunoffRound{
+				{
+					Tallies:             map[uuid.UUID]int{optionA: 2, optionB: 2, optionC: 1},
+					EliminatedOptionIDs: []uuid.UUID{optionC},
+				},
+				{Tallies: map[uuid.UUID]int{optionA: 2, optionB: 3}},
+			},
+			wantWinner: &optionB,
+		},
+		{
+			name:      "exhausted ballots do not count toward the majority",
+			optionIDs: []uuid.UUID{optionA, optionB, optionC},
+			ballots:   [][]uuid.UUID{{optionA}, {optionA}, {optionB}, {optionC}},
+			wantRounds: []RunoffRound{
+				{
+					// half of the ballots is not a majority, and options tied for the fewest votes are eliminated together
+					Tallies:             map[uuid.UUID]int{optionA: 2, optionB: 1, optionC: 1},
+					EliminatedOptionIDs: []uuid.UUID{optionB, optionC},
+				},
+				{Tallies: map[uuid.UUID]int{optionA: 2}},
+			},
+			wantWinner: &optionA,
+		},
+		{
+			name:      "options without votes are eliminated first",
+			optionIDs: []uuid.UUID{optionA, optionB, optionC},
+			ballots:   [][]uuid.UUID{{optionA, optionC}, {optionB, optionC}, {optionB}, {optionA}},
+			wantRounds: []RunoffRound{
+				{
+					Tallies:             map[uuid.UUID]int{optionA: 2, optionB: 2, optionC: 0},
+					EliminatedOptionIDs: []uuid.UUID{optionC},
+				},
+				{
+					Tallies:             map[uuid.UUID]int{optionA: 2, optionB: 2},
+					EliminatedOptionIDs: []uuid.UUID{optionA, optionB},
+				},
+			},
+			wantWinner: nil,
+		},
+		{
+			name:      "options that are not part of the poll are skipped",
+			optionIDs: []uuid.UUID{optionA, optionB},
+			ballots:   [][]uuid.UUID{{optionC, optionA}, {optionB}, {optionA}},
+			wantRounds: []RunoffRound{
+				{Tallies: map[uuid.UUID]int{optionA: 2, optionB: 1}},
+			},
+			wantWinner: &optionA,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := InstantRunoff(tt.optionIDs, tt.ballots)
+
+			if !reflect.DeepEqual(result.Rounds, tt.wantRounds) {
+				t.Errorf("rounds = %+v, want %+v", result.Rounds, tt.wantRounds)
+			}
+			if !reflect.DeepEqual(result.WinnerOptionID, tt.wantWinner) {
+				t.Errorf("winner = %v, want %v", result.WinnerOptionID, tt.wantWinner)
+			}
+		})
+	}
+}
+
+func TestRatingTallyAnswers(t *testing.T) {
+	tests := []struct {
+		name           string
+		answers        []RatingBucket
+		wantHistogram  []RatingBucket
+		wantNumAnswers int
+		wantAverage    *float64
+	}{
+		{
+			name:           "no answers",
+			answers:        nil,
+			wantHistogram:  []RatingBucket{{Value: 1}, {Value: 2}, {Value: 3}},
+			wantNumAnswers: 0,
+			wantAverage:    nil,
+		},
+		{
+			name:           "values without answers keep an empty bucket",
+			answers:        []RatingBucket{{Value: 1, NumAnswers: 1}, {Value: 3, NumAnswers: 2}},
+			wantHistogram:  []RatingBucket{{Value: 1, NumAnswers: 1}, {Value: 2}, {Value: 3, NumAnswers: 2}},
+			wantNumAnswers: 3,
+			wantAverage:    ptrTo(7.0 / 3.0),
+		},
+		{
+			name:           "average is weighted by the number of answers",
+			answers:        []RatingBucket{{Value: 2, NumAnswers: 4}, {Value: 3, NumAnswers: 1}},
+			wantHistogram:  []RatingBucket{{Value: 1}, {Value: 2, NumAnswers: 4}, {Value: 3, NumAnswers: 1}},
+			wantNumAnswers: 5,
+			wantAverage:    ptrTo(2.2),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rating := Rating{MinValue: 1, MaxValue: 3}
+			rating.TallyAnswers(tt.answers)
+
+			if !reflect.DeepEqual(rating.Histogram, tt.wantHistogram) {
+				t.Errorf("histogram = %+v, want %+v", rating.Histogram, tt.wantHistogram)
+			}
+			if rating.NumAnswers != tt.wantNumAnswers {
+				t.Errorf("num answers = %d, want %d", rating.NumAnswers, tt.wantNumAnswers)
+			}
+			switch {
+			case tt.wantAverage == nil && rating.Average != nil:
+				t.Errorf("average = %v, want nil", *rating.Average)
+			case tt.wantAverage != nil && (rating.Average == nil || math.Abs(*rating.Average-*tt.wantAverage) > 1e-9):
+				t.Errorf("average = %v, want %v", rating.Average, *tt.wantAverage)
+			}
+		})
+	}
+}
+
+func TestConfirmConfidence(t *testing.T) {
+	tests := []struct {
+		name      string
+		yesWeight float64
+		noWeight  float64
+		want      *float64
+	}{
+		{name: "no answers", yesWeight: 0, noWeight: 0, want: nil},
+		{name: "only yes", yesWeight: 2, noWeight: 0, want: ptrTo(1.0)},
+		{name: "only no", yesWeight: 0, noWeight: 1.5, want: ptrTo(0.0)},
+		// a fresh "yes" outweighs two "no" answers that are two half lives old
+		{name: "decayed answers", yesWeight: 1, noWeight: 2 * 0.25, want: ptrTo(2.0 / 3.0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConfirmConfidence(tt.yesWeight, tt.noWeight)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("confidence = %v, want nil", *got)
+			case tt.want != nil && (got == nil || math.Abs(*got-*tt.want) > 1e-9):
+				t.Errorf("confidence = %v, want %v", got, *tt.want)
+			}
+		})
+	}
+}
+
+func ptrTo(v float64) *float64 {
+	return &v
+}
@@ -14,6 +14,8 @@ type CreateResponseParams struct {
 type EditResponseParams struct {
 	ResponseID uuid.UUID
 	Body       string
+	// ImageURLs replaces the images of the response, nil keeps the current ones
+	ImageURLs []string
 }
 
 // Response model
@@ -26,4 +28,7 @@ type Response struct {
 	ImageURLs  []string  `json:"image_urls"`
 	CreatedAt  time.Time `json:"created_at"`
 	EditedAt   time.Time `json:"edited_at"`
+	EditCount  int       `json:"edit_count"`
+	ReplyCount int       `json:"reply_count"`
+	Mentions   []Mention `json:"mentions"`
 }
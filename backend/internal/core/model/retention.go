@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionCandidate is a question that is due to be archived or purged by the retention job
+type RetentionCandidate struct {
+	QuestionID   uuid.UUID      `json:"question_id"`
+	Status       QuestionStatus `json:"status"`
+	ExpiredAt    time.Time      `json:"expired_at"`
+	NumResponses int            `json:"num_responses"`
+	// ImageURLs are the images of the question and, for purge candidates, of its responses
+	ImageURLs []string `json:"image_urls"`
+}
+
+// RetentionPreview is what the next run of the retention job would archive and purge
+type RetentionPreview struct {
+	DryRun bool `json:"dry_run"`
+	// questions that expired before ArchiveBefore are archived, archived questions that expired before DeleteBefore are purged
+	ArchiveBefore time.Time            `json:"archive_before"`
+	DeleteBefore  time.Time            `json:"delete_before"`
+	ToArchive     []RetentionCandidate `json:"to_archive"`
+	ToPurge       []RetentionCandidate `json:"to_purge"`
+}
+
+// RetentionStats are the metrics of the retention job since the server started
+type RetentionStats struct {
+	DryRun          bool       `json:"dry_run"`
+	NumRuns         int        `json:"num_runs"`
+	LastRunAt       *time.Time `json:"last_run_at"`
+	LastError       *string    `json:"last_error"`
+	NumArchived     int        `json:"num_archived"`
+	NumPurged       int        `json:"num_purged"`
+	NumMediaDeleted int        `json:"num_media_deleted"`
+	NumFailed       int        `json:"num_failed"`
+	// the amounts a dry run found during its last run
+	NumWouldArchive int `json:"num_would_archive"`
+	NumWouldPurge   int `json:"num_would_purge"`
+}
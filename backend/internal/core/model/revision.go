@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuestionRevision is the content of a question before one of its edits
+type QuestionRevision struct {
+	ID         uuid.UUID `json:"id"`
+	QuestionID uuid.UUID `json:"question_id"`
+	Title      string    `json:"title"`
+	Body       *string   `json:"body"`
+	Category   Category  `json:"category"`
+	// ImageURLs only has the images the question still has, removed images are deleted
+	ImageURLs []string `json:"image_urls"`
+	// EditedAt is when this content was written, CreatedAt is when it was replaced
+	EditedAt  time.Time `json:"edited_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ResponseRevision is the content of a response before one of its edits
+type ResponseRevision struct {
+	ID         uuid.UUID `json:"id"`
+	ResponseID uuid.UUID `json:"response_id"`
+	Body       string    `json:"body"`
+	// ImageURLs only has the images the response still has, removed images are deleted
+	ImageURLs []string  `json:"image_urls"`
+	EditedAt  time.Time `json:"edited_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
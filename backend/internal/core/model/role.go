@@ -3,5 +3,6 @@ package model
 type Role string
 
 const (
-	RoleUser Role = "User"
+	RoleUser  Role = "User"
+	RoleAdmin Role = "Admin"
 )
@@ -1,29 +1,44 @@
 package model
 
 import (
+	"math"
 	"time"
 )
 
 // AuthUser represents a logged-in user
 type AuthUser struct {
 	User
-	Email string `json:"email"`
+	Email             string    `json:"email"`
+	LastKnownLocation *GeoPoint `json:"last_known_location"`
+	// UsernameChangedAt is the last time the user changed their username, it is nil if they never did
+	UsernameChangedAt *time.Time `json:"username_changed_at"`
 }
 
 // User represents public-facing users
 type User struct {
-	ID                string    `json:"id"`
-	Username          string    `json:"username"`
-	AvatarURL         *string   `json:"avatar_url"`
-	AboutMe           *string   `json:"about_me"`
-	DisplayName       string    `json:"display_name"`
-	Role              Role      `json:"role"`
-	CreatedAt         time.Time `json:"created_at"`
-	ExpoPushToken     *string   `json:"expo_push_token"`
-	LastKnownLocation *GeoPoint `json:"last_known_location"`
+	ID          string    `json:"id"`
+	Username    string    `json:"username"`
+	AvatarURL   *string   `json:"avatar_url"`
+	AboutMe     *string   `json:"about_me"`
+	DisplayName string    `json:"display_name"`
+	Role        Role      `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
 }
 
 type GeoPoint struct {
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
 }
+
+const earthRadiusMeters = 6371000.0
+
+// DistanceMeters returns the great-circle distance between both points (haversine formula)
+func (p GeoPoint) DistanceMeters(other GeoPoint) float64 {
+	lat1 := p.Latitude * math.Pi / 180
+	lat2 := other.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (other.Longitude - p.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
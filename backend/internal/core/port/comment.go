@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+)
+
+type CommentService interface {
+	CreateComment(ctx context.Context, userID string, params model.CreateCommentParams) (model.Comment, error)
+	GetCommentsByResponseID(ctx context.Context, userID string, responseID uuid.UUID, page model.PageParams) ([]model.Comment, error)
+	EditComment(ctx context.Context, userID string, params model.EditCommentParams) (model.Comment, error)
+	DeleteComment(ctx context.Context, userID string, commentID uuid.UUID) error
+}
+
+type CommentRepo interface {
+	CreateComment(ctx context.Context, userID string, params model.CreateCommentParams) (model.Comment, error)
+	GetCommentsByResponseID(ctx context.Context, userID string, responseID uuid.UUID, page model.PageParams) ([]model.Comment, error)
+	GetCommentByID(ctx context.Context, userID string, commentID uuid.UUID) (model.Comment, error)
+	EditComment(ctx context.Context, userID string, params model.EditCommentParams) (model.Comment, error)
+	DeleteComment(ctx context.Context, commentID uuid.UUID, responseID uuid.UUID) error
+}
@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+)
+
+type EventPublisher interface {
+	// Publish broadcasts the event to the subscribers of every API instance
+	Publish(ctx context.Context, event model.Event) error
+	// PublishLocal broadcasts the event to the subscribers of this API instance only.
+	// It is meant for events that every instance produces on its own.
+	PublishLocal(event model.Event)
+}
+
+type EventBroker interface {
+	EventPublisher
+	// Subscribe returns a channel receiving all events, and a function that ends the subscription
+	Subscribe() (<-chan model.Event, func())
+	// Run receives the events published by other API instances until the context is canceled
+	Run(ctx context.Context) error
+}
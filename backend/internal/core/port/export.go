@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+)
+
+type DataExportRepo interface {
+	// CreateDataExport returns a forbidden error if the user already has an export in progress
+	CreateDataExport(ctx context.Context, userID string) (model.DataExport, error)
+	GetDataExportByID(ctx context.Context, userID string, exportID uuid.UUID) (model.DataExport, error)
+	// ClaimDataExport returns the oldest pending export (or one whose processing was abandoned before staleBefore),
+	// or a not found error if there is none
+	ClaimDataExport(ctx context.Context, staleBefore time.Time) (model.DataExport, error)
+	// CompleteDataExport returns false if the export no longer exists (e.g. the user deleted their account meanwhile)
+	CompleteDataExport(ctx context.Context, exportID uuid.UUID, objectKey string) (bool, error)
+	FailDataExport(ctx context.Context, exportID uuid.UUID, lastError string) error
+	// GetVotesByUserID returns the answers of the user to polls, ratings and confirms
+	GetVotesByUserID(ctx context.Context, userID string) ([]model.ExportedVote, error)
+}
+
+// DataExporter builds the requested data exports until the context is canceled
+type DataExporter interface {
+	Run(ctx context.Context) error
+}
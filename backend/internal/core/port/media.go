@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 )
@@ -10,6 +11,14 @@ type MediaService interface {
 	UploadMedia(ctx context.Context, params model.UploadMediaParams) (model.MediaAsset, error)
 	GetMediaURL(ctx context.Context, key string) (model.MediaAsset, error)
 	DeleteMedia(ctx context.Context, urls []string) error
+	// ValidateOwnership returns an error if any of the urls wasn't uploaded by the uploader
+	ValidateOwnership(ctx context.Context, uploader string, urls []string) error
+	// StoreFile stores a file generated for the uploader (e.g. a data export), it is not subject to the upload size limit
+	StoreFile(ctx context.Context, params model.UploadMediaParams) (model.MediaAsset, error)
+	// GetDownloadURL returns a temporary link to download the file stored under key
+	GetDownloadURL(ctx context.Context, key string) (url string, expiresAt time.Time, err error)
+	// DeleteUploads deletes everything uploaded by or stored for the uploader
+	DeleteUploads(ctx context.Context, uploader string) error
 }
 
 type MediaClient interface {
@@ -17,4 +26,7 @@ type MediaClient interface {
 	Get(ctx context.Context, key string) (model.MediaAsset, error)
 	Delete(ctx context.Context, key string) error
 	DeleteMany(ctx context.Context, urls []string) error
+	IsUploadedBy(ctx context.Context, url string, uploader string) (bool, error)
+	DeleteByUploader(ctx context.Context, uploader string) error
+	PresignURL(ctx context.Context, key string) (string, time.Time, error)
 }
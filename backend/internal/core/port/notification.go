@@ -2,8 +2,49 @@ package port
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 )
 
 type NotificationService interface {
-	SendPushNotification(ctx context.Context, tokens []string, title, body string, data map[string]interface{}) error
+	SendPushNotification(ctx context.Context, recipients []model.PushRecipient, title, body string, data map[string]interface{}) error
+}
+
+type NotificationRepo interface {
+	ClaimOutboxMessages(ctx context.Context, limit int, lockedUntil time.Time) ([]model.OutboxMessage, error)
+	MarkOutboxMessageDelivered(ctx context.Context, messageID uuid.UUID) error
+	RetryOutboxMessage(ctx context.Context, messageID uuid.UUID, lastError string, availableAt time.Time) error
+	FailOutboxMessage(ctx context.Context, messageID uuid.UUID, lastError string) error
+	CreatePushTickets(ctx context.Context, tickets []model.PushTicket) error
+	GetPushTicketsCreatedBefore(ctx context.Context, createdBefore time.Time, limit int) ([]model.PushTicket, error)
+	DeletePushTickets(ctx context.Context, ticketIDs []string) error
+	// CreateNotifications returns the ids of the users the notification was added for,
+	// leaving out the ones that already had a notification with the same dedupe key
+	CreateNotifications(ctx context.Context, userIDs []string, params model.NotifyParams) ([]string, error)
+	GetNotificationsByUserID(ctx context.Context, userID string, page model.PageParams) ([]model.Notification, error)
+	GetUnreadNotificationCount(ctx context.Context, userID string) (int, error)
+	MarkNotificationRead(ctx context.Context, userID string, notificationID uuid.UUID) error
+	MarkAllNotificationsRead(ctx context.Context, userID string) error
+	DeleteNotification(ctx context.Context, userID string, notificationID uuid.UUID) error
+}
+
+// InboxService persists the notifications sent to users, so they can be read in-app, and pushes them to their devices
+type InboxService interface {
+	Notify(ctx context.Context, userIDs []string, params model.NotifyParams) error
+	GetNotifications(ctx context.Context, userID string, page model.PageParams) (notifications []model.Notification, unreadCount int, err error)
+	MarkNotificationRead(ctx context.Context, userID string, notificationID uuid.UUID) error
+	MarkAllNotificationsRead(ctx context.Context, userID string) error
+	DeleteNotification(ctx context.Context, userID string, notificationID uuid.UUID) error
+}
+
+// OutboxWorker delivers the messages recorded in the notification outbox until the context is canceled
+type OutboxWorker interface {
+	Run(ctx context.Context) error
+}
+
+// PushReceiptPoller checks the delivery receipts of sent push notifications until the context is canceled
+type PushReceiptPoller interface {
+	Run(ctx context.Context) error
 }
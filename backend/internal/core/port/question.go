@@ -4,29 +4,115 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"time"
 )
 
 type QuestionService interface {
 	CreateQuestion(ctx context.Context, userID string, params model.CreateQuestionParams) (uuid.UUID, error)
+	GetQuestionPolicy(ctx context.Context) model.QuestionPolicy
 	CreatePoll(ctx context.Context, userID string, params model.CreatePollParams) (uuid.UUID, error)
-	VotePoll(ctx context.Context, userID string, pollID uuid.UUID, optionID *uuid.UUID) error
+	VotePoll(ctx context.Context, userID string, params model.VotePollParams) error
+	GetPollTally(ctx context.Context, userID string, pollID uuid.UUID) (model.Poll, error)
+	GetPollOptionVoters(ctx context.Context, userID string, pollID, optionID uuid.UUID, page model.PageParams) ([]model.PollVoter, error)
+	EditPoll(ctx context.Context, userID string, params model.EditPollParams) (model.Poll, error)
+	ClosePoll(ctx context.Context, userID string, pollID uuid.UUID) (model.Poll, error)
+	CreateRating(ctx context.Context, userID string, params model.CreateRatingParams) (uuid.UUID, error)
+	AnswerRating(ctx context.Context, userID string, params model.AnswerRatingParams) (model.Rating, error)
+	CreateConfirm(ctx context.Context, userID string, params model.CreateConfirmParams) (uuid.UUID, error)
+	AnswerConfirm(ctx context.Context, userID string, params model.AnswerConfirmParams) (model.Confirm, error)
 	DeleteQuestion(ctx context.Context, userID string, questionID uuid.UUID) error
+	// RestoreQuestion undoes the deletion of the question, as long as its grace period hasn't passed
+	RestoreQuestion(ctx context.Context, userID string, questionID uuid.UUID) (model.Question, error)
 	EditQuestion(ctx context.Context, userID string, params model.EditQuestionParams) (model.Question, error)
+	// GetQuestionRevisions returns the previous contents of the question, the latest first
+	GetQuestionRevisions(ctx context.Context, userID string, questionID uuid.UUID, page model.PageParams) ([]model.QuestionRevision, error)
+	ExtendQuestion(ctx context.Context, userID string, questionID uuid.UUID, duration time.Duration) (model.Question, error)
+	ReopenQuestion(ctx context.Context, userID string, questionID uuid.UUID, duration time.Duration) (model.Question, error)
 	GetQuestionByID(ctx context.Context, userID string, questionID uuid.UUID) (model.Question, error)
 	GetQuestionsInRadiusFeed(ctx context.Context, userID string, params model.GetQuestionsInRadiusFeedParams, page model.PageParams) ([]model.Question, error)
 	GetQuestionsByUserID(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error)
 	GetQuestionsRespondedByUserID(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error)
+	// GetQuestionsFromFollowedUsers returns the questions of the users that the user follows, the latest first
+	GetQuestionsFromFollowedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error)
+	// BookmarkQuestion saves the question for the user, if notify is set they are pushed its new responses and its expiry
+	BookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID, notify bool) error
+	UnbookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID) error
+	// GetBookmarkedQuestions returns the questions saved by the user, the latest bookmark first
+	GetBookmarkedQuestions(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error)
+	// GetQuestionsByAuthorID returns the questions of the author that the user can see, the latest first
+	GetQuestionsByAuthorID(ctx context.Context, userID string, authorID string, page model.PageParams) ([]model.Question, error)
 }
 
 type QuestionRepo interface {
-	CreateQuestion(ctx context.Context, userID string, params model.CreateQuestionParams) (uuid.UUID, error)
+	CreateQuestion(ctx context.Context, userID string, params model.CreateQuestionParams, expiresAt time.Time) (uuid.UUID, error)
 	CreatePoll(ctx context.Context, userID string, params model.CreatePollParams) (uuid.UUID, error)
 	IsPollExpired(ctx context.Context, pollID uuid.UUID) (bool, error)
-	VotePoll(ctx context.Context, userID string, pollID uuid.UUID, optionID *uuid.UUID) error
+	VotePoll(ctx context.Context, userID string, pollType model.PollType, params model.VotePollParams) error
+	GetPollOptionVoters(ctx context.Context, optionID uuid.UUID, page model.PageParams) ([]model.PollVoter, error)
+	EditPoll(ctx context.Context, params model.EditPollParams) error
+	ClosePoll(ctx context.Context, pollID uuid.UUID) error
+	GetPollIDsToFinalize(ctx context.Context, limit int) ([]uuid.UUID, error)
+	FinalizePoll(ctx context.Context, question model.Question, poll model.Poll, notifyVoters bool) error
+	GetPollVoterIDs(ctx context.Context, pollID uuid.UUID) ([]string, error)
+	CreateRating(ctx context.Context, userID string, params model.CreateRatingParams) (uuid.UUID, error)
+	AnswerRating(ctx context.Context, userID string, params model.AnswerRatingParams) error
+	GetQuestionByRatingID(ctx context.Context, userID string, ratingID uuid.UUID) (model.Question, error)
+	CreateConfirm(ctx context.Context, userID string, params model.CreateConfirmParams) (uuid.UUID, error)
+	AnswerConfirm(ctx context.Context, userID string, params model.AnswerConfirmParams) error
+	GetQuestionByConfirmID(ctx context.Context, userID string, confirmID uuid.UUID) (model.Question, error)
 	DeleteQuestion(ctx context.Context, userID string, questionID uuid.UUID) error
-	EditQuestion(ctx context.Context, userID string, params model.EditQuestionParams) (model.Question, error)
+	GetDeletedQuestionByID(ctx context.Context, questionID uuid.UUID) (model.DeletedContent, error)
+	// RestoreQuestion returns false if the question was deleted before deletedAfter
+	RestoreQuestion(ctx context.Context, questionID uuid.UUID, deletedAfter time.Time) (bool, error)
+	// GetQuestionsDeletedBefore returns the deleted questions along with the images of their responses
+	GetQuestionsDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int) ([]model.DeletedContent, error)
+	HardDeleteQuestion(ctx context.Context, questionID uuid.UUID, deletedBefore time.Time) (bool, error)
+	// EditQuestion returns the edited question along with the image urls it had before the edit
+	EditQuestion(ctx context.Context, userID string, params model.EditQuestionParams) (model.Question, []string, error)
+	GetQuestionRevisions(ctx context.Context, questionID uuid.UUID, page model.PageParams) ([]model.QuestionRevision, error)
+	// ExtendQuestion pushes the expiry of the open question back by duration. It returns an unauthorized error if the question
+	// already expired, was extended maxExtensions times, or would stay open for more than maxLifetime.
+	ExtendQuestion(ctx context.Context, questionID uuid.UUID, duration time.Duration, maxExtensions int, maxLifetime time.Duration) error
+	// ReopenQuestion opens the expired question for duration, along with its poll if it only closed because the question expired.
+	// It returns an unauthorized error if the question was already reopened or archived.
+	ReopenQuestion(ctx context.Context, question model.Question, duration time.Duration) error
+	GetQuestionIDsToExpire(ctx context.Context, limit int) ([]uuid.UUID, error)
+	// ExpireQuestion flips the status of the expired question and stores its summary, notifying its author and responders if notify is set.
+	// It does nothing if the question was already expired, or was extended or reopened meanwhile.
+	ExpireQuestion(ctx context.Context, question model.Question, summary *string, notify bool) error
+	GetQuestionResponderIDs(ctx context.Context, questionID uuid.UUID) ([]string, error)
+	GetQuestionsToArchive(ctx context.Context, expiredBefore time.Time, limit int) ([]model.RetentionCandidate, error)
+	ArchiveQuestions(ctx context.Context, questionIDs []uuid.UUID, expiredBefore time.Time) (int, error)
+	// GetQuestionsToPurge returns archived questions along with the images of their responses
+	GetQuestionsToPurge(ctx context.Context, expiredBefore time.Time, limit int) ([]model.RetentionCandidate, error)
+	// PurgeQuestion hard-deletes the archived question, it returns false if the question is no longer due
+	PurgeQuestion(ctx context.Context, questionID uuid.UUID, expiredBefore time.Time) (bool, error)
 	GetQuestionByID(ctx context.Context, userID string, questionID uuid.UUID) (model.Question, error)
+	GetQuestionByPollID(ctx context.Context, userID string, pollID uuid.UUID) (model.Question, error)
+	GetQuestionsExpiredBetween(ctx context.Context, expiredAfter, expiredUntil time.Time) ([]model.QuestionExpiry, error)
 	GetQuestionsInRadiusFeed(ctx context.Context, userID string, params model.GetQuestionsInRadiusFeedParams, page model.PageParams) ([]model.Question, error)
     GetQuestionsByUserID(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error)
     GetQuestionsRespondedByUserID(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error)
+	GetQuestionsFromFollowedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error)
+	BookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID, notify bool) error
+	UnbookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID) error
+	GetBookmarkedQuestions(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error)
+	// GetBookmarkSubscriberIDs returns the users that bookmarked the question and want to be notified about it
+	GetBookmarkSubscriberIDs(ctx context.Context, questionID uuid.UUID) ([]string, error)
+	GetQuestionsByAuthorID(ctx context.Context, userID string, authorID string, page model.PageParams) ([]model.Question, error)
+}
+
+// PollCloser closes polls once they are due and snapshots their final results until the context is canceled
+type PollCloser interface {
+	Run(ctx context.Context) error
+}
+
+// QuestionLifecycleScheduler finalizes questions once they expire (summary, poll results, notifications) until the context is canceled
+type QuestionLifecycleScheduler interface {
+	Run(ctx context.Context) error
+}
+
+// QuestionExpiryWatcher broadcasts the expiry of questions to realtime subscribers until the context is canceled
+type QuestionExpiryWatcher interface {
+	Run(ctx context.Context) error
 }
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
@@ -12,16 +13,28 @@ type ResponseService interface {
 	GetResponsesByQuestionID(ctx context.Context, userID string, questionID uuid.UUID, page model.PageParams) ([]model.Response, error)
 	EditResponse(ctx context.Context, userID string, params model.EditResponseParams) (model.Response, error)
 	DeleteResponse(ctx context.Context, userID string, responseID uuid.UUID) error
-	//GetResponsesByUserID(ctx context.Context, userID string, page model.PageParams) ([]model.Response, error)
+	// RestoreResponse undoes the deletion of the response, as long as its grace period hasn't passed
+	RestoreResponse(ctx context.Context, userID string, responseID uuid.UUID) (model.Response, error)
+	// GetResponsesByAuthorID returns the responses of the author that the user can see, the latest first
+	GetResponsesByAuthorID(ctx context.Context, userID string, authorID string, page model.PageParams) ([]model.Response, error)
 	GetResponseByID(ctx context.Context, userID string, responseID uuid.UUID) (model.Response, error)
+	// GetResponseRevisions returns the previous contents of the response, the latest first
+	GetResponseRevisions(ctx context.Context, userID string, responseID uuid.UUID, page model.PageParams) ([]model.ResponseRevision, error)
 	SummarizeResponsesByQuestionID(ctx context.Context, userID string, questionID uuid.UUID) (string, error)
 }
 
 type ResponseRepo interface {
 	CreateResponse(ctx context.Context, userID string, params model.CreateResponseParams) (model.Response, error)
 	GetResponsesByQuestionID(ctx context.Context, userID string, questionID uuid.UUID, page model.PageParams) ([]model.Response, error)
-	EditResponse(ctx context.Context, userID string, params model.EditResponseParams) (model.Response, error)
+	// EditResponse returns the edited response along with the image urls it had before the edit
+	EditResponse(ctx context.Context, userID string, params model.EditResponseParams) (model.Response, []string, error)
 	DeleteResponse(ctx context.Context, userID string, questionID uuid.UUID, responseID uuid.UUID) error
+	GetDeletedResponseByID(ctx context.Context, responseID uuid.UUID) (model.DeletedContent, error)
+	// RestoreResponse returns false if the response was deleted before deletedAfter
+	RestoreResponse(ctx context.Context, response model.DeletedContent, deletedAfter time.Time) (bool, error)
+	GetResponsesDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int) ([]model.DeletedContent, error)
+	HardDeleteResponse(ctx context.Context, responseID uuid.UUID, deletedBefore time.Time) (bool, error)
 	GetResponseByID(ctx context.Context, userID string, responseID uuid.UUID) (model.Response, error)
-	//GetResponsesByUserID(ctx context.Context, userID string, page model.PageParams) ([]model.Response, error)
+	GetResponseRevisions(ctx context.Context, responseID uuid.UUID, page model.PageParams) ([]model.ResponseRevision, error)
+	GetResponsesByAuthorID(ctx context.Context, userID string, authorID string, page model.PageParams) ([]model.Response, error)
 }
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+)
+
+// RetentionJob archives and then purges expired questions (including their media) until the context is canceled
+type RetentionJob interface {
+	Run(ctx context.Context) error
+	// Preview returns up to limit questions of each kind that the next run would archive and purge
+	Preview(ctx context.Context, limit int) (model.RetentionPreview, error)
+	Stats() model.RetentionStats
+}
+
+// DeletedContentPurger hard-deletes soft-deleted questions and responses (including their media) once their grace period
+// has passed, until the context is canceled
+type DeletedContentPurger interface {
+	Run(ctx context.Context) error
+}
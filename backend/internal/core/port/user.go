@@ -2,28 +2,71 @@ package port
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 )
 
 type UserService interface {
 	GetUserStatistics(ctx context.Context, userID string) (questionCount int, responseCount int, err error)
-	UpdateProfile(ctx context.Context, userID string, displayName string) (model.AuthUser, error)
-	//EditUser(ctx context.Context, params model.EditUserParams) (model.AuthUser, error)
+	// GetUserByID returns the public profile of the user, users that blocked the viewer are not found
+	GetUserByID(ctx context.Context, viewerID, userID string) (model.User, error)
+	GetUserByUsername(ctx context.Context, viewerID, username string) (model.User, error)
+	// EditUser updates the profile of the user, the previous avatar is deleted if it was uploaded by the user
+	EditUser(ctx context.Context, params model.EditUserParams) (model.AuthUser, error)
 	UpdateLocation(ctx context.Context, userID string, lat, long float64) error
-	UpdatePushToken(ctx context.Context, userID, token string) error
-	DeletePushToken(ctx context.Context, userID string) error
+	UpdatePushToken(ctx context.Context, userID string, params model.RegisterDeviceParams) error
+	// DeletePushToken unregisters the device with the given token, or all of the user's devices if token is empty
+	DeletePushToken(ctx context.Context, userID, token string) error
+	FollowUser(ctx context.Context, userID, followeeID string) error
+	UnfollowUser(ctx context.Context, userID, followeeID string) error
+	GetFollowCounts(ctx context.Context, userID string) (followerCount int, followingCount int, err error)
+	// BlockUser hides the content of both users from each other and keeps the blocked user from responding to or mentioning the user
+	BlockUser(ctx context.Context, userID, blockedID string) error
+	UnblockUser(ctx context.Context, userID, blockedID string) error
+	GetBlockedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.User, error)
+	// MuteUser only suppresses the notifications caused by the muted user
+	MuteUser(ctx context.Context, userID, mutedID string) error
+	UnmuteUser(ctx context.Context, userID, mutedID string) error
+	GetMutedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.User, error)
+	// DeleteAccount deletes the user along with all of their content and uploads, and their Clerk user
+	DeleteAccount(ctx context.Context, userID string) error
+	// RequestDataExport queues an export of the user's data, it is built in the background
+	RequestDataExport(ctx context.Context, userID string) (model.DataExport, error)
+	// GetDataExport returns the export, with a download link once it is ready
+	GetDataExport(ctx context.Context, userID string, exportID uuid.UUID) (model.DataExport, error)
 }
 
 type UserRepository interface {
 	CreateUser(ctx context.Context, params model.CreateUserParams) (model.AuthUser, error)
 	GetAuthUserByID(ctx context.Context, clerkID string) (model.AuthUser, error)
+	GetPublicUserByID(ctx context.Context, viewerID, userID string) (model.User, error)
+	GetPublicUserByUsername(ctx context.Context, viewerID, username string) (model.User, error)
 	GetUserQuestionCount(ctx context.Context, userID string) (int, error)
 	GetUserResponseCount(ctx context.Context, userID string) (int, error)
-    UpdateDisplayName(ctx context.Context, userID string, displayName string) (model.AuthUser, error)
-	//EditUser(ctx context.Context, params model.EditUserParams) (model.AuthUser, error)
+	EditUser(ctx context.Context, params model.EditUserParams) (model.AuthUser, error)
+	// IsUsernameTaken reports whether another user has the username, ignoring case
+	IsUsernameTaken(ctx context.Context, userID string, username string) (bool, error)
 	UpdateLocation(ctx context.Context, userID string, lat, long float64) error
-	UpdatePushToken(ctx context.Context, userID, token string) error
-	DeletePushToken(ctx context.Context, userID string) error
-	GetUsersInRadius(ctx context.Context, lat, long, radius float64) ([]model.User, error)
+	UpdatePushToken(ctx context.Context, userID string, params model.RegisterDeviceParams) error
+	DeletePushToken(ctx context.Context, userID, token string) error
+	GetUserIDsInRadius(ctx context.Context, lat, long, radius float64) ([]string, error)
+	GetPushRecipients(ctx context.Context, userIDs []string, activeSince time.Time) ([]model.PushRecipient, error)
+	FollowUser(ctx context.Context, followerID, followeeID string) error
+	UnfollowUser(ctx context.Context, followerID, followeeID string) error
+	GetUserFollowerCount(ctx context.Context, userID string) (int, error)
+	GetUserFollowingCount(ctx context.Context, userID string) (int, error)
+	GetFollowerIDs(ctx context.Context, userID string) ([]string, error)
+	BlockUser(ctx context.Context, blockerID, blockedID string) error
+	UnblockUser(ctx context.Context, blockerID, blockedID string) error
+	GetBlockedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.User, error)
+	MuteUser(ctx context.Context, muterID, mutedID string) error
+	UnmuteUser(ctx context.Context, muterID, mutedID string) error
+	GetMutedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.User, error)
+	// GetNotifiableUserIDs drops the users that muted or blocked the actor
+	GetNotifiableUserIDs(ctx context.Context, userIDs []string, actorID string) ([]string, error)
+	// DeleteUser deletes the user and (by cascade) all of their rows, it returns the images of other users' content
+	// that was deleted along with the user
+	DeleteUser(ctx context.Context, userID string) ([]string, error)
 }
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+	"github.com/ksha23/CS407-FactSnap/internal/errs"
+)
+
+type commentService struct {
+	commentRepo     port.CommentRepo
+	responseService port.ResponseService
+	questionService port.QuestionService
+}
+
+func NewCommentService(
+	commentRepo port.CommentRepo,
+	responseService port.ResponseService,
+	questionService port.QuestionService,
+) port.CommentService {
+	return &commentService{
+		commentRepo:     commentRepo,
+		responseService: responseService,
+		questionService: questionService,
+	}
+}
+
+func (s *commentService) CreateComment(ctx context.Context, userID string, params model.CreateCommentParams) (model.Comment, error) {
+	// ensure the response exists and its question hasn't expired yet
+	if _, err := s.getOpenResponse(ctx, userID, params.ResponseID); err != nil {
+		return model.Comment{}, fmt.Errorf("CommentService::CreateComment: %w", err)
+	}
+
+	comment, err := s.commentRepo.CreateComment(ctx, userID, params)
+	if err != nil {
+		return model.Comment{}, fmt.Errorf("CommentService::CreateComment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (s *commentService) GetCommentsByResponseID(ctx context.Context, userID string, responseID uuid.UUID, page model.PageParams) ([]model.Comment, error) {
+	// make sure the response exists and is not deleted
+	if _, err := s.responseService.GetResponseByID(ctx, userID, responseID); err != nil {
+		return nil, fmt.Errorf("CommentService::GetCommentsByResponseID: %w", err)
+	}
+
+	comments, err := s.commentRepo.GetCommentsByResponseID(ctx, userID, responseID, page)
+	if err != nil {
+		return nil, fmt.Errorf("CommentService::GetCommentsByResponseID: %w", err)
+	}
+	return comments, nil
+}
+
+func (s *commentService) EditComment(ctx context.Context, userID string, params model.EditCommentParams) (model.Comment, error) {
+	// check if user is authorized to edit this comment
+	if _, err := s.authorizeUser(ctx, userID, params.CommentID, false); err != nil {
+		return model.Comment{}, fmt.Errorf("CommentService::EditComment: %w", err)
+	}
+
+	comment, err := s.commentRepo.EditComment(ctx, userID, params)
+	if err != nil {
+		return model.Comment{}, fmt.Errorf("CommentService::EditComment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (s *commentService) DeleteComment(ctx context.Context, userID string, commentID uuid.UUID) error {
+	// check if user is authorized to delete this comment
+	comment, err := s.authorizeUser(ctx, userID, commentID, true)
+	if err != nil {
+		return fmt.Errorf("CommentService::DeleteComment: %w", err)
+	}
+
+	if err := s.commentRepo.DeleteComment(ctx, comment.ID, comment.ResponseID); err != nil {
+		return fmt.Errorf("CommentService::DeleteComment: %w", err)
+	}
+
+	return nil
+}
+
+// authorizeUser follows the same rules as for responses, the user must own the comment and,
+// unless bypassExpiration is set, the question of the response must not have expired yet
+func (s *commentService) authorizeUser(ctx context.Context, userID string, commentID uuid.UUID, bypassExpiration bool) (model.Comment, error) {
+	// fetch the comment
+	comment, err := s.commentRepo.GetCommentByID(ctx, userID, commentID)
+	if err != nil {
+		return model.Comment{}, fmt.Errorf("authorizeUser: %w", err)
+	}
+
+	// ensure that the user owns the comment
+	if !comment.IsOwned {
+		err := fmt.Errorf("user id %s does not own comment id %s", userID, commentID)
+		return model.Comment{}, fmt.Errorf("authorizeUser: %w", errs.UnauthorizedError("You must own this comment", err))
+	}
+
+	// the response may have been deleted meanwhile
+	if bypassExpiration {
+		if _, err := s.responseService.GetResponseByID(ctx, userID, comment.ResponseID); err != nil {
+			return model.Comment{}, fmt.Errorf("authorizeUser: %w", err)
+		}
+	} else {
+		if _, err := s.getOpenResponse(ctx, userID, comment.ResponseID); err != nil {
+			return model.Comment{}, fmt.Errorf("authorizeUser: %w", err)
+		}
+	}
+
+	return comment, nil
+}
+
+// getOpenResponse returns the response if it exists and its question hasn't expired yet
+func (s *commentService) getOpenResponse(ctx context.Context, userID string, responseID uuid.UUID) (model.Response, error) {
+	response, err := s.responseService.GetResponseByID(ctx, userID, responseID)
+	if err != nil {
+		return model.Response{}, fmt.Errorf("getOpenResponse: %w", err)
+	}
+
+	question, err := s.questionService.GetQuestionByID(ctx, userID, response.QuestionID)
+	if err != nil {
+		return model.Response{}, fmt.Errorf("getOpenResponse: GetQuestionByID: %w", err)
+	}
+
+	// check if question has expired
+	if time.Now().After(question.ExpiredAt) {
+		err := fmt.Errorf("question id %s has expired", question.ID)
+		return model.Response{}, fmt.Errorf("getOpenResponse: %w", errs.UnauthorizedError("This question has expired", err))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+	"github.com/ksha23/CS407-FactSnap/internal/errs"
+	"github.com/ksha23/CS407-FactSnap/internal/ptr"
+)
+
+const (
+	defaultDataExportInterval = 30 * time.Second
+	// an export that is processing for longer was abandoned (e.g. the server restarted) and is claimed again
+	dataExportStaleAfter = 15 * time.Minute
+	dataExportPageSize   = 100
+
+	dataExportFileName    = "factsnap-data-export.zip"
+	dataExportArchiveFile = "factsnap-data.json"
+)
+
+type dataExporter struct {
+	dataExportRepo port.DataExportRepo
+	userRepo       port.UserRepository
+	questionRepo   port.QuestionRepo
+	responseRepo   port.ResponseRepo
+	mediaService   port.MediaService
+	inboxService   port.InboxService
+	interval       time.Duration
+}
+
+func NewDataExporter(
+	dataExportRepo port.DataExportRepo,
+	userRepo port.UserRepository,
+	questionRepo port.QuestionRepo,
+	responseRepo port.ResponseRepo,
+	mediaService port.MediaService,
+	inboxService port.InboxService,
+) port.DataExporter {
+	return &dataExporter{
+		dataExportRepo: dataExportRepo,
+		userRepo:       userRepo,
+		questionRepo:   questionRepo,
+		responseRepo:   responseRepo,
+		mediaService:   mediaService,
+		inboxService:   inboxService,
+		interval:       defaultDataExportInterval,
+	}
+}
+
+func (e *dataExporter) Run(ctx context.Context) error {
+	slog.Info("Starting data exporter...", "interval", e.interval.String())
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.exportAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Shutting down data exporter...")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// exportAll builds the claimable exports one at a time, until there are none left
+func (e *dataExporter) exportAll(ctx context.Context) {
+	for ctx.Err() == nil {
+		export, err := e.dataExportRepo.ClaimDataExport(ctx, time.Now().Add(-dataExportStaleAfter))
+		if err != nil {
+			if errs.ErrType(err) != errs.TypeNotFound {
+				slog.ErrorContext(ctx, "Failed to claim data export", "error", err)
+			}
+			return
+		}
+
+		if err := e.export(ctx, export); err != nil {
+			slog.ErrorContext(ctx, "Failed to build data export", "export_id", export.ID, "user_id", export.UserID, "error", err)
+			if err := e.dataExportRepo.FailDataExport(ctx, export.ID, err.Error()); err != nil {
+				slog.ErrorContext(ctx, "Failed to mark data export as failed", "export_id", export.ID, "error", err)
+			}
+		}
+	}
+}
+
+func (e *dataExporter) export(ctx context.Context, export model.DataExport) error {
+	bundle, err := e.buildBundle(ctx, export.UserID)
+	if err != nil {
+		return fmt.Errorf("DataExporter::export: %w", err)
+	}
+
+	archive, err := zipBundle(bundle)
+	if err != nil {
+		return fmt.Errorf("DataExporter::export: %w", err)
+	}
+
+	// the archive is stored with the user's uploads, so it is deleted along with their account
+	asset, err := e.mediaService.StoreFile(ctx, model.UploadMediaParams{
+		FileName: dataExportFileName,
+		MimeType: "application/zip",
+		Data:     archive,
+		Uploader: export.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("DataExporter::export: %w", err)
+	}
+
+	completed, err := e.dataExportRepo.CompleteDataExport(ctx, export.ID, asset.Key)
+	if err != nil {
+		return fmt.Errorf("DataExporter::export: %w", err)
+	}
+	if !completed {
+		// the user deleted their account meanwhile, so the archive must not outlive it
+		slog.InfoContext(ctx, "Data export was deleted while it was built", "export_id", export.ID, "user_id", export.UserID)
+		if err := e.mediaService.DeleteUploads(ctx, export.UserID); err != nil {
+			return fmt.Errorf("DataExporter::export: %w", err)
+		}
+		return nil
+	}
+
+	err = e.inboxService.Notify(ctx, []string{export.UserID}, model.NotifyParams{
+		Type:  model.NotificationTypeDataExportReady,
+		Title: "Your Data Is Ready",
+		Body:  "The copy of your questions, responses and votes can now be downloaded.",
+		Data: map[string]any{
+			"exportId": export.ID.String(),
+			"type":     "data_export_ready",
+		},
+		DedupeKey: ptr.To(fmt.Sprintf("data_export:%s", export.ID)),
+	})
+	if err != nil {
+		// the export is ready anyway, it can still be fetched by the client
+		slog.ErrorContext(ctx, "Failed to notify user about data export", "export_id", export.ID, "user_id", export.UserID, "error", err)
+	}
+
+	slog.InfoContext(ctx, "Built data export", "export_id", export.ID, "user_id", export.UserID, "size", asset.Size)
+	return nil
+}
+
+func (e *dataExporter) buildBundle(ctx context.Context, userID string) (model.UserDataBundle, error) {
+	user, err := e.userRepo.GetAuthUserByID(ctx, userID)
+	if err != nil {
+		return model.UserDataBundle{}, fmt.Errorf("DataExporter::buildBundle: %w", err)
+	}
+
+	questions, err := collectPages(func(page model.PageParams) ([]model.Question, error) {
+		return e.questionRepo.GetQuestionsByUserID(ctx, userID, page)
+	})
+	if err != nil {
+		return model.UserDataBundle{}, fmt.Errorf("DataExporter::buildBundle: %w", err)
+	}
+
+	responses, err := collectPages(func(page model.PageParams) ([]model.Response, error) {
+		return e.responseRepo.GetResponsesByAuthorID(ctx, userID, userID, page)
+	})
+	if err != nil {
+		return model.UserDataBundle{}, fmt.Errorf("DataExporter::buildBundle: %w", err)
+	}
+
+	votes, err := e.dataExportRepo.GetVotesByUserID(ctx, userID)
+	if err != nil {
+		return model.UserDataBundle{}, fmt.Errorf("DataExporter::buildBundle: %w", err)
+	}
+
+	mediaURLs := []string{}
+	if user.AvatarURL != nil {
+		mediaURLs = append(mediaURLs, *user.AvatarURL)
+	}
+	for _, question := range questions {
+		mediaURLs = append(mediaURLs, question.ImageURLs...)
+	}
+	for _, response := range responses {
+		mediaURLs = append(mediaURLs, response.ImageURLs...)
+	}
+
+	return model.UserDataBundle{
+		ExportedAt: time.Now().UTC(),
+		User:       user,
+		Questions:  questions,
+		Responses:  responses,
+		Votes:      votes,
+		MediaURLs:  mediaURLs,
+	}, nil
+}
+
+// collectPages fetches pages until a page is not full
+func collectPages[T any](fetch func(page model.PageParams) ([]T, error)) ([]T, error) {
+	items := []T{}
+	for offset := 0; ; offset += dataExportPageSize {
+		page, err := fetch(model.PageParams{Limit: dataExportPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if len(page) < dataExportPageSize {
+			return items, nil
+		}
+	}
+}
+
+// zipBundle returns a ZIP archive with the bundle as a single JSON file
+func zipBundle(bundle model.UserDataBundle) ([]byte, error) {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+
+	file, err := zipWriter.Create(dataExportArchiveFile)
+	if err != nil {
+		return nil, fmt.Errorf("zipBundle: could not create archive file: %w", err)
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(bundle); err != nil {
+		return nil, fmt.Errorf("zipBundle: could not encode bundle: %w", err)
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("zipBundle: could not close archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/config"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+const (
+	defaultSoftDeleteGracePeriod   = 10 * time.Minute
+	defaultSoftDeletePurgeInterval = time.Minute
+	defaultSoftDeletePurgeBatch    = 50
+
+	purgeMediaDeleteTimeout = 30 * time.Second
+)
+
+// parseSoftDeleteGracePeriod returns how long deleted questions and responses can be restored
+func parseSoftDeleteGracePeriod(cfg config.SoftDelete) (time.Duration, error) {
+	if cfg.GracePeriod == "" {
+		return defaultSoftDeleteGracePeriod, nil
+	}
+
+	gracePeriod, err := time.ParseDuration(cfg.GracePeriod)
+	if err != nil {
+		return 0, fmt.Errorf("invalid soft delete grace period: %w", err)
+	}
+	return gracePeriod, nil
+}
+
+type deletedContentPurger struct {
+	questionRepo port.QuestionRepo
+	responseRepo port.ResponseRepo
+	mediaService port.MediaService
+	gracePeriod  time.Duration
+	interval     time.Duration
+	batchSize    int
+}
+
+func NewDeletedContentPurger(
+	cfg config.SoftDelete,
+	questionRepo port.QuestionRepo,
+	responseRepo port.ResponseRepo,
+	mediaService port.MediaService,
+) (port.DeletedContentPurger, error) {
+	gracePeriod, err := parseSoftDeleteGracePeriod(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &deletedContentPurger{
+		questionRepo: questionRepo,
+		responseRepo: responseRepo,
+		mediaService: mediaService,
+		gracePeriod:  gracePeriod,
+		interval:     defaultSoftDeletePurgeInterval,
+		batchSize:    defaultSoftDeletePurgeBatch,
+	}
+
+	if cfg.PurgeInterval != "" {
+		interval, err := time.ParseDuration(cfg.PurgeInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid soft delete purge interval: %w", err)
+		}
+		p.interval = interval
+	}
+	if cfg.PurgeBatchSize > 0 {
+		p.batchSize = cfg.PurgeBatchSize
+	}
+
+	return p, nil
+}
+
+func (p *deletedContentPurger) Run(ctx context.Context) error {
+	slog.Info("Starting deleted content purger...", "interval", p.interval.String(), "grace_period", p.gracePeriod.String())
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		deletedBefore := time.Now().Add(-p.gracePeriod)
+
+		// keep purging while full batches are due
+		p.purgeAll(ctx, "question", func() (int, error) {
+			return p.purgeBatch(ctx, deletedBefore, p.questionRepo.GetQuestionsDeletedBefore, p.questionRepo.HardDeleteQuestion)
+		})
+		p.purgeAll(ctx, "response", func() (int, error) {
+			return p.purgeBatch(ctx, deletedBefore, p.responseRepo.GetResponsesDeletedBefore, p.responseRepo.HardDeleteResponse)
+		})
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Shutting down deleted content purger...")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *deletedContentPurger) purgeAll(ctx context.Context, kind string, purgeBatch func() (int, error)) {
+	for {
+		n, err := purgeBatch()
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to purge deleted content", "kind", kind, "error", err)
+			return
+		}
+		if n < p.batchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// purgeBatch hard-deletes a batch of content that was deleted before deletedBefore, media first,
+// so that a failure leaves the content to be purged again by the next run
+func (p *deletedContentPurger) purgeBatch(
+	ctx context.Context,
+	deletedBefore time.Time,
+	getDeleted func(ctx context.Context, deletedBefore time.Time, limit int) ([]model.DeletedContent, error),
+	hardDelete func(ctx context.Context, id uuid.UUID, deletedBefore time.Time) (bool, error),
+) (int, error) {
+	contents, err := getDeleted(ctx, deletedBefore, p.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("DeletedContentPurger::purgeBatch: %w", err)
+	}
+
+	purged := 0
+	for _, content := range contents {
+		if len(content.ImageURLs) > 0 {
+			mediaCtx, cancel := context.WithTimeout(ctx, purgeMediaDeleteTimeout)
+			err := p.mediaService.DeleteMedia(mediaCtx, content.ImageURLs)
+			cancel()
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to delete media of deleted content", "id", content.ID, "error", err, "image_urls", content.ImageURLs)
+				continue
+			}
+		}
+
+		if _, err := hardDelete(ctx, content.ID, deletedBefore); err != nil {
+			slog.ErrorContext(ctx, "Failed to hard-delete deleted content", "id", content.ID, "error", err)
+			continue
+		}
+		purged++
+	}
+
+	// failed content is not counted, so that a batch that keeps failing is not retried in a tight loop
+	return purged, nil
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+// publishEvent broadcasts a realtime event. Realtime updates are best-effort, so a failure is only logged
+// and must never fail the request that caused the event.
+func publishEvent(ctx context.Context, publisher port.EventPublisher, event model.Event) {
+	if err := publisher.Publish(context.WithoutCancel(ctx), event); err != nil {
+		slog.ErrorContext(ctx, "Failed to publish realtime event", "error", err, "type", event.Type, "question_id", event.QuestionID)
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+// devices that have not been seen for this long are no longer notified
+const activeDeviceWindow = 90 * 24 * time.Hour
+
+type inboxService struct {
+	notificationRepo    port.NotificationRepo
+	userRepo            port.UserRepository
+	notificationService port.NotificationService
+}
+
+func NewInboxService(notificationRepo port.NotificationRepo, userRepo port.UserRepository, notificationService port.NotificationService) *inboxService {
+	return &inboxService{
+		notificationRepo:    notificationRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+	}
+}
+
+func (s *inboxService) Notify(ctx context.Context, userIDs []string, params model.NotifyParams) error {
+	if params.ActorID != nil && len(userIDs) > 0 {
+		notifiableIDs, err := s.userRepo.GetNotifiableUserIDs(ctx, userIDs, *params.ActorID)
+		if err != nil {
+			return fmt.Errorf("InboxService::Notify: %w", err)
+		}
+		userIDs = notifiableIDs
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	// persist first, so the notification shows up in-app even if the user has push disabled
+	createdIDs, err := s.notificationRepo.CreateNotifications(ctx, userIDs, params)
+	if err != nil {
+		return fmt.Errorf("InboxService::Notify: %w", err)
+	}
+	// users that already had the notification, e.g. from an earlier attempt of a retried outbox message, are not pushed to again
+	userIDs = createdIDs
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	recipients, err := s.userRepo.GetPushRecipients(ctx, userIDs, time.Now().Add(-activeDeviceWindow))
+	if err != nil {
+		return fmt.Errorf("InboxService::Notify: %w", err)
+	}
+
+	if err := s.notificationService.SendPushNotification(ctx, recipients, params.Title, params.Body, params.Data); err != nil {
+		return fmt.Errorf("InboxService::Notify: %w", err)
+	}
+
+	return nil
+}
+
+func (s *inboxService) GetNotifications(ctx context.Context, userID string, page model.PageParams) ([]model.Notification, int, error) {
+	notifications, err := s.notificationRepo.GetNotificationsByUserID(ctx, userID, page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("InboxService::GetNotifications: %w", err)
+	}
+
+	unreadCount, err := s.notificationRepo.GetUnreadNotificationCount(ctx, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("InboxService::GetNotifications: %w", err)
+	}
+
+	return notifications, unreadCount, nil
+}
+
+func (s *inboxService) MarkNotificationRead(ctx context.Context, userID string, notificationID uuid.UUID) error {
+	if err := s.notificationRepo.MarkNotificationRead(ctx, userID, notificationID); err != nil {
+		return fmt.Errorf("InboxService::MarkNotificationRead: %w", err)
+	}
+	return nil
+}
+
+func (s *inboxService) MarkAllNotificationsRead(ctx context.Context, userID string) error {
+	if err := s.notificationRepo.MarkAllNotificationsRead(ctx, userID); err != nil {
+		return fmt.Errorf("InboxService::MarkAllNotificationsRead: %w", err)
+	}
+	return nil
+}
+
+func (s *inboxService) DeleteNotification(ctx context.Context, userID string, notificationID uuid.UUID) error {
+	if err := s.notificationRepo.DeleteNotification(ctx, userID, notificationID); err != nil {
+		return fmt.Errorf("InboxService::DeleteNotification: %w", err)
+	}
+	return nil
+}
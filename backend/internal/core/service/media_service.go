@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 	"github.com/ksha23/CS407-FactSnap/internal/core/port"
@@ -62,6 +64,52 @@ func (s *mediaService) DeleteMedia(ctx context.Context, mediaUrls []string) erro
 	return nil
 }
 
+func (s *mediaService) ValidateOwnership(ctx context.Context, uploader string, mediaUrls []string) error {
+	for _, url := range mediaUrls {
+		uploaded, err := s.client.IsUploadedBy(ctx, url, uploader)
+		if err != nil {
+			return fmt.Errorf("MediaService::ValidateOwnership: %w", err)
+		}
+		if !uploaded {
+			err := errs.BadRequestError("You can only attach images you have uploaded", fmt.Errorf("media url %s was not uploaded by user id %s", url, uploader))
+			return fmt.Errorf("MediaService::ValidateOwnership: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *mediaService) StoreFile(ctx context.Context, params model.UploadMediaParams) (model.MediaAsset, error) {
+	if params.Uploader == "" || params.FileName == "" || params.MimeType == "" {
+		return model.MediaAsset{}, fmt.Errorf("MediaService::StoreFile: missing uploader, file name or mime type of file %q", params.FileName)
+	}
+
+	asset, err := s.client.Upload(ctx, params)
+	if err != nil {
+		return model.MediaAsset{}, fmt.Errorf("MediaService::StoreFile: %w", err)
+	}
+
+	return asset, nil
+}
+
+func (s *mediaService) GetDownloadURL(ctx context.Context, key string) (string, time.Time, error) {
+	url, expiresAt, err := s.client.PresignURL(ctx, key)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("MediaService::GetDownloadURL: %w", err)
+	}
+	return url, expiresAt, nil
+}
+
+func (s *mediaService) DeleteUploads(ctx context.Context, uploader string) error {
+	if uploader == "" {
+		return errors.New("MediaService::DeleteUploads: uploader id is required")
+	}
+
+	if err := s.client.DeleteByUploader(ctx, uploader); err != nil {
+		return fmt.Errorf("MediaService::DeleteUploads: %w", err)
+	}
+	return nil
+}
+
 func (s *mediaService) validateUploadParams(params model.UploadMediaParams) error {
 	if params.Uploader == "" {
 		return errs.UnauthenticatedError("uploader id is required", errors.New("missing uploader id"))
@@ -96,3 +144,38 @@ func (s *mediaService) validateUploadParams(params model.UploadMediaParams) erro
 	}
 	return nil
 }
+
+// diffMediaURLs returns the urls that are in after but not in before, and the ones that are in before but not in after
+func diffMediaURLs(before, after []string) (added []string, removed []string) {
+	beforeSet := make(map[string]struct{}, len(before))
+	for _, url := range before {
+		beforeSet[url] = struct{}{}
+	}
+	afterSet := make(map[string]struct{}, len(after))
+	for _, url := range after {
+		afterSet[url] = struct{}{}
+		if _, ok := beforeSet[url]; !ok {
+			added = append(added, url)
+		}
+	}
+	for _, url := range before {
+		if _, ok := afterSet[url]; !ok {
+			removed = append(removed, url)
+		}
+	}
+	return added, removed
+}
+
+// deleteMediaInBackground deletes the media without blocking the caller, errors are only logged
+func deleteMediaInBackground(ctx context.Context, mediaService port.MediaService, mediaUrls []string, caller string) {
+	if len(mediaUrls) == 0 {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		if err := mediaService.DeleteMedia(ctx, mediaUrls); err != nil {
+			slog.ErrorContext(ctx, caller+": error while deleting images", "error", err, "image_urls", mediaUrls)
+		}
+	}()
+}
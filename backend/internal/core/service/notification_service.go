@@ -4,20 +4,67 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/ksha23/CS407-FactSnap/internal/config"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 	"github.com/ksha23/CS407-FactSnap/internal/core/port"
 )
 
+const (
+	defaultExpoBaseURL         = "https://exp.host"
+	defaultReceiptPollInterval = 5 * time.Minute
+
+	expoSendPath     = "/--/api/v2/push/send"
+	expoReceiptsPath = "/--/api/v2/push/getReceipts"
+
+	// https://docs.expo.dev/push-notifications/sending-notifications/#request-errors
+	expoMaxMessagesPerRequest   = 100
+	expoMaxReceiptIDsPerRequest = 1000
+	// Expo recommends waiting ~15 minutes before checking the receipt of a ticket
+	expoReceiptDelay = 15 * time.Minute
+
+	expoStatusOK               = "ok"
+	expoErrDeviceNotRegistered = "DeviceNotRegistered"
+)
+
 type ExpoNotificationService struct {
-	client *http.Client
+	client              *http.Client
+	baseURL             string
+	accessToken         string
+	receiptPollInterval time.Duration
+	notificationRepo    port.NotificationRepo
+	userRepo            port.UserRepository
 }
 
-func NewExpoNotificationService() port.NotificationService {
-	return &ExpoNotificationService{
-		client: &http.Client{},
+func NewExpoNotificationService(cfg config.Expo, notificationRepo port.NotificationRepo, userRepo port.UserRepository) (*ExpoNotificationService, error) {
+	s := &ExpoNotificationService{
+		client:              &http.Client{Timeout: 30 * time.Second},
+		baseURL:             defaultExpoBaseURL,
+		accessToken:         cfg.AccessToken,
+		receiptPollInterval: defaultReceiptPollInterval,
+		notificationRepo:    notificationRepo,
+		userRepo:            userRepo,
+	}
+
+	if cfg.BaseURL != "" {
+		s.baseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	}
+	if cfg.ReceiptPollInterval != "" {
+		interval, err := time.ParseDuration(cfg.ReceiptPollInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expo receipt poll interval: %w", err)
+		}
+		s.receiptPollInterval = interval
 	}
+
+	return s, nil
 }
 
 type ExpoMessage struct {
@@ -27,41 +74,220 @@ type ExpoMessage struct {
 	Data  map[string]interface{} `json:"data,omitempty"`
 }
 
-func (s *ExpoNotificationService) SendPushNotification(ctx context.Context, tokens []string, title, body string, data map[string]interface{}) error {
-	if len(tokens) == 0 {
-		// log that there are no tokens to send to
-		fmt.Println("No tokens to send push notifications to")
+type expoErrorDetails struct {
+	Error string `json:"error"`
+}
+
+// expoPushTicket is used for both push tickets and push receipts, as they share the same shape
+type expoPushTicket struct {
+	Status  string            `json:"status"`
+	ID      string            `json:"id"`
+	Message string            `json:"message"`
+	Details *expoErrorDetails `json:"details"`
+}
+
+func (t expoPushTicket) errorCode() string {
+	if t.Details == nil {
+		return ""
+	}
+	return t.Details.Error
+}
+
+type expoRequestError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type expoSendResponse struct {
+	Data   []expoPushTicket   `json:"data"`
+	Errors []expoRequestError `json:"errors"`
+}
+
+type expoReceiptsResponse struct {
+	Data   map[string]expoPushTicket `json:"data"`
+	Errors []expoRequestError        `json:"errors"`
+}
+
+func (s *ExpoNotificationService) SendPushNotification(ctx context.Context, recipients []model.PushRecipient, title, body string, data map[string]interface{}) error {
+	if len(recipients) == 0 {
+		slog.DebugContext(ctx, "No recipients to send push notification to")
 		return nil
 	}
 
-	fmt.Println("Sending push notification to tokens:", tokens)
+	slog.DebugContext(ctx, "Sending push notification", "recipients", len(recipients))
+
+	var (
+		tickets  []model.PushTicket
+		sendErrs []error
+		sent     int
+	)
+	for start := 0; start < len(recipients); start += expoMaxMessagesPerRequest {
+		chunk := recipients[start:min(start+expoMaxMessagesPerRequest, len(recipients))]
+
+		chunkTickets, err := s.sendChunk(ctx, chunk, title, body, data)
+		if err != nil {
+			sendErrs = append(sendErrs, err)
+			continue
+		}
+		sent += len(chunk)
+		tickets = append(tickets, chunkTickets...)
+	}
+
+	// keep the tickets, so that their receipts can be checked later on
+	if err := s.notificationRepo.CreatePushTickets(ctx, tickets); err != nil {
+		slog.ErrorContext(ctx, "Failed to store push tickets", "error", err)
+	}
+
+	if len(sendErrs) > 0 {
+		err := errors.Join(sendErrs...)
+		// only fail if nothing went out, otherwise a retry would notify the recipients of the successful chunks twice
+		if sent == 0 {
+			return fmt.Errorf("ExpoNotificationService::SendPushNotification: %w", err)
+		}
+		slog.ErrorContext(ctx, "Failed to send some push notification chunks", "sent", sent, "total", len(recipients), "error", err)
+	}
 
-	// Expo allows batching, but for simplicity we send one request with all messages
-	// Note: Expo recommends batching if sending to many users.
-	// Here we assume tokens list is not huge.
+	return nil
+}
 
-	var messages []ExpoMessage
-	for _, token := range tokens {
+// sendChunk sends a single request of at most expoMaxMessagesPerRequest messages and returns the tickets that were accepted
+func (s *ExpoNotificationService) sendChunk(ctx context.Context, recipients []model.PushRecipient, title, body string, data map[string]interface{}) ([]model.PushTicket, error) {
+	messages := make([]ExpoMessage, 0, len(recipients))
+	for _, r := range recipients {
 		messages = append(messages, ExpoMessage{
-			To:    token,
+			To:    r.Token,
 			Title: title,
 			Body:  body,
 			Data:  data,
 		})
 	}
 
-	payload, err := json.Marshal(messages)
+	var resp expoSendResponse
+	if err := s.postJSON(ctx, expoSendPath, messages, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("expo push request failed: %v", resp.Errors)
+	}
+	if len(resp.Data) != len(recipients) {
+		return nil, fmt.Errorf("expo returned %d tickets for %d messages", len(resp.Data), len(recipients))
+	}
+
+	// tickets are returned in the same order as the messages were sent
+	tickets := make([]model.PushTicket, 0, len(recipients))
+	for i, ticket := range resp.Data {
+		recipient := recipients[i]
+		if ticket.Status == expoStatusOK {
+			if ticket.ID != "" {
+				tickets = append(tickets, model.PushTicket{
+					TicketID: ticket.ID,
+					UserID:   recipient.UserID,
+					Token:    recipient.Token,
+				})
+			}
+			continue
+		}
+
+		if ticket.errorCode() == expoErrDeviceNotRegistered {
+			s.removeToken(ctx, recipient)
+			continue
+		}
+		slog.WarnContext(ctx, "Expo rejected push notification", "user_id", recipient.UserID, "error", ticket.errorCode(), "message", ticket.Message)
+	}
+
+	return tickets, nil
+}
+
+// Run periodically checks the receipts of sent push notifications until the context is canceled
+func (s *ExpoNotificationService) Run(ctx context.Context) error {
+	slog.Info("Starting push receipt poller...", "poll_interval", s.receiptPollInterval.String())
+
+	ticker := time.NewTicker(s.receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Shutting down push receipt poller...")
+			return nil
+		case <-ticker.C:
+			if err := s.checkReceipts(ctx); err != nil {
+				slog.ErrorContext(ctx, "Failed to check push receipts", "error", err)
+			}
+		}
+	}
+}
+
+func (s *ExpoNotificationService) checkReceipts(ctx context.Context) error {
+	for {
+		tickets, err := s.notificationRepo.GetPushTicketsCreatedBefore(ctx, time.Now().Add(-expoReceiptDelay), expoMaxReceiptIDsPerRequest)
+		if err != nil {
+			return fmt.Errorf("ExpoNotificationService::checkReceipts: %w", err)
+		}
+		if len(tickets) == 0 {
+			return nil
+		}
+
+		ticketIDs := make([]string, 0, len(tickets))
+		for _, t := range tickets {
+			ticketIDs = append(ticketIDs, t.TicketID)
+		}
+
+		var resp expoReceiptsResponse
+		if err := s.postJSON(ctx, expoReceiptsPath, map[string][]string{"ids": ticketIDs}, &resp); err != nil {
+			return fmt.Errorf("ExpoNotificationService::checkReceipts: %w", err)
+		}
+		if len(resp.Errors) > 0 {
+			return fmt.Errorf("ExpoNotificationService::checkReceipts: expo receipts request failed: %v", resp.Errors)
+		}
+
+		for _, t := range tickets {
+			receipt, ok := resp.Data[t.TicketID]
+			if !ok || receipt.Status == expoStatusOK {
+				continue
+			}
+			if receipt.errorCode() == expoErrDeviceNotRegistered {
+				s.removeToken(ctx, model.PushRecipient{UserID: t.UserID, Token: t.Token})
+				continue
+			}
+			slog.WarnContext(ctx, "Expo failed to deliver push notification", "user_id", t.UserID, "error", receipt.errorCode(), "message", receipt.Message)
+		}
+
+		// Checked tickets are removed even if Expo returned no receipt for them. A receipt missing after
+		// expoReceiptDelay most likely expired, and keeping the ticket would re-check the same batch forever.
+		if err := s.notificationRepo.DeletePushTickets(ctx, ticketIDs); err != nil {
+			return fmt.Errorf("ExpoNotificationService::checkReceipts: %w", err)
+		}
+
+		if len(tickets) < expoMaxReceiptIDsPerRequest {
+			return nil
+		}
+	}
+}
+
+// removeToken unregisters a push token that Expo reported as no longer valid
+func (s *ExpoNotificationService) removeToken(ctx context.Context, recipient model.PushRecipient) {
+	slog.InfoContext(ctx, "Removing unregistered push token", "user_id", recipient.UserID)
+	if err := s.userRepo.DeletePushToken(ctx, recipient.UserID, recipient.Token); err != nil {
+		slog.ErrorContext(ctx, "Failed to remove unregistered push token", "user_id", recipient.UserID, "error", err)
+	}
+}
+
+func (s *ExpoNotificationService) postJSON(ctx context.Context, path string, payload any, out any) error {
+	body, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal notification payload: %w", err)
+		return fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://exp.host/--/api/v2/push/send", bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+path, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
-
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	if s.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.accessToken)
+	}
 
 	resp, err := s.client.Do(req)
 	if err != nil {
@@ -69,15 +295,17 @@ func (s *ExpoNotificationService) SendPushNotification(ctx context.Context, toke
 	}
 	defer resp.Body.Close()
 
-	// Read response body for debugging
-	var respBody map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&respBody); err != nil {
-		return fmt.Errorf("failed to decode response body: %w", err)
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
 	}
-	fmt.Println("Expo push notification response:", respBody)
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("expo api returned status: %d, response: %v", resp.StatusCode, respBody)
+		return fmt.Errorf("expo api returned status: %d, response: %s", resp.StatusCode, respBody)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
 	}
 
 	return nil
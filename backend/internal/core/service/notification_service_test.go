@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ksha23/CS407-FactSnap/internal/config"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+// fakeNotificationRepo keeps push tickets in memory, other methods are not implemented
+type fakeNotificationRepo struct {
+	port.NotificationRepo
+	tickets        []model.PushTicket
+	deletedTickets []string
+}
+
+func (r *fakeNotificationRepo) CreatePushTickets(ctx context.Context, tickets []model.PushTicket) error {
+	r.tickets = append(r.tickets, tickets...)
+	return nil
+}
+
+func (r *fakeNotificationRepo) GetPushTicketsCreatedBefore(ctx context.Context, createdBefore time.Time, limit int) ([]model.PushTicket, error) {
+	var tickets []model.PushTicket
+	for _, t := range r.tickets {
+		if t.CreatedAt.Before(createdBefore) && !slices.Contains(r.deletedTickets, t.TicketID) && len(tickets) < limit {
+			tickets = append(tickets, t)
+		}
+	}
+	return tickets, nil
+}
+
+func (r *fakeNotificationRepo) DeletePushTickets(ctx context.Context, ticketIDs []string) error {
+	r.deletedTickets = append(r.deletedTickets, ticketIDs...)
+	return nil
+}
+
+// fakeUserRepo records the removed push tokens, other methods are not implemented
+type fakeUserRepo struct {
+	port.UserRepository
+	deletedTokens []string
+}
+
+func (r *fakeUserRepo) DeletePushToken(ctx context.Context, userID, token string) error {
+	r.deletedTokens = append(r.deletedTokens, token)
+	return nil
+}
+
+// fakeExpo stands in for the Expo push API
+type fakeExpo struct {
+	mu         sync.Mutex
+	chunkSizes []int
+	// tickets returns the ticket for a sent message
+	tickets func(message ExpoMessage) expoPushTicket
+	// receipts maps a ticket id to its receipt
+	receipts map[string]expoPushTicket
+}
+
+func (f *fakeExpo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case expoSendPath:
+		var messages []ExpoMessage
+		if err := json.NewDecoder(r.Body).Decode(&messages); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.chunkSizes = append(f.chunkSizes, len(messages))
+
+		resp := expoSendResponse{}
+		for _, message := range messages {
+			resp.Data = append(resp.Data, f.tickets(message))
+		}
+		json.NewEncoder(w).Encode(resp)
+	case expoReceiptsPath:
+		var req map[string][]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp := expoReceiptsResponse{Data: map[string]expoPushTicket{}}
+		for _, id := range req["ids"] {
+			if receipt, ok := f.receipts[id]; ok {
+				resp.Data[id] = receipt
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestNotificationService(t *testing.T, expo *fakeExpo) (*ExpoNotificationService, *fakeNotificationRepo, *fakeUserRepo) {
+	t.Helper()
+
+	server := httptest.NewServer(expo)
+	t.Cleanup(server.Close)
+
+	notificationRepo := &fakeNotificationRepo{}
+	userRepo := &fakeUserRepo{}
+	s, err := NewExpoNotificationService(config.Expo{BaseURL: server.URL}, notificationRepo, userRepo)
+	if err != nil {
+		t.Fatalf("NewExpoNotificationService: %v", err)
+	}
+	return s, notificationRepo, userRepo
+}
+
+func testRecipients(n int) []model.PushRecipient {
+	recipients := make([]model.PushRecipient, 0, n)
+	for i := range n {
+		recipients = append(recipients, model.PushRecipient{
+			UserID: fmt.Sprintf("user-%d", i),
+			Token:  fmt.Sprintf("ExponentPushToken[%d]", i),
+		})
+	}
+	return recipients
+}
+
+func TestSendPushNotificationSplitsIntoChunks(t *testing.T) {
+	expo := &fakeExpo{
+		tickets: func(message ExpoMessage) expoPushTicket {
+			return expoPushTicket{Status: expoStatusOK, ID: "ticket-" + message.To}
+		},
+	}
+	s, notificationRepo, _ := newTestNotificationService(t, expo)
+
+	err := s.SendPushNotification(context.Background(), testRecipients(250), "title", "body", nil)
+	if err != nil {
+		t.Fatalf("SendPushNotification: %v", err)
+	}
+
+	if want := []int{100, 100, 50}; !slices.Equal(expo.chunkSizes, want) {
+		t.Errorf("chunk sizes = %v, want %v", expo.chunkSizes, want)
+	}
+	if len(notificationRepo.tickets) != 250 {
+		t.Errorf("stored %d tickets, want 250", len(notificationRepo.tickets))
+	}
+}
+
+func TestSendPushNotificationHandlesTicketErrors(t *testing.T) {
+	recipients := testRecipients(3)
+	expo := &fakeExpo{
+		tickets: func(message ExpoMessage) expoPushTicket {
+			switch message.To {
+			case recipients[1].Token:
+				return expoPushTicket{Status: "error", Message: "not registered", Details: &expoErrorDetails{Error: expoErrDeviceNotRegistered}}
+			case recipients[2].Token:
+				return expoPushTicket{Status: "error", Message: "too big", Details: &expoErrorDetails{Error: "MessageTooBig"}}
+			default:
+				return expoPushTicket{Status: expoStatusOK, ID: "ticket-" + message.To}
+			}
+		},
+	}
+	s, notificationRepo, userRepo := newTestNotificationService(t, expo)
+
+	err := s.SendPushNotification(context.Background(), recipients, "title", "body", nil)
+	if err != nil {
+		t.Fatalf("SendPushNotification: %v", err)
+	}
+
+	// only the accepted message leaves a ticket to check
+	if len(notificationRepo.tickets) != 1 || notificationRepo.tickets[0].TicketID != "ticket-"+recipients[0].Token {
+		t.Errorf("stored tickets = %+v, want only the ticket of %s", notificationRepo.tickets, recipients[0].UserID)
+	}
+	// only the unregistered device is removed
+	if want := []string{recipients[1].Token}; !slices.Equal(userRepo.deletedTokens, want) {
+		t.Errorf("deleted tokens = %v, want %v", userRepo.deletedTokens, want)
+	}
+}
+
+func TestCheckReceiptsRemovesUnregisteredTokens(t *testing.T) {
+	expo := &fakeExpo{
+		receipts: map[string]expoPushTicket{
+			"ticket-ok":           {Status: expoStatusOK},
+			"ticket-unregistered": {Status: "error", Details: &expoErrorDetails{Error: expoErrDeviceNotRegistered}},
+			"ticket-rate-limited": {Status: "error", Details: &expoErrorDetails{Error: "MessageRateExceeded"}},
+		},
+	}
+	s, notificationRepo, userRepo := newTestNotificationService(t, expo)
+
+	createdAt := time.Now().Add(-2 * expoReceiptDelay)
+	notificationRepo.tickets = []model.PushTicket{
+		{TicketID: "ticket-ok", UserID: "user-1", Token: "token-ok", CreatedAt: createdAt},
+		{TicketID: "ticket-unregistered", UserID: "user-2", Token: "token-unregistered", CreatedAt: createdAt},
+		{TicketID: "ticket-rate-limited", UserID: "user-3", Token: "token-rate-limited", CreatedAt: createdAt},
+		{TicketID: "ticket-expired", UserID: "user-4", Token: "token-expired", CreatedAt: createdAt},
+		// too recent to be checked
+		{TicketID: "ticket-recent", UserID: "user-5", Token: "token-recent", CreatedAt: time.Now()},
+	}
+
+	if err := s.checkReceipts(context.Background()); err != nil {
+		t.Fatalf("checkReceipts: %v", err)
+	}
+
+	if want := []string{"token-unregistered"}; !slices.Equal(userRepo.deletedTokens, want) {
+		t.Errorf("deleted tokens = %v, want %v", userRepo.deletedTokens, want)
+	}
+	wantDeleted := []string{"ticket-ok", "ticket-unregistered", "ticket-rate-limited", "ticket-expired"}
+	if !slices.Equal(notificationRepo.deletedTickets, wantDeleted) {
+		t.Errorf("deleted tickets = %v, want %v", notificationRepo.deletedTickets, wantDeleted)
+	}
+}
@@ -0,0 +1,464 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ksha23/CS407-FactSnap/internal/config"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+	"github.com/ksha23/CS407-FactSnap/internal/errs"
+)
+
+const (
+	defaultOutboxPollInterval = 5 * time.Second
+	defaultOutboxBatchSize    = 50
+	defaultOutboxMaxAttempts  = 8
+
+	// how long a claimed message stays locked before another worker may reclaim it (e.g. if this worker crashed)
+	outboxLeaseDuration = 5 * time.Minute
+	// retry backoff: outboxBaseBackoff * 2^(attempts-1), capped at outboxMaxBackoff
+	outboxBaseBackoff = 10 * time.Second
+	outboxMaxBackoff  = 30 * time.Minute
+
+	// radius used to find users near a new question (10 miles ~ 16093 meters)
+	newQuestionRadiusMeters = 16093.0
+)
+
+type outboxHandler func(ctx context.Context, msg model.OutboxMessage) error
+
+type outboxWorker struct {
+	notificationRepo port.NotificationRepo
+	userRepo         port.UserRepository
+	questionRepo     port.QuestionRepo
+	inboxService     port.InboxService
+	pollInterval     time.Duration
+	batchSize        int
+	maxAttempts      int
+	handlers         map[model.OutboxMessageType]outboxHandler
+}
+
+func NewOutboxWorker(
+	cfg config.Notification,
+	notificationRepo port.NotificationRepo,
+	userRepo port.UserRepository,
+	questionRepo port.QuestionRepo,
+	inboxService port.InboxService,
+) (port.OutboxWorker, error) {
+	w := &outboxWorker{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		questionRepo:     questionRepo,
+		inboxService:     inboxService,
+		pollInterval:     defaultOutboxPollInterval,
+		batchSize:        defaultOutboxBatchSize,
+		maxAttempts:      defaultOutboxMaxAttempts,
+	}
+
+	if cfg.OutboxPollInterval != "" {
+		interval, err := time.ParseDuration(cfg.OutboxPollInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notification outbox poll interval: %w", err)
+		}
+		w.pollInterval = interval
+	}
+	if cfg.OutboxBatchSize > 0 {
+		w.batchSize = cfg.OutboxBatchSize
+	}
+	if cfg.OutboxMaxAttempts > 0 {
+		w.maxAttempts = cfg.OutboxMaxAttempts
+	}
+
+	w.handlers = map[model.OutboxMessageType]outboxHandler{
+		model.OutboxMessageTypeNewQuestion: w.handleNewQuestion,
+		model.OutboxMessageTypePollClosed:  w.handlePollClosed,
+		// a reopened question is announced to nearby users just like a new one
+		model.OutboxMessageTypeQuestionReopened: w.handleNewQuestion,
+		model.OutboxMessageTypeQuestionExpired:  w.handleQuestionExpired,
+		model.OutboxMessageTypeMention:          w.handleMention,
+		model.OutboxMessageTypeNewResponse:      w.handleNewResponse,
+	}
+
+	return w, nil
+}
+
+func (w *outboxWorker) Run(ctx context.Context) error {
+	slog.Info("Starting notification outbox worker...", "poll_interval", w.pollInterval.String())
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// keep draining while full batches are being claimed
+		for {
+			n, err := w.processBatch(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to process notification outbox batch", "error", err)
+				break
+			}
+			if n < w.batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Shutting down notification outbox worker...")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *outboxWorker) processBatch(ctx context.Context) (int, error) {
+	messages, err := w.notificationRepo.ClaimOutboxMessages(ctx, w.batchSize, time.Now().Add(outboxLeaseDuration))
+	if err != nil {
+		return 0, fmt.Errorf("OutboxWorker::processBatch: %w", err)
+	}
+
+	for _, msg := range messages {
+		w.deliver(ctx, msg)
+	}
+
+	return len(messages), nil
+}
+
+func (w *outboxWorker) deliver(ctx context.Context, msg model.OutboxMessage) {
+	// record the outcome even if the worker is shutting down mid-delivery
+	statusCtx := context.WithoutCancel(ctx)
+
+	handler, ok := w.handlers[msg.Type]
+	if !ok {
+		err := fmt.Errorf("no handler registered for outbox message type %q", msg.Type)
+		if err := w.notificationRepo.FailOutboxMessage(statusCtx, msg.ID, err.Error()); err != nil {
+			slog.ErrorContext(ctx, "Failed to mark outbox message as failed", "message_id", msg.ID, "error", err)
+		}
+		return
+	}
+
+	deliveryErr := handler(ctx, msg)
+	if deliveryErr == nil {
+		if err := w.notificationRepo.MarkOutboxMessageDelivered(statusCtx, msg.ID); err != nil {
+			slog.ErrorContext(ctx, "Failed to mark outbox message as delivered", "message_id", msg.ID, "error", err)
+		}
+		return
+	}
+
+	// msg.Attempts already includes the current attempt
+	if msg.Attempts >= w.maxAttempts {
+		slog.ErrorContext(ctx, "Giving up on outbox message", "message_id", msg.ID, "type", msg.Type, "attempts", msg.Attempts, "error", deliveryErr)
+		if err := w.notificationRepo.FailOutboxMessage(statusCtx, msg.ID, deliveryErr.Error()); err != nil {
+			slog.ErrorContext(ctx, "Failed to mark outbox message as failed", "message_id", msg.ID, "error", err)
+		}
+		return
+	}
+
+	availableAt := time.Now().Add(outboxBackoff(msg.Attempts))
+	slog.WarnContext(ctx, "Outbox message delivery failed, retrying later", "message_id", msg.ID, "type", msg.Type, "attempts", msg.Attempts, "retry_at", availableAt, "error", deliveryErr)
+	if err := w.notificationRepo.RetryOutboxMessage(statusCtx, msg.ID, deliveryErr.Error(), availableAt); err != nil {
+		slog.ErrorContext(ctx, "Failed to reschedule outbox message", "message_id", msg.ID, "error", err)
+	}
+}
+
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= outboxMaxBackoff {
+			return outboxMaxBackoff
+		}
+	}
+	return backoff
+}
+
+func (w *outboxWorker) handleNewQuestion(ctx context.Context, msg model.OutboxMessage) error {
+	var payload model.NewQuestionPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return fmt.Errorf("OutboxWorker::handleNewQuestion: could not unmarshal payload: %w", err)
+	}
+
+	// the followers of the author learn about new questions wherever they are
+	followerIDs := make(map[string]bool)
+	if msg.Type == model.OutboxMessageTypeNewQuestion {
+		ids, err := w.userRepo.GetFollowerIDs(ctx, payload.AuthorID)
+		if err != nil {
+			return fmt.Errorf("OutboxWorker::handleNewQuestion: %w", err)
+		}
+		for _, id := range ids {
+			followerIDs[id] = true
+		}
+
+		if len(ids) > 0 {
+			author, err := w.userRepo.GetAuthUserByID(ctx, payload.AuthorID)
+			if err != nil {
+				return fmt.Errorf("OutboxWorker::handleNewQuestion: %w", err)
+			}
+			err = w.inboxService.Notify(ctx, ids, model.NotifyParams{
+				Type:  model.NotificationTypeFollowedUserQuestion,
+				Title: fmt.Sprintf("@%s Asked a Question", author.Username),
+				Body:  payload.Title,
+				Data: map[string]any{
+					"questionId": payload.QuestionID.String(),
+					"type":       "followed_user_question",
+				},
+				DedupeKey: outboxDedupeKey(msg, "followers"),
+				ActorID:   &payload.AuthorID,
+			})
+			if err != nil {
+				return fmt.Errorf("OutboxWorker::handleNewQuestion: %w", err)
+			}
+		}
+	}
+
+	nearbyUserIDs, err := w.userRepo.GetUserIDsInRadius(ctx, payload.Latitude, payload.Longitude, newQuestionRadiusMeters)
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handleNewQuestion: %w", err)
+	}
+
+	var userIDs []string
+	for _, id := range nearbyUserIDs {
+		if id == payload.AuthorID {
+			continue // Don't notify the author
+		}
+		if followerIDs[id] {
+			continue // already notified as a follower
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	params := model.NotifyParams{
+		Type:  model.NotificationTypeNewQuestion,
+		Title: "New Question Nearby!",
+		Body:  fmt.Sprintf("New question: %s", payload.Title),
+		Data: map[string]any{
+			"questionId": payload.QuestionID.String(),
+			"type":       "new_question",
+		},
+		DedupeKey: outboxDedupeKey(msg, "nearby"),
+		ActorID:   &payload.AuthorID,
+	}
+	if msg.Type == model.OutboxMessageTypeQuestionReopened {
+		params.Type = model.NotificationTypeQuestionReopened
+		params.Title = "Question Reopened Nearby!"
+		params.Body = fmt.Sprintf("Reopened question: %s", payload.Title)
+		params.Data["type"] = "question_reopened"
+	}
+
+	err = w.inboxService.Notify(ctx, userIDs, params)
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handleNewQuestion: %w", err)
+	}
+
+	return nil
+}
+
+func (w *outboxWorker) handlePollClosed(ctx context.Context, msg model.OutboxMessage) error {
+	var payload model.PollClosedPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return fmt.Errorf("OutboxWorker::handlePollClosed: could not unmarshal payload: %w", err)
+	}
+
+	voterIDs, err := w.questionRepo.GetPollVoterIDs(ctx, payload.PollID)
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handlePollClosed: %w", err)
+	}
+
+	err = w.inboxService.Notify(ctx, voterIDs, model.NotifyParams{
+		Type:  model.NotificationTypePollClosed,
+		Title: "Poll Closed",
+		Body:  fmt.Sprintf("The results are in: %s", payload.Title),
+		Data: map[string]any{
+			"questionId": payload.QuestionID.String(),
+			"pollId":     payload.PollID.String(),
+			"type":       "poll_closed",
+		},
+		DedupeKey: outboxDedupeKey(msg, "voters"),
+	})
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handlePollClosed: %w", err)
+	}
+
+	return nil
+}
+
+func (w *outboxWorker) handleQuestionExpired(ctx context.Context, msg model.OutboxMessage) error {
+	var payload model.QuestionExpiredPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return fmt.Errorf("OutboxWorker::handleQuestionExpired: could not unmarshal payload: %w", err)
+	}
+
+	data := map[string]any{
+		"questionId": payload.QuestionID.String(),
+		"hasSummary": payload.HasSummary,
+		"type":       "question_expired",
+	}
+
+	// the author learns the outcome of their question
+	body := fmt.Sprintf("\"%s\" got %d response(s)", payload.Title, payload.NumResponses)
+	if payload.HasSummary {
+		body += ", see the summary"
+	}
+	err := w.inboxService.Notify(ctx, []string{payload.AuthorID}, model.NotifyParams{
+		Type:      model.NotificationTypeQuestionExpired,
+		Title:     "Your Question Has Expired",
+		Body:      body,
+		Data:      data,
+		DedupeKey: outboxDedupeKey(msg, "author"),
+	})
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handleQuestionExpired: %w", err)
+	}
+
+	// the responders learn that the question they helped with has closed
+	responderIDs, err := w.questionRepo.GetQuestionResponderIDs(ctx, payload.QuestionID)
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handleQuestionExpired: %w", err)
+	}
+
+	err = w.inboxService.Notify(ctx, responderIDs, model.NotifyParams{
+		Type:      model.NotificationTypeQuestionExpired,
+		Title:     "Question Closed",
+		Body:      fmt.Sprintf("A question you responded to has closed: %s", payload.Title),
+		Data:      data,
+		DedupeKey: outboxDedupeKey(msg, "responders"),
+		ActorID:   &payload.AuthorID,
+	})
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handleQuestionExpired: %w", err)
+	}
+
+	// the users that bookmarked the question learn that it has closed, unless they were already notified above
+	subscriberIDs, err := w.questionRepo.GetBookmarkSubscriberIDs(ctx, payload.QuestionID)
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handleQuestionExpired: %w", err)
+	}
+	notified := map[string]bool{payload.AuthorID: true}
+	for _, id := range responderIDs {
+		notified[id] = true
+	}
+	var bookmarkerIDs []string
+	for _, id := range subscriberIDs {
+		if !notified[id] {
+			bookmarkerIDs = append(bookmarkerIDs, id)
+		}
+	}
+
+	err = w.inboxService.Notify(ctx, bookmarkerIDs, model.NotifyParams{
+		Type:      model.NotificationTypeQuestionExpired,
+		Title:     "Saved Question Closed",
+		Body:      fmt.Sprintf("A question you saved has closed: %s", payload.Title),
+		Data:      data,
+		DedupeKey: outboxDedupeKey(msg, "bookmarkers"),
+		ActorID:   &payload.AuthorID,
+	})
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handleQuestionExpired: %w", err)
+	}
+
+	return nil
+}
+
+func (w *outboxWorker) handleMention(ctx context.Context, msg model.OutboxMessage) error {
+	var payload model.MentionPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return fmt.Errorf("OutboxWorker::handleMention: could not unmarshal payload: %w", err)
+	}
+
+	// the question may have been deleted meanwhile, then there is nothing to link to
+	question, err := w.questionRepo.GetQuestionByID(ctx, payload.AuthorID, payload.QuestionID)
+	if err != nil {
+		if errs.ErrType(err) == errs.TypeNotFound {
+			return nil
+		}
+		return fmt.Errorf("OutboxWorker::handleMention: %w", err)
+	}
+
+	author, err := w.userRepo.GetAuthUserByID(ctx, payload.AuthorID)
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handleMention: %w", err)
+	}
+
+	data := map[string]any{
+		"questionId": payload.QuestionID.String(),
+		"type":       "mention",
+	}
+	body := fmt.Sprintf("@%s mentioned you in: %s", author.Username, question.Title)
+	if payload.ResponseID != nil {
+		data["responseId"] = payload.ResponseID.String()
+		body = fmt.Sprintf("@%s mentioned you in a response to: %s", author.Username, question.Title)
+	}
+
+	err = w.inboxService.Notify(ctx, payload.UserIDs, model.NotifyParams{
+		Type:      model.NotificationTypeMention,
+		Title:     "You Were Mentioned",
+		Body:      body,
+		Data:      data,
+		DedupeKey: outboxDedupeKey(msg, "mentioned"),
+		ActorID:   &payload.AuthorID,
+	})
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handleMention: %w", err)
+	}
+
+	return nil
+}
+
+// outboxDedupeKey keeps a retried outbox message from adding the same notification to an inbox, or pushing it, twice.
+// A message that notifies several groups of users uses a key per group, so that each group is deduped on its own.
+func outboxDedupeKey(msg model.OutboxMessage, group string) *string {
+	key := fmt.Sprintf("outbox:%s:%s", msg.ID, group)
+	return &key
+}
+
+func (w *outboxWorker) handleNewResponse(ctx context.Context, msg model.OutboxMessage) error {
+	var payload model.NewResponsePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return fmt.Errorf("OutboxWorker::handleNewResponse: could not unmarshal payload: %w", err)
+	}
+
+	subscriberIDs, err := w.questionRepo.GetBookmarkSubscriberIDs(ctx, payload.QuestionID)
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handleNewResponse: %w", err)
+	}
+
+	var userIDs []string
+	for _, id := range subscriberIDs {
+		if id == payload.AuthorID {
+			continue // Don't notify the responder
+		}
+		userIDs = append(userIDs, id)
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	// the question may have been deleted meanwhile, then there is nothing to link to
+	question, err := w.questionRepo.GetQuestionByID(ctx, payload.AuthorID, payload.QuestionID)
+	if err != nil {
+		if errs.ErrType(err) == errs.TypeNotFound {
+			return nil
+		}
+		return fmt.Errorf("OutboxWorker::handleNewResponse: %w", err)
+	}
+
+	err = w.inboxService.Notify(ctx, userIDs, model.NotifyParams{
+		Type:  model.NotificationTypeBookmarkedQuestionResponse,
+		Title: "New Response",
+		Body:  fmt.Sprintf("A question you saved got a new response: %s", question.Title),
+		Data: map[string]any{
+			"questionId": payload.QuestionID.String(),
+			"responseId": payload.ResponseID.String(),
+			"type":       "bookmarked_question_response",
+		},
+		DedupeKey: outboxDedupeKey(msg, "bookmarkers"),
+		ActorID:   &payload.AuthorID,
+	})
+	if err != nil {
+		return fmt.Errorf("OutboxWorker::handleNewResponse: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+const (
+	pollCloserInterval  = 10 * time.Second
+	pollCloserBatchSize = 50
+	// voters are not notified about polls that closed long before they were finalized (e.g. during downtime)
+	pollClosedNotifyWindow = time.Hour
+)
+
+type pollCloser struct {
+	questionRepo   port.QuestionRepo
+	eventPublisher port.EventPublisher
+}
+
+func NewPollCloser(questionRepo port.QuestionRepo, eventPublisher port.EventPublisher) port.PollCloser {
+	return &pollCloser{
+		questionRepo:   questionRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+func (c *pollCloser) Run(ctx context.Context) error {
+	slog.Info("Starting poll closer...", "interval", pollCloserInterval.String())
+
+	ticker := time.NewTicker(pollCloserInterval)
+	defer ticker.Stop()
+
+	for {
+		// keep finalizing while full batches are due
+		for {
+			n, err := c.finalizeDuePolls(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to finalize due polls", "error", err)
+				break
+			}
+			if n < pollCloserBatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Shutting down poll closer...")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *pollCloser) finalizeDuePolls(ctx context.Context) (int, error) {
+	pollIDs, err := c.questionRepo.GetPollIDsToFinalize(ctx, pollCloserBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("PollCloser::finalizeDuePolls: %w", err)
+	}
+
+	finalized := 0
+	for _, pollID := range pollIDs {
+		if err := finalizePoll(ctx, c.questionRepo, c.eventPublisher, pollID); err != nil {
+			slog.ErrorContext(ctx, "Failed to finalize poll", "poll_id", pollID, "error", err)
+			continue
+		}
+		finalized++
+	}
+
+	// failed polls are not counted, so that a batch that keeps failing is not retried in a tight loop
+	return finalized, nil
+}
+
+// finalizePoll closes the poll (if it isn't already), snapshots its final results and lets its voters know.
+// It is safe to call more than once, and from several instances at the same time.
+func finalizePoll(ctx context.Context, questionRepo port.QuestionRepo, eventPublisher port.EventPublisher, pollID uuid.UUID) error {
+	if err := questionRepo.ClosePoll(ctx, pollID); err != nil {
+		return fmt.Errorf("finalizePoll: %w", err)
+	}
+
+	// fetched without a user, so the results don't contain anyone's selection
+	question, err := questionRepo.GetQuestionByPollID(ctx, "", pollID)
+	if err != nil {
+		return fmt.Errorf("finalizePoll: %w", err)
+	}
+	poll, ok := question.Content.Data.(model.Poll)
+	if !ok || poll.ClosedAt == nil {
+		return fmt.Errorf("finalizePoll: poll id %s could not be closed", pollID)
+	}
+
+	notifyVoters := time.Since(*poll.ClosedAt) < pollClosedNotifyWindow
+	if err := questionRepo.FinalizePoll(ctx, question, poll, notifyVoters); err != nil {
+		return fmt.Errorf("finalizePoll: %w", err)
+	}
+
+	publishEvent(ctx, eventPublisher, model.NewQuestionEvent(model.EventTypePollClosed, question, model.PollClosedEventData{
+		PollID:  poll.ID,
+		Results: poll.Results(),
+	}))
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+const questionExpiryPollInterval = 5 * time.Second
+
+type questionExpiryWatcher struct {
+	questionRepo   port.QuestionRepo
+	eventPublisher port.EventPublisher
+}
+
+func NewQuestionExpiryWatcher(questionRepo port.QuestionRepo, eventPublisher port.EventPublisher) port.QuestionExpiryWatcher {
+	return &questionExpiryWatcher{
+		questionRepo:   questionRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+func (w *questionExpiryWatcher) Run(ctx context.Context) error {
+	slog.Info("Starting question expiry watcher...")
+
+	ticker := time.NewTicker(questionExpiryPollInterval)
+	defer ticker.Stop()
+
+	lastCheck := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Shutting down question expiry watcher...")
+			return nil
+		case now := <-ticker.C:
+			if err := w.publishExpiries(ctx, lastCheck, now); err != nil {
+				slog.ErrorContext(ctx, "Failed to publish question expiries", "error", err)
+				continue // check the same window again on the next tick
+			}
+			lastCheck = now
+		}
+	}
+}
+
+func (w *questionExpiryWatcher) publishExpiries(ctx context.Context, expiredAfter, expiredUntil time.Time) error {
+	expiries, err := w.questionRepo.GetQuestionsExpiredBetween(ctx, expiredAfter, expiredUntil)
+	if err != nil {
+		return fmt.Errorf("QuestionExpiryWatcher::publishExpiries: %w", err)
+	}
+
+	for _, expiry := range expiries {
+		location := expiry.Location
+		// every API instance runs this watcher, so each one only notifies its own subscribers
+		w.eventPublisher.PublishLocal(model.Event{
+			Type:       model.EventTypeQuestionExpired,
+			QuestionID: expiry.QuestionID,
+			Location:   &location,
+			CreatedAt:  expiry.ExpiredAt,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+const (
+	questionLifecycleInterval  = 10 * time.Second
+	questionLifecycleBatchSize = 20
+	// the summary of a question is retried until this long after it expired, then the question expires without one
+	questionSummaryRetryWindow = 10 * time.Minute
+	// the author and responders are not notified about questions that expired long before they were processed (e.g. during downtime)
+	questionExpiredNotifyWindow = time.Hour
+	// the final summary covers this many responses
+	questionSummaryMaxResponses = 20
+)
+
+type questionLifecycleScheduler struct {
+	questionRepo   port.QuestionRepo
+	responseRepo   port.ResponseRepo
+	aiClient       port.AIClient
+	eventPublisher port.EventPublisher
+}
+
+func NewQuestionLifecycleScheduler(
+	questionRepo port.QuestionRepo,
+	responseRepo port.ResponseRepo,
+	aiClient port.AIClient,
+	eventPublisher port.EventPublisher,
+) port.QuestionLifecycleScheduler {
+	return &questionLifecycleScheduler{
+		questionRepo:   questionRepo,
+		responseRepo:   responseRepo,
+		aiClient:       aiClient,
+		eventPublisher: eventPublisher,
+	}
+}
+
+func (s *questionLifecycleScheduler) Run(ctx context.Context) error {
+	slog.Info("Starting question lifecycle scheduler...", "interval", questionLifecycleInterval.String())
+
+	ticker := time.NewTicker(questionLifecycleInterval)
+	defer ticker.Stop()
+
+	for {
+		// keep expiring while full batches are due
+		for {
+			n, err := s.expireDueQuestions(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to expire due questions", "error", err)
+				break
+			}
+			if n < questionLifecycleBatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Shutting down question lifecycle scheduler...")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *questionLifecycleScheduler) expireDueQuestions(ctx context.Context) (int, error) {
+	questionIDs, err := s.questionRepo.GetQuestionIDsToExpire(ctx, questionLifecycleBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("QuestionLifecycleScheduler::expireDueQuestions: %w", err)
+	}
+
+	expired := 0
+	for _, questionID := range questionIDs {
+		if err := s.expireQuestion(ctx, questionID); err != nil {
+			slog.ErrorContext(ctx, "Failed to expire question", "question_id", questionID, "error", err)
+			continue
+		}
+		expired++
+	}
+
+	// failed questions are not counted, so that a batch that keeps failing is not retried in a tight loop
+	return expired, nil
+}
+
+// expireQuestion stores the final summary of the question, closes its poll and lets its author and responders know.
+// It is safe to call more than once, and from several instances at the same time.
+func (s *questionLifecycleScheduler) expireQuestion(ctx context.Context, questionID uuid.UUID) error {
+	// fetched without a user, so the question doesn't contain anyone's answers
+	question, err := s.questionRepo.GetQuestionByID(ctx, "", questionID)
+	if err != nil {
+		return fmt.Errorf("expireQuestion: %w", err)
+	}
+
+	// the poll closer may not have gotten to the poll yet
+	if poll, ok := question.Content.Data.(model.Poll); ok && poll.FinalResults == nil {
+		if err := finalizePoll(ctx, s.questionRepo, s.eventPublisher, poll.ID); err != nil {
+			return fmt.Errorf("expireQuestion: %w", err)
+		}
+	}
+
+	summary, err := s.summarize(ctx, question)
+	if err != nil {
+		if time.Since(question.ExpiredAt) < questionSummaryRetryWindow {
+			return fmt.Errorf("expireQuestion: %w", err)
+		}
+		slog.WarnContext(ctx, "Expiring question without a summary", "question_id", questionID, "error", err)
+	}
+
+	notify := time.Since(question.ExpiredAt) < questionExpiredNotifyWindow
+	if err := s.questionRepo.ExpireQuestion(ctx, question, summary, notify); err != nil {
+		return fmt.Errorf("expireQuestion: %w", err)
+	}
+
+	return nil
+}
+
+// summarize returns the AI summary of the question's responses, or nil if there are none
+func (s *questionLifecycleScheduler) summarize(ctx context.Context, question model.Question) (*string, error) {
+	if question.ResponsesAmount == 0 {
+		return nil, nil
+	}
+
+	responses, err := s.responseRepo.GetResponsesByQuestionID(ctx, "", question.ID, model.PageParams{
+		Limit:  questionSummaryMaxResponses,
+		Offset: 0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("summarize: %w", err)
+	}
+	if len(responses) == 0 {
+		return nil, nil
+	}
+
+	prompt, err := buildSummaryPrompt(responses)
+	if err != nil {
+		return nil, fmt.Errorf("summarize: %w", err)
+	}
+
+	output, err := s.aiClient.Prompt(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("summarize: prompt error: %w", err)
+	}
+
+	return &output, nil
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ksha23/CS407-FactSnap/internal/config"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/errs"
+)
+
+const (
+	defaultMaxQuestionExtension  = time.Hour
+	defaultMaxQuestionExtensions = 3
+	defaultMaxQuestionLifetime   = 24 * time.Hour
+)
+
+// defaultQuestionDurations are used for categories missing from the config
+var defaultQuestionDurations = map[model.Category]model.QuestionDurationLimits{
+	model.CategoryRestaurant:     {Min: 15 * time.Minute, Max: 3 * time.Hour, Default: time.Hour},
+	model.CategoryStore:          {Min: 15 * time.Minute, Max: 3 * time.Hour, Default: time.Hour},
+	model.CategoryTransportation: {Min: 5 * time.Minute, Max: 2 * time.Hour, Default: 30 * time.Minute},
+	model.CategoryEvent:          {Min: 30 * time.Minute, Max: 24 * time.Hour, Default: 3 * time.Hour},
+	model.CategoryGeneral:        {Min: 15 * time.Minute, Max: time.Hour, Default: 30 * time.Minute},
+}
+
+// questionPolicy limits how long questions stay open
+type questionPolicy struct {
+	durations map[model.Category]model.QuestionDurationLimits
+	// maxExtension is how far a single extension (or reopening) may push a question's expiry out
+	maxExtension  time.Duration
+	maxExtensions int
+	// maxLifetime is the max time between creating (or reopening) a question and its expiry
+	maxLifetime time.Duration
+}
+
+func newQuestionPolicy(cfg config.QuestionPolicy) (questionPolicy, error) {
+	policy := questionPolicy{
+		durations:     make(map[model.Category]model.QuestionDurationLimits, len(defaultQuestionDurations)),
+		maxExtension:  defaultMaxQuestionExtension,
+		maxExtensions: defaultMaxQuestionExtensions,
+		maxLifetime:   defaultMaxQuestionLifetime,
+	}
+
+	if cfg.MaxExtension != "" {
+		maxExtension, err := time.ParseDuration(cfg.MaxExtension)
+		if err != nil {
+			return questionPolicy{}, fmt.Errorf("invalid question policy max extension: %w", err)
+		}
+		policy.maxExtension = maxExtension
+	}
+	if cfg.MaxExtensions > 0 {
+		policy.maxExtensions = cfg.MaxExtensions
+	}
+	if cfg.MaxLifetime != "" {
+		maxLifetime, err := time.ParseDuration(cfg.MaxLifetime)
+		if err != nil {
+			return questionPolicy{}, fmt.Errorf("invalid question policy max lifetime: %w", err)
+		}
+		policy.maxLifetime = maxLifetime
+	}
+
+	for category, limits := range defaultQuestionDurations {
+		limits.Category = category
+		policy.durations[category] = limits
+	}
+	for key, durationsCfg := range cfg.Durations {
+		category, err := model.ParseCategory(key)
+		if err != nil {
+			return questionPolicy{}, fmt.Errorf("invalid question policy durations: %w", err)
+		}
+
+		// unset durations fall back to the category's defaults
+		limits := policy.durations[category]
+		for _, d := range []struct {
+			value  string
+			target *time.Duration
+		}{
+			{durationsCfg.Min, &limits.Min},
+			{durationsCfg.Max, &limits.Max},
+			{durationsCfg.Default, &limits.Default},
+		} {
+			if d.value == "" {
+				continue
+			}
+			duration, err := time.ParseDuration(d.value)
+			if err != nil {
+				return questionPolicy{}, fmt.Errorf("invalid question policy durations for %s: %w", category, err)
+			}
+			*d.target = duration
+		}
+		policy.durations[category] = limits
+	}
+
+	for category, limits := range policy.durations {
+		if limits.Min <= 0 || limits.Min > limits.Default || limits.Default > limits.Max {
+			return questionPolicy{}, fmt.Errorf("invalid question policy durations for %s: must satisfy 0 < min <= default <= max", category)
+		}
+		if limits.Max > policy.maxLifetime {
+			return questionPolicy{}, fmt.Errorf("invalid question policy durations for %s: max exceeds the max lifetime", category)
+		}
+	}
+
+	return policy, nil
+}
+
+// resolveDuration returns how long a new question of the category stays open, using the category's default if duration is nil
+func (p questionPolicy) resolveDuration(category model.Category, duration *time.Duration) (time.Duration, error) {
+	limits, ok := p.durations[category]
+	if !ok {
+		return 0, errs.BadRequestError("Invalid category", fmt.Errorf("no question durations for category %s", category))
+	}
+
+	if duration == nil {
+		return limits.Default, nil
+	}
+	if *duration < limits.Min || *duration > limits.Max {
+		msg := fmt.Sprintf("%s questions must be open between %s and %s", category, limits.Min, limits.Max)
+		return 0, errs.BadRequestError(msg, fmt.Errorf("duration %s is out of range", *duration))
+	}
+	return *duration, nil
+}
+
+func (p questionPolicy) toModel() model.QuestionPolicy {
+	durations := make([]model.QuestionDurationLimits, 0, len(p.durations))
+	for _, limits := range p.durations {
+		durations = append(durations, limits)
+	}
+	// keep the order stable for clients
+	slices.SortFunc(durations, func(a, b model.QuestionDurationLimits) int {
+		return strings.Compare(string(a.Category), string(b.Category))
+	})
+
+	return model.QuestionPolicy{
+		Durations:     durations,
+		MaxExtension:  p.maxExtension,
+		MaxExtensions: p.maxExtensions,
+		MaxLifetime:   p.maxLifetime,
+	}
+}
@@ -4,76 +4,83 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/config"
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 	"github.com/ksha23/CS407-FactSnap/internal/core/port"
 	"github.com/ksha23/CS407-FactSnap/internal/errs"
+	"github.com/ksha23/CS407-FactSnap/internal/ptr"
 )
 
 type questionService struct {
-	questionRepo        port.QuestionRepo
-	mediaService        port.MediaService
-	notificationService port.NotificationService
-	userRepo            port.UserRepository
+	questionRepo   port.QuestionRepo
+	mediaService   port.MediaService
+	eventPublisher port.EventPublisher
+	policy         questionPolicy
+	// deleteGracePeriod is how long deleted questions can be restored
+	deleteGracePeriod time.Duration
 }
 
-func NewQuestionService(questionRepo port.QuestionRepo, mediaService port.MediaService, notificationService port.NotificationService, userRepo port.UserRepository) *questionService {
-	return &questionService{
-		questionRepo:        questionRepo,
-		mediaService:        mediaService,
-		notificationService: notificationService,
-		userRepo:            userRepo,
+func NewQuestionService(
+	questionRepo port.QuestionRepo,
+	mediaService port.MediaService,
+	eventPublisher port.EventPublisher,
+	policyCfg config.QuestionPolicy,
+	softDeleteCfg config.SoftDelete,
+) (*questionService, error) {
+	policy, err := newQuestionPolicy(policyCfg)
+	if err != nil {
+		return nil, err
+	}
+	deleteGracePeriod, err := parseSoftDeleteGracePeriod(softDeleteCfg)
+	if err != nil {
+		return nil, err
 	}
+
+	return &questionService{
+		questionRepo:      questionRepo,
+		mediaService:      mediaService,
+		eventPublisher:    eventPublisher,
+		policy:            policy,
+		deleteGracePeriod: deleteGracePeriod,
+	}, nil
 }
 
 func (s *questionService) CreateQuestion(ctx context.Context, userID string, params model.CreateQuestionParams) (uuid.UUID, error) {
-	questionID, err := s.questionRepo.CreateQuestion(ctx, userID, params)
+	// the question's category decides how long it may stay open
+	duration, err := s.policy.resolveDuration(params.Category, params.Duration)
 	if err != nil {
 		return uuid.UUID{}, fmt.Errorf("QuestionService::CreateQuestion: %w", err)
 	}
 
-	// Send notifications asynchronously
-	go func() {
-		// Create a new context for the background task
-		bgCtx := context.Background()
-
-		// Find users in radius (10 miles ~ 16093 meters)
-		radiusMeters := 16093.0
-		users, err := s.userRepo.GetUsersInRadius(bgCtx, params.Location.Latitude, params.Location.Longitude, radiusMeters)
-		if err != nil {
-			slog.Error("Failed to get users for notification", "error", err)
-			return
-		}
-
-		var tokens []string
-		for _, u := range users {
-			if u.ID == userID {
-				continue // Don't notify the author
-			}
-			if u.ExpoPushToken != nil {
-				tokens = append(tokens, *u.ExpoPushToken)
-			}
-		}
-
-		if len(tokens) > 0 {
-			err := s.notificationService.SendPushNotification(bgCtx, tokens, "New Question Nearby!", fmt.Sprintf("New question: %s", params.Title), map[string]interface{}{
-				"questionId": questionID.String(),
-				"type":       "new_question",
-			})
-			if err != nil {
-				slog.Error("Failed to send push notifications", "error", err)
-			}
-		}
-	}()
+	questionID, err := s.questionRepo.CreateQuestion(ctx, userID, params, time.Now().Add(duration))
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionService::CreateQuestion: %w", err)
+	}
 
 	return questionID, nil
 }
 
+func (s *questionService) GetQuestionPolicy(ctx context.Context) model.QuestionPolicy {
+	return s.policy.toModel()
+}
+
 func (s *questionService) CreatePoll(ctx context.Context, userID string, params model.CreatePollParams) (uuid.UUID, error) {
 	// check if user is authorized to create a poll for this question
-	if _, err := s.authorizeUser(ctx, userID, params.QuestionID, false); err != nil {
+	question, err := s.authorizeUser(ctx, userID, params.QuestionID, false)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionService::CreatePoll: %w", err)
+	}
+
+	if err := ensureNoContent(question); err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionService::CreatePoll: %w", err)
+	}
+
+	// the poll may close early, but not outlive its question
+	if err := validatePollClosesAt(question, params.ClosesAt); err != nil {
 		return uuid.UUID{}, fmt.Errorf("QuestionService::CreatePoll: %w", err)
 	}
 
@@ -86,7 +93,9 @@ func (s *questionService) CreatePoll(ctx context.Context, userID string, params
 	return pollID, nil
 }
 
-func (s *questionService) VotePoll(ctx context.Context, userID string, pollID uuid.UUID, optionID *uuid.UUID) error {
+func (s *questionService) VotePoll(ctx context.Context, userID string, params model.VotePollParams) error {
+	pollID := params.PollID
+
 	// first ensure that the poll hasn't expired yet
 	isExpired, err := s.questionRepo.IsPollExpired(ctx, pollID)
 	if err != nil {
@@ -97,12 +106,441 @@ func (s *questionService) VotePoll(ctx context.Context, userID string, pollID uu
 		return fmt.Errorf("QuestionService::VotePoll: %w", err)
 	}
 
+	// then ensure that the vote fits the type of the poll
+	poll, err := s.GetPollTally(ctx, userID, pollID)
+	if err != nil {
+		return fmt.Errorf("QuestionService::VotePoll: %w", err)
+	}
+	if err := validatePollVote(poll, params); err != nil {
+		return fmt.Errorf("QuestionService::VotePoll: %w", err)
+	}
+
 	// place new poll vote
-	err = s.questionRepo.VotePoll(ctx, userID, pollID, optionID)
+	err = s.questionRepo.VotePoll(ctx, userID, poll.Type, params)
 	if err != nil {
 		return fmt.Errorf("QuestionService::VotePoll: %w", err)
 	}
 
+	s.publishPollTally(ctx, userID, pollID)
+
+	return nil
+}
+
+func (s *questionService) GetPollTally(ctx context.Context, userID string, pollID uuid.UUID) (model.Poll, error) {
+	question, err := s.questionRepo.GetQuestionByPollID(ctx, userID, pollID)
+	if err != nil {
+		return model.Poll{}, fmt.Errorf("QuestionService::GetPollTally: %w", err)
+	}
+
+	poll, ok := question.Content.Data.(model.Poll)
+	if !ok {
+		err := fmt.Errorf("question id %s does not have a poll", question.ID)
+		return model.Poll{}, fmt.Errorf("QuestionService::GetPollTally: %w", err)
+	}
+
+	return poll, nil
+}
+
+func (s *questionService) GetPollOptionVoters(ctx context.Context, userID string, pollID, optionID uuid.UUID, page model.PageParams) ([]model.PollVoter, error) {
+	poll, err := s.GetPollTally(ctx, userID, pollID)
+	if err != nil {
+		return nil, fmt.Errorf("QuestionService::GetPollOptionVoters: %w", err)
+	}
+
+	// voters are only listed for public polls whose results the user may already see
+	if poll.IsAnonymous {
+		err := errs.UnauthorizedError("The voters of this poll are anonymous", fmt.Errorf("poll id %s is anonymous", pollID))
+		return nil, fmt.Errorf("QuestionService::GetPollOptionVoters: %w", err)
+	}
+	if poll.ResultsHidden {
+		err := errs.UnauthorizedError("The results of this poll are hidden", fmt.Errorf("results of poll id %s are hidden from user id %s", pollID, userID))
+		return nil, fmt.Errorf("QuestionService::GetPollOptionVoters: %w", err)
+	}
+
+	isPollOption := false
+	for _, option := range poll.Options {
+		if option.ID == optionID {
+			isPollOption = true
+			break
+		}
+	}
+	if !isPollOption {
+		err := errs.BadRequestError("This option does not belong to the poll", fmt.Errorf("option id %s is not part of poll id %s", optionID, pollID))
+		return nil, fmt.Errorf("QuestionService::GetPollOptionVoters: %w", err)
+	}
+
+	voters, err := s.questionRepo.GetPollOptionVoters(ctx, optionID, page)
+	if err != nil {
+		return nil, fmt.Errorf("QuestionService::GetPollOptionVoters: %w", err)
+	}
+	return voters, nil
+}
+
+func (s *questionService) EditPoll(ctx context.Context, userID string, params model.EditPollParams) (model.Poll, error) {
+	// check if user is authorized to edit the poll of this question
+	question, err := s.questionRepo.GetQuestionByPollID(ctx, userID, params.PollID)
+	if err != nil {
+		return model.Poll{}, fmt.Errorf("QuestionService::EditPoll: %w", err)
+	}
+	question, err = s.authorizeUser(ctx, userID, question.ID, false)
+	if err != nil {
+		return model.Poll{}, fmt.Errorf("QuestionService::EditPoll: %w", err)
+	}
+	poll, ok := question.Content.Data.(model.Poll)
+	if !ok {
+		err := fmt.Errorf("question id %s does not have a poll", question.ID)
+		return model.Poll{}, fmt.Errorf("QuestionService::EditPoll: %w", err)
+	}
+	if poll.IsClosed() {
+		err := errs.UnauthorizedError("This poll has closed", fmt.Errorf("poll id %s has closed", poll.ID))
+		return model.Poll{}, fmt.Errorf("QuestionService::EditPoll: %w", err)
+	}
+
+	// closes_at is kept unless it is changed or cleared
+	if params.ClearClosesAt {
+		if params.ClosesAt != nil {
+			err := errs.BadRequestError("A closing time cannot be set and cleared at once", fmt.Errorf("poll id %s got both closes at and clear closes at", poll.ID))
+			return model.Poll{}, fmt.Errorf("QuestionService::EditPoll: %w", err)
+		}
+	} else if params.ClosesAt == nil {
+		params.ClosesAt = poll.ClosesAt
+	} else if err := validatePollClosesAt(question, params.ClosesAt); err != nil {
+		return model.Poll{}, fmt.Errorf("QuestionService::EditPoll: %w", err)
+	}
+
+	// the max selections must still fit the number of options
+	numOptions := len(poll.Options)
+	if params.OptionLabels != nil {
+		numOptions = len(params.OptionLabels)
+	}
+	maxSelections := model.MaxPollSelections(poll.Type, numOptions, poll.AllowOther)
+	if params.MaxSelections == nil {
+		// a poll that allowed picking every option keeps doing so
+		prevMaxSelections := model.MaxPollSelections(poll.Type, len(poll.Options), poll.AllowOther)
+		if poll.MaxSelections >= prevMaxSelections {
+			params.MaxSelections = &maxSelections
+		} else {
+			params.MaxSelections = ptr.To(min(poll.MaxSelections, maxSelections))
+		}
+	} else if *params.MaxSelections < 1 || *params.MaxSelections > maxSelections {
+		msg := fmt.Sprintf("Max selections must be between 1 and %d", maxSelections)
+		err := errs.BadRequestError(msg, fmt.Errorf("max selections %d of poll id %s is out of range", *params.MaxSelections, poll.ID))
+		return model.Poll{}, fmt.Errorf("QuestionService::EditPoll: %w", err)
+	}
+
+	if err := s.questionRepo.EditPoll(ctx, params); err != nil {
+		return model.Poll{}, fmt.Errorf("QuestionService::EditPoll: %w", err)
+	}
+
+	editedPoll, err := s.GetPollTally(ctx, userID, params.PollID)
+	if err != nil {
+		return model.Poll{}, fmt.Errorf("QuestionService::EditPoll: %w", err)
+	}
+	s.publishPollTally(ctx, userID, params.PollID)
+
+	return editedPoll, nil
+}
+
+func (s *questionService) ClosePoll(ctx context.Context, userID string, pollID uuid.UUID) (model.Poll, error) {
+	// check if user is authorized to close the poll of this question
+	question, err := s.questionRepo.GetQuestionByPollID(ctx, userID, pollID)
+	if err != nil {
+		return model.Poll{}, fmt.Errorf("QuestionService::ClosePoll: %w", err)
+	}
+	if _, err := s.authorizeUser(ctx, userID, question.ID, false); err != nil {
+		return model.Poll{}, fmt.Errorf("QuestionService::ClosePoll: %w", err)
+	}
+	if poll, ok := question.Content.Data.(model.Poll); ok && poll.IsClosed() {
+		err := errs.UnauthorizedError("This poll has already closed", fmt.Errorf("poll id %s has already closed", pollID))
+		return model.Poll{}, fmt.Errorf("QuestionService::ClosePoll: %w", err)
+	}
+
+	if err := finalizePoll(ctx, s.questionRepo, s.eventPublisher, pollID); err != nil {
+		return model.Poll{}, fmt.Errorf("QuestionService::ClosePoll: %w", err)
+	}
+
+	poll, err := s.GetPollTally(ctx, userID, pollID)
+	if err != nil {
+		return model.Poll{}, fmt.Errorf("QuestionService::ClosePoll: %w", err)
+	}
+	return poll, nil
+}
+
+func (s *questionService) CreateRating(ctx context.Context, userID string, params model.CreateRatingParams) (uuid.UUID, error) {
+	// check if user is authorized to create a rating for this question
+	question, err := s.authorizeUser(ctx, userID, params.QuestionID, false)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionService::CreateRating: %w", err)
+	}
+	if err := ensureNoContent(question); err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionService::CreateRating: %w", err)
+	}
+
+	ratingID, err := s.questionRepo.CreateRating(ctx, userID, params)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionService::CreateRating: %w", err)
+	}
+
+	return ratingID, nil
+}
+
+func (s *questionService) AnswerRating(ctx context.Context, userID string, params model.AnswerRatingParams) (model.Rating, error) {
+	question, err := s.questionRepo.GetQuestionByRatingID(ctx, userID, params.RatingID)
+	if err != nil {
+		return model.Rating{}, fmt.Errorf("QuestionService::AnswerRating: %w", err)
+	}
+	rating, ok := question.Content.Data.(model.Rating)
+	if !ok {
+		err := fmt.Errorf("question id %s does not have a rating", question.ID)
+		return model.Rating{}, fmt.Errorf("QuestionService::AnswerRating: %w", err)
+	}
+
+	// ensure that the rating hasn't expired yet and the value is on its scale
+	if time.Now().After(question.ExpiredAt) {
+		err := errs.UnauthorizedError("This rating has expired", fmt.Errorf("rating id %s has expired", rating.ID))
+		return model.Rating{}, fmt.Errorf("QuestionService::AnswerRating: %w", err)
+	}
+	if params.Value != nil && (*params.Value < rating.MinValue || *params.Value > rating.MaxValue) {
+		msg := fmt.Sprintf("The value must be between %d and %d", rating.MinValue, rating.MaxValue)
+		err := errs.BadRequestError(msg, fmt.Errorf("value %d is not on the scale of rating id %s", *params.Value, rating.ID))
+		return model.Rating{}, fmt.Errorf("QuestionService::AnswerRating: %w", err)
+	}
+
+	if err := s.questionRepo.AnswerRating(ctx, userID, params); err != nil {
+		return model.Rating{}, fmt.Errorf("QuestionService::AnswerRating: %w", err)
+	}
+
+	question, err = s.questionRepo.GetQuestionByID(ctx, userID, question.ID)
+	if err != nil {
+		return model.Rating{}, fmt.Errorf("QuestionService::AnswerRating: %w", err)
+	}
+	rating, _ = question.Content.Data.(model.Rating)
+
+	return rating, nil
+}
+
+func (s *questionService) CreateConfirm(ctx context.Context, userID string, params model.CreateConfirmParams) (uuid.UUID, error) {
+	// check if user is authorized to create a confirm for this question
+	question, err := s.authorizeUser(ctx, userID, params.QuestionID, false)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionService::CreateConfirm: %w", err)
+	}
+	if err := ensureNoContent(question); err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionService::CreateConfirm: %w", err)
+	}
+
+	confirmID, err := s.questionRepo.CreateConfirm(ctx, userID, params)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("QuestionService::CreateConfirm: %w", err)
+	}
+
+	return confirmID, nil
+}
+
+func (s *questionService) AnswerConfirm(ctx context.Context, userID string, params model.AnswerConfirmParams) (model.Confirm, error) {
+	question, err := s.questionRepo.GetQuestionByConfirmID(ctx, userID, params.ConfirmID)
+	if err != nil {
+		return model.Confirm{}, fmt.Errorf("QuestionService::AnswerConfirm: %w", err)
+	}
+
+	// ensure that the confirm hasn't expired yet
+	if time.Now().After(question.ExpiredAt) {
+		err := errs.UnauthorizedError("This question has expired", fmt.Errorf("confirm id %s has expired", params.ConfirmID))
+		return model.Confirm{}, fmt.Errorf("QuestionService::AnswerConfirm: %w", err)
+	}
+
+	if err := s.questionRepo.AnswerConfirm(ctx, userID, params); err != nil {
+		return model.Confirm{}, fmt.Errorf("QuestionService::AnswerConfirm: %w", err)
+	}
+
+	question, err = s.questionRepo.GetQuestionByID(ctx, userID, question.ID)
+	if err != nil {
+		return model.Confirm{}, fmt.Errorf("QuestionService::AnswerConfirm: %w", err)
+	}
+	confirm, ok := question.Content.Data.(model.Confirm)
+	if !ok {
+		err := fmt.Errorf("question id %s does not have a confirm", question.ID)
+		return model.Confirm{}, fmt.Errorf("QuestionService::AnswerConfirm: %w", err)
+	}
+
+	return confirm, nil
+}
+
+func (s *questionService) ExtendQuestion(ctx context.Context, userID string, questionID uuid.UUID, duration time.Duration) (model.Question, error) {
+	// check if user is authorized to extend this question, which must still be open
+	question, err := s.authorizeUser(ctx, userID, questionID, false)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionService::ExtendQuestion: %w", err)
+	}
+
+	if question.NumExtensions >= s.policy.maxExtensions {
+		msg := fmt.Sprintf("A question can only be extended %d time(s)", s.policy.maxExtensions)
+		err := errs.UnauthorizedError(msg, fmt.Errorf("question id %s was already extended %d times", questionID, question.NumExtensions))
+		return model.Question{}, fmt.Errorf("QuestionService::ExtendQuestion: %w", err)
+	}
+
+	expiresAt := question.ExpiredAt.Add(duration)
+	if err := s.validateOpenDuration(question, duration, expiresAt); err != nil {
+		return model.Question{}, fmt.Errorf("QuestionService::ExtendQuestion: %w", err)
+	}
+
+	// the limits are checked again by the update, in case of concurrent extensions
+	if err := s.questionRepo.ExtendQuestion(ctx, questionID, duration, s.policy.maxExtensions, s.policy.maxLifetime); err != nil {
+		return model.Question{}, fmt.Errorf("QuestionService::ExtendQuestion: %w", err)
+	}
+
+	extendedQuestion, err := s.questionRepo.GetQuestionByID(ctx, userID, questionID)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionService::ExtendQuestion: %w", err)
+	}
+	return extendedQuestion, nil
+}
+
+func (s *questionService) ReopenQuestion(ctx context.Context, userID string, questionID uuid.UUID, duration time.Duration) (model.Question, error) {
+	// check if user is authorized to reopen this question
+	question, err := s.authorizeUser(ctx, userID, questionID, true)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionService::ReopenQuestion: %w", err)
+	}
+
+	if time.Now().Before(question.ExpiredAt) {
+		err := errs.BadRequestError("Only expired questions can be reopened", fmt.Errorf("question id %s has not expired yet", questionID))
+		return model.Question{}, fmt.Errorf("QuestionService::ReopenQuestion: %w", err)
+	}
+	if question.ReopenedAt != nil {
+		err := errs.UnauthorizedError("A question can only be reopened once", fmt.Errorf("question id %s was already reopened", questionID))
+		return model.Question{}, fmt.Errorf("QuestionService::ReopenQuestion: %w", err)
+	}
+	if question.Status == model.QuestionStatusArchived {
+		err := errs.UnauthorizedError("Archived questions cannot be reopened", fmt.Errorf("question id %s is archived", questionID))
+		return model.Question{}, fmt.Errorf("QuestionService::ReopenQuestion: %w", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(duration)
+	// the lifetime of a reopened question starts over
+	question.ReopenedAt = &now
+	if err := s.validateOpenDuration(question, duration, expiresAt); err != nil {
+		return model.Question{}, fmt.Errorf("QuestionService::ReopenQuestion: %w", err)
+	}
+
+	// the question is checked again by the update, in case of concurrent requests
+	if err := s.questionRepo.ReopenQuestion(ctx, question, duration); err != nil {
+		return model.Question{}, fmt.Errorf("QuestionService::ReopenQuestion: %w", err)
+	}
+
+	reopenedQuestion, err := s.questionRepo.GetQuestionByID(ctx, userID, questionID)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionService::ReopenQuestion: %w", err)
+	}
+	return reopenedQuestion, nil
+}
+
+// validateOpenDuration ensures that extending or reopening a question stays within the question policy
+func (s *questionService) validateOpenDuration(question model.Question, duration time.Duration, expiresAt time.Time) error {
+	if duration <= 0 || duration > s.policy.maxExtension {
+		msg := fmt.Sprintf("The duration must be between 0 and %s", s.policy.maxExtension)
+		return errs.BadRequestError(msg, fmt.Errorf("duration %s is out of range", duration))
+	}
+
+	openedAt := question.CreatedAt
+	if question.ReopenedAt != nil {
+		openedAt = *question.ReopenedAt
+	}
+	if expiresAt.Sub(openedAt) > s.policy.maxLifetime {
+		msg := fmt.Sprintf("A question cannot stay open for more than %s", s.policy.maxLifetime)
+		return errs.BadRequestError(msg, fmt.Errorf("question id %s would stay open until %s", question.ID, expiresAt))
+	}
+
+	return nil
+}
+
+// ensureNoContent ensures that a question doesn't have a poll, rating or confirm yet
+func ensureNoContent(question model.Question) error {
+	if question.Content.Type != model.ContentTypeNone {
+		msg := fmt.Sprintf("This question already has a %s", strings.ToLower(string(question.Content.Type)))
+		return errs.UnauthorizedError(msg, fmt.Errorf("question id %s already has content of type %s", question.ID, question.Content.Type))
+	}
+	return nil
+}
+
+// validatePollClosesAt ensures that a poll closes in the future, but not after its question expires
+func validatePollClosesAt(question model.Question, closesAt *time.Time) error {
+	if closesAt == nil {
+		return nil
+	}
+	if !closesAt.After(time.Now()) {
+		return errs.BadRequestError("A poll must close in the future", fmt.Errorf("closes at %s is in the past", closesAt))
+	}
+	if closesAt.After(question.ExpiredAt) {
+		return errs.BadRequestError("A poll cannot close after its question expires", fmt.Errorf("closes at %s is after question id %s expires", closesAt, question.ID))
+	}
+	return nil
+}
+
+// publishPollTally broadcasts the new vote counts of the poll to its realtime subscribers
+func (s *questionService) publishPollTally(ctx context.Context, userID string, pollID uuid.UUID) {
+	question, err := s.questionRepo.GetQuestionByPollID(ctx, userID, pollID)
+	if err != nil {
+		slog.ErrorContext(ctx, "QuestionService::publishPollTally: could not fetch question", "error", err, "poll_id", pollID)
+		return
+	}
+	poll, ok := question.Content.Data.(model.Poll)
+	if !ok {
+		return
+	}
+
+	// the results may be hidden from some subscribers, so only let them know to re-fetch the poll
+	if poll.ResultsVisibility != model.PollResultsVisibilityAlways {
+		event := model.NewQuestionEvent(model.EventTypePollTallyUpdated, question, nil)
+		event.Truncated = true
+		publishEvent(ctx, s.eventPublisher, event)
+		return
+	}
+
+	// the tally is broadcast to everyone, so it must not contain the voter's selection
+	results := poll.Results()
+	publishEvent(ctx, s.eventPublisher, model.NewQuestionEvent(model.EventTypePollTallyUpdated, question, model.PollTallyEventData{
+		PollID:        poll.ID,
+		Options:       results.Options,
+		OtherVotes:    results.OtherVotes,
+		NumTotalVotes: results.NumTotalVotes,
+		NumVoters:     results.NumVoters,
+		Runoff:        results.Runoff,
+	}))
+}
+
+// validatePollVote checks the picked options against the type and settings of the poll
+func validatePollVote(poll model.Poll, params model.VotePollParams) error {
+	pollOptionIDs := make(map[uuid.UUID]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		pollOptionIDs[option.ID] = true
+	}
+
+	picked := make(map[uuid.UUID]bool, len(params.OptionIDs))
+	for _, optionID := range params.OptionIDs {
+		if !pollOptionIDs[optionID] {
+			return errs.BadRequestError("This option does not belong to the poll", fmt.Errorf("option id %s is not part of poll id %s", optionID, poll.ID))
+		}
+		if picked[optionID] {
+			return errs.BadRequestError("An option can only be picked once", fmt.Errorf("option id %s was picked more than once", optionID))
+		}
+		picked[optionID] = true
+	}
+
+	if params.OtherText != nil && !poll.AllowOther {
+		return errs.BadRequestError("This poll does not allow other answers", fmt.Errorf("poll id %s does not allow other answers", poll.ID))
+	}
+
+	numSelections := len(params.OptionIDs)
+	if params.OtherText != nil {
+		numSelections++
+	}
+	if numSelections > poll.MaxSelections {
+		msg := fmt.Sprintf("You can pick at most %d option(s) in this poll", poll.MaxSelections)
+		return errs.BadRequestError(msg, fmt.Errorf("%d selections exceed the max of %d in poll id %s", numSelections, poll.MaxSelections, poll.ID))
+	}
+
 	return nil
 }
 
@@ -114,6 +552,19 @@ func (s *questionService) GetQuestionByID(ctx context.Context, userID string, qu
 	return question, err
 }
 
+func (s *questionService) GetQuestionRevisions(ctx context.Context, userID string, questionID uuid.UUID, page model.PageParams) ([]model.QuestionRevision, error) {
+	// make sure the question exists and is not deleted
+	if _, err := s.questionRepo.GetQuestionByID(ctx, userID, questionID); err != nil {
+		return nil, fmt.Errorf("QuestionService::GetQuestionRevisions: %w", err)
+	}
+
+	revisions, err := s.questionRepo.GetQuestionRevisions(ctx, questionID, page)
+	if err != nil {
+		return nil, fmt.Errorf("QuestionService::GetQuestionRevisions: %w", err)
+	}
+	return revisions, nil
+}
+
 func (s *questionService) GetQuestionsInRadiusFeed(
 	ctx context.Context,
 	userID string,
@@ -129,15 +580,28 @@ func (s *questionService) GetQuestionsInRadiusFeed(
 
 func (s *questionService) EditQuestion(ctx context.Context, userID string, params model.EditQuestionParams) (model.Question, error) {
 	// check if user is authorized to edit this question
-	if _, err := s.authorizeUser(ctx, userID, params.QuestionID, false); err != nil {
+	question, err := s.authorizeUser(ctx, userID, params.QuestionID, false)
+	if err != nil {
 		return model.Question{}, fmt.Errorf("QuestionService::EditQuestion: %w", err)
 	}
 
-	editedQuestion, err := s.questionRepo.EditQuestion(ctx, userID, params)
+	// only images uploaded by the user can be attached
+	if params.ImageURLs != nil {
+		addedImageURLs, _ := diffMediaURLs(question.ImageURLs, params.ImageURLs)
+		if err := s.mediaService.ValidateOwnership(ctx, userID, addedImageURLs); err != nil {
+			return model.Question{}, fmt.Errorf("QuestionService::EditQuestion: %w", err)
+		}
+	}
+
+	editedQuestion, previousImageURLs, err := s.questionRepo.EditQuestion(ctx, userID, params)
 	if err != nil {
 		return model.Question{}, fmt.Errorf("QuestionService::EditQuestion: %w", err)
 	}
 
+	// the edit is committed, so the images that were removed can be deleted
+	_, removedImageURLs := diffMediaURLs(previousImageURLs, editedQuestion.ImageURLs)
+	deleteMediaInBackground(ctx, s.mediaService, removedImageURLs, "QuestionService::EditQuestion")
+
 	return editedQuestion, nil
 }
 
@@ -148,22 +612,40 @@ func (s *questionService) DeleteQuestion(ctx context.Context, userID string, que
 		return fmt.Errorf("QuestionService::DeleteQuestion: %w", err)
 	}
 
-	if err := s.questionRepo.DeleteQuestion(ctx, userID, questionID); err != nil {
+	// the question and its images are purged once the grace period has passed
+	if err := s.questionRepo.DeleteQuestion(ctx, userID, question.ID); err != nil {
 		return fmt.Errorf("QuestionService::DeleteQuestion: %w", err)
 	}
 
-	// delete question images in the background (async)
-	if len(question.ImageURLs) > 0 {
-		go func() {
-			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
-			defer cancel()
-			if err := s.mediaService.DeleteMedia(ctx, question.ImageURLs); err != nil {
-				slog.ErrorContext(ctx, "QuestionService::DeleteQuestion: error while deleting images", "error", err, "image_urls", question.ImageURLs)
-			}
-		}()
+	return nil
+}
+
+func (s *questionService) RestoreQuestion(ctx context.Context, userID string, questionID uuid.UUID) (model.Question, error) {
+	deleted, err := s.questionRepo.GetDeletedQuestionByID(ctx, questionID)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionService::RestoreQuestion: %w", err)
+	}
+
+	// we need to ensure that the user owns the question
+	if deleted.AuthorID != userID {
+		err := errs.UnauthorizedError("You must own this question", fmt.Errorf("user id %s does not own question id %s", userID, questionID))
+		return model.Question{}, fmt.Errorf("QuestionService::RestoreQuestion: %w", err)
 	}
 
-	return nil
+	restored, err := s.questionRepo.RestoreQuestion(ctx, questionID, time.Now().Add(-s.deleteGracePeriod))
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionService::RestoreQuestion: %w", err)
+	}
+	if !restored {
+		err := errs.UnauthorizedError("This question can no longer be restored", fmt.Errorf("question id %s was deleted at %s", questionID, deleted.DeletedAt))
+		return model.Question{}, fmt.Errorf("QuestionService::RestoreQuestion: %w", err)
+	}
+
+	question, err := s.questionRepo.GetQuestionByID(ctx, userID, questionID)
+	if err != nil {
+		return model.Question{}, fmt.Errorf("QuestionService::RestoreQuestion: %w", err)
+	}
+	return question, nil
 }
 
 //
@@ -222,4 +704,47 @@ func (s *questionService) GetQuestionsRespondedByUserID(
 		return nil, fmt.Errorf("QuestionService::GetQuestionsRespondedByUserID: %w", err)
 	}
 	return questions, nil
-}
\ No newline at end of file
+}
+
+func (s *questionService) GetQuestionsFromFollowedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error) {
+	questions, err := s.questionRepo.GetQuestionsFromFollowedUsers(ctx, userID, page)
+	if err != nil {
+		return nil, fmt.Errorf("QuestionService::GetQuestionsFromFollowedUsers: %w", err)
+	}
+	return questions, nil
+}
+
+func (s *questionService) BookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID, notify bool) error {
+	// ensure the question exists
+	if _, err := s.questionRepo.GetQuestionByID(ctx, userID, questionID); err != nil {
+		return fmt.Errorf("QuestionService::BookmarkQuestion: %w", err)
+	}
+
+	if err := s.questionRepo.BookmarkQuestion(ctx, userID, questionID, notify); err != nil {
+		return fmt.Errorf("QuestionService::BookmarkQuestion: %w", err)
+	}
+	return nil
+}
+
+func (s *questionService) UnbookmarkQuestion(ctx context.Context, userID string, questionID uuid.UUID) error {
+	if err := s.questionRepo.UnbookmarkQuestion(ctx, userID, questionID); err != nil {
+		return fmt.Errorf("QuestionService::UnbookmarkQuestion: %w", err)
+	}
+	return nil
+}
+
+func (s *questionService) GetBookmarkedQuestions(ctx context.Context, userID string, page model.PageParams) ([]model.Question, error) {
+	questions, err := s.questionRepo.GetBookmarkedQuestions(ctx, userID, page)
+	if err != nil {
+		return nil, fmt.Errorf("QuestionService::GetBookmarkedQuestions: %w", err)
+	}
+	return questions, nil
+}
+
+func (s *questionService) GetQuestionsByAuthorID(ctx context.Context, userID string, authorID string, page model.PageParams) ([]model.Question, error) {
+	questions, err := s.questionRepo.GetQuestionsByAuthorID(ctx, userID, authorID, page)
+	if err != nil {
+		return nil, fmt.Errorf("QuestionService::GetQuestionsByAuthorID: %w", err)
+	}
+	return questions, nil
+}
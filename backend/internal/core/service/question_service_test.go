@@ -0,0 +1,101 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/errs"
+	"github.com/ksha23/CS407-FactSnap/internal/ptr"
+)
+
+func TestValidatePollVote(t *testing.T) {
+	optionA, optionB, optionC := uuid.New(), uuid.New(), uuid.New()
+	poll := func(maxSelections int, allowOther bool) model.Poll {
+		return model.Poll{
+			ID:            uuid.New(),
+			Type:          model.PollTypeMultiSelect,
+			MaxSelections: maxSelections,
+			AllowOther:    allowOther,
+			Options:       []model.PollOption{{ID: optionA}, {ID: optionB}, {ID: optionC}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		poll    model.Poll
+		params  model.VotePollParams
+		wantErr bool
+	}{
+		{
+			name:   "single option",
+			poll:   poll(1, false),
+			params: model.VotePollParams{OptionIDs: []uuid.UUID{optionA}},
+		},
+		{
+			name:   "several options up to the max",
+			poll:   poll(2, false),
+			params: model.VotePollParams{OptionIDs: []uuid.UUID{optionA, optionC}},
+		},
+		{
+			name:   "removing the vote",
+			poll:   poll(1, false),
+			params: model.VotePollParams{},
+		},
+		{
+			name:    "more options than the max",
+			poll:    poll(2, false),
+			params:  model.VotePollParams{OptionIDs: []uuid.UUID{optionA, optionB, optionC}},
+			wantErr: true,
+		},
+		{
+			name:    "option of another poll",
+			poll:    poll(2, false),
+			params:  model.VotePollParams{OptionIDs: []uuid.UUID{uuid.New()}},
+			wantErr: true,
+		},
+		{
+			name:    "same option twice",
+			poll:    poll(2, false),
+			params:  model.VotePollParams{OptionIDs: []uuid.UUID{optionA, optionA}},
+			wantErr: true,
+		},
+		{
+			name:   "other answer only",
+			poll:   poll(1, true),
+			params: model.VotePollParams{OtherText: ptr.To("something else")},
+		},
+		{
+			name:   "other answer along with an option",
+			poll:   poll(2, true),
+			params: model.VotePollParams{OptionIDs: []uuid.UUID{optionB}, OtherText: ptr.To("something else")},
+		},
+		{
+			name:    "other answer counts toward the max",
+			poll:    poll(2, true),
+			params:  model.VotePollParams{OptionIDs: []uuid.UUID{optionA, optionB}, OtherText: ptr.To("something else")},
+			wantErr: true,
+		},
+		{
+			name:    "other answer when not allowed",
+			poll:    poll(2, false),
+			params:  model.VotePollParams{OtherText: ptr.To("something else")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePollVote(tt.poll, tt.params)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("validatePollVote() error = %v, want nil", err)
+				}
+				return
+			}
+			if errs.ErrType(err) != errs.TypeBadRequest {
+				t.Errorf("validatePollVote() error = %v, want a bad request error", err)
+			}
+		})
+	}
+}
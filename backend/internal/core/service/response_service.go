@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/config"
 	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 	"github.com/ksha23/CS407-FactSnap/internal/core/port"
 	"github.com/ksha23/CS407-FactSnap/internal/errs"
@@ -34,6 +35,9 @@ type responseService struct {
 	mediaService    port.MediaService
 	responseRepo    port.ResponseRepo
 	aiClient        port.AIClient
+	eventPublisher  port.EventPublisher
+	// deleteGracePeriod is how long deleted responses can be restored
+	deleteGracePeriod time.Duration
 }
 
 func NewResponseService(
@@ -41,13 +45,22 @@ func NewResponseService(
 	mediaService port.MediaService,
 	responseRepo port.ResponseRepo,
 	aiClient port.AIClient,
-) *responseService {
-	return &responseService{
-		questionService: questionService,
-		mediaService:    mediaService,
-		responseRepo:    responseRepo,
-		aiClient:        aiClient,
+	eventPublisher port.EventPublisher,
+	softDeleteCfg config.SoftDelete,
+) (*responseService, error) {
+	deleteGracePeriod, err := parseSoftDeleteGracePeriod(softDeleteCfg)
+	if err != nil {
+		return nil, err
 	}
+
+	return &responseService{
+		questionService:   questionService,
+		mediaService:      mediaService,
+		responseRepo:      responseRepo,
+		aiClient:          aiClient,
+		eventPublisher:    eventPublisher,
+		deleteGracePeriod: deleteGracePeriod,
+	}, nil
 }
 
 func (s *responseService) CreateResponse(ctx context.Context, userID string, params model.CreateResponseParams) (model.Response, error) {
@@ -62,6 +75,8 @@ func (s *responseService) CreateResponse(ctx context.Context, userID string, par
 		return model.Response{}, fmt.Errorf("ResponseService::CreateResponse: %w", err)
 	}
 
+	s.publishResponseEvent(ctx, userID, model.EventTypeResponseCreated, response.QuestionID, publicResponse(response))
+
 	return response, nil
 }
 
@@ -82,17 +97,45 @@ func (s *responseService) GetResponseByID(ctx context.Context, userID string, re
 	return response, err
 }
 
+func (s *responseService) GetResponseRevisions(ctx context.Context, userID string, responseID uuid.UUID, page model.PageParams) ([]model.ResponseRevision, error) {
+	// make sure the response exists and is not deleted
+	if _, err := s.responseRepo.GetResponseByID(ctx, userID, responseID); err != nil {
+		return nil, fmt.Errorf("ResponseService::GetResponseRevisions: %w", err)
+	}
+
+	revisions, err := s.responseRepo.GetResponseRevisions(ctx, responseID, page)
+	if err != nil {
+		return nil, fmt.Errorf("ResponseService::GetResponseRevisions: %w", err)
+	}
+	return revisions, nil
+}
+
 func (s *responseService) EditResponse(ctx context.Context, userID string, params model.EditResponseParams) (model.Response, error) {
 	// check if user is authorized to edit this response
-	if _, err := s.authorizeUser(ctx, userID, params.ResponseID, false); err != nil {
+	response, err := s.authorizeUser(ctx, userID, params.ResponseID, false)
+	if err != nil {
 		return model.Response{}, fmt.Errorf("ResponseService::EditResponse: %w", err)
 	}
 
-	resp, err := s.responseRepo.EditResponse(ctx, userID, params)
+	// only images uploaded by the user can be attached
+	if params.ImageURLs != nil {
+		addedImageURLs, _ := diffMediaURLs(response.ImageURLs, params.ImageURLs)
+		if err := s.mediaService.ValidateOwnership(ctx, userID, addedImageURLs); err != nil {
+			return model.Response{}, fmt.Errorf("ResponseService::EditResponse: %w", err)
+		}
+	}
+
+	resp, previousImageURLs, err := s.responseRepo.EditResponse(ctx, userID, params)
 	if err != nil {
 		return model.Response{}, fmt.Errorf("ResponseService::EditResponse: %w", err)
 	}
 
+	// the edit is committed, so the images that were removed can be deleted
+	_, removedImageURLs := diffMediaURLs(previousImageURLs, resp.ImageURLs)
+	deleteMediaInBackground(ctx, s.mediaService, removedImageURLs, "ResponseService::EditResponse")
+
+	s.publishResponseEvent(ctx, userID, model.EventTypeResponseEdited, resp.QuestionID, publicResponse(resp))
+
 	return resp, nil
 }
 
@@ -109,20 +152,51 @@ func (s *responseService) DeleteResponse(ctx context.Context, userID string, res
 
 	}
 
-	// delete response images in the background (async)
-	if len(response.ImageURLs) > 0 {
-		go func() {
-			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
-			defer cancel()
-			if err := s.mediaService.DeleteMedia(ctx, response.ImageURLs); err != nil {
-				slog.ErrorContext(ctx, "ResponseService::DeleteResponse: error while deleting images", "error", err, "image_urls", response.ImageURLs)
-			}
-		}()
-	}
+	s.publishResponseEvent(ctx, userID, model.EventTypeResponseDeleted, response.QuestionID, model.ResponseDeletedEventData{
+		ResponseID: responseID,
+	})
 
+	// the response images are deleted by the purger once the grace period has passed
 	return nil
 }
 
+func (s *responseService) RestoreResponse(ctx context.Context, userID string, responseID uuid.UUID) (model.Response, error) {
+	deleted, err := s.responseRepo.GetDeletedResponseByID(ctx, responseID)
+	if err != nil {
+		return model.Response{}, fmt.Errorf("ResponseService::RestoreResponse: %w", err)
+	}
+
+	// ensure that the user owns the response
+	if deleted.AuthorID != userID {
+		err := errs.UnauthorizedError("You must own this response", fmt.Errorf("user id %s does not own response id %s", userID, responseID))
+		return model.Response{}, fmt.Errorf("ResponseService::RestoreResponse: %w", err)
+	}
+
+	// the question itself may have been deleted meanwhile
+	if _, err := s.questionService.GetQuestionByID(ctx, userID, deleted.QuestionID); err != nil {
+		return model.Response{}, fmt.Errorf("ResponseService::RestoreResponse: %w", err)
+	}
+
+	restored, err := s.responseRepo.RestoreResponse(ctx, deleted, time.Now().Add(-s.deleteGracePeriod))
+	if err != nil {
+		return model.Response{}, fmt.Errorf("ResponseService::RestoreResponse: %w", err)
+	}
+	if !restored {
+		err := errs.UnauthorizedError("This response can no longer be restored", fmt.Errorf("response id %s was deleted at %s", responseID, deleted.DeletedAt))
+		return model.Response{}, fmt.Errorf("ResponseService::RestoreResponse: %w", err)
+	}
+
+	response, err := s.responseRepo.GetResponseByID(ctx, userID, responseID)
+	if err != nil {
+		return model.Response{}, fmt.Errorf("ResponseService::RestoreResponse: %w", err)
+	}
+
+	// to realtime subscribers, the response shows up again
+	s.publishResponseEvent(ctx, userID, model.EventTypeResponseCreated, response.QuestionID, publicResponse(response))
+
+	return response, nil
+}
+
 func (s *responseService) SummarizeResponsesByQuestionID(ctx context.Context, userID string, questionID uuid.UUID) (string, error) {
 	// fetch responses to summarize (for now we do first 20)
 	responses, err := s.responseRepo.GetResponsesByQuestionID(ctx, userID, questionID, model.PageParams{
@@ -138,7 +212,7 @@ func (s *responseService) SummarizeResponsesByQuestionID(ctx context.Context, us
 	}
 
 	// build prompt
-	prompt, err := s.buildSummaryPrompt(responses)
+	prompt, err := buildSummaryPrompt(responses)
 	if err != nil {
 		return "", fmt.Errorf("ResponseService::SummarizeResponsesByQuestionID: %w", err)
 	}
@@ -153,7 +227,23 @@ func (s *responseService) SummarizeResponsesByQuestionID(ctx context.Context, us
 	return output, nil
 }
 
-func (s *responseService) buildSummaryPrompt(responses []model.Response) (string, error) {
+// publishResponseEvent broadcasts a change of the question's responses to its realtime subscribers
+func (s *responseService) publishResponseEvent(ctx context.Context, userID string, eventType model.EventType, questionID uuid.UUID, data any) {
+	question, err := s.questionService.GetQuestionByID(ctx, userID, questionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "ResponseService::publishResponseEvent: could not fetch question", "error", err, "question_id", questionID)
+		return
+	}
+	publishEvent(ctx, s.eventPublisher, model.NewQuestionEvent(eventType, question, data))
+}
+
+// publicResponse returns the response as it should be seen by users other than its author
+func publicResponse(response model.Response) model.Response {
+	response.IsOwned = false
+	return response
+}
+
+func buildSummaryPrompt(responses []model.Response) (string, error) {
 	// gather the responses' body delimited by new-line
 	responseBodies := bytes.NewBufferString("")
 	for _, response := range responses {
@@ -220,3 +310,11 @@ func (s *responseService) isQuestionExpired(ctx context.Context, userID string,
 
 	return nil
 }
+
+func (s *responseService) GetResponsesByAuthorID(ctx context.Context, userID string, authorID string, page model.PageParams) ([]model.Response, error) {
+	responses, err := s.responseRepo.GetResponsesByAuthorID(ctx, userID, authorID, page)
+	if err != nil {
+		return nil, fmt.Errorf("ResponseService::GetResponsesByAuthorID: %w", err)
+	}
+	return responses, nil
+}
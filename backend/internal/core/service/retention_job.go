@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/config"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+)
+
+const (
+	defaultRetentionInterval     = time.Hour
+	defaultRetentionBatchSize    = 100
+	defaultRetentionArchiveAfter = 30 * 24 * time.Hour
+	defaultRetentionDeleteAfter  = 90 * 24 * time.Hour
+
+	retentionMediaDeleteTimeout = 30 * time.Second
+)
+
+type retentionJob struct {
+	questionRepo port.QuestionRepo
+	mediaService port.MediaService
+	interval     time.Duration
+	batchSize    int
+	// archiveAfter and deleteAfter are measured from the question's expiry
+	archiveAfter time.Duration
+	deleteAfter  time.Duration
+	dryRun       bool
+
+	mu    sync.Mutex
+	stats model.RetentionStats
+}
+
+func NewRetentionJob(cfg config.Retention, questionRepo port.QuestionRepo, mediaService port.MediaService) (port.RetentionJob, error) {
+	j := &retentionJob{
+		questionRepo: questionRepo,
+		mediaService: mediaService,
+		interval:     defaultRetentionInterval,
+		batchSize:    defaultRetentionBatchSize,
+		archiveAfter: defaultRetentionArchiveAfter,
+		deleteAfter:  defaultRetentionDeleteAfter,
+		dryRun:       cfg.DryRun,
+	}
+
+	if cfg.Interval != "" {
+		interval, err := time.ParseDuration(cfg.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention interval: %w", err)
+		}
+		j.interval = interval
+	}
+	if cfg.BatchSize > 0 {
+		j.batchSize = cfg.BatchSize
+	}
+	if cfg.ArchiveAfter != "" {
+		archiveAfter, err := time.ParseDuration(cfg.ArchiveAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention archive after: %w", err)
+		}
+		j.archiveAfter = archiveAfter
+	}
+	if cfg.DeleteAfter != "" {
+		deleteAfter, err := time.ParseDuration(cfg.DeleteAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention delete after: %w", err)
+		}
+		j.deleteAfter = deleteAfter
+	}
+	if j.deleteAfter < j.archiveAfter {
+		return nil, fmt.Errorf("invalid retention: delete after (%s) must not be shorter than archive after (%s)", j.deleteAfter, j.archiveAfter)
+	}
+
+	j.stats.DryRun = j.dryRun
+	return j, nil
+}
+
+func (j *retentionJob) Run(ctx context.Context) error {
+	slog.Info("Starting retention job...",
+		"interval", j.interval.String(),
+		"archive_after", j.archiveAfter.String(),
+		"delete_after", j.deleteAfter.String(),
+		"dry_run", j.dryRun,
+	)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Shutting down retention job...")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *retentionJob) Preview(ctx context.Context, limit int) (model.RetentionPreview, error) {
+	archiveBefore, deleteBefore := j.cutoffs(time.Now())
+
+	toArchive, err := j.questionRepo.GetQuestionsToArchive(ctx, archiveBefore, limit)
+	if err != nil {
+		return model.RetentionPreview{}, fmt.Errorf("RetentionJob::Preview: %w", err)
+	}
+	toPurge, err := j.questionRepo.GetQuestionsToPurge(ctx, deleteBefore, limit)
+	if err != nil {
+		return model.RetentionPreview{}, fmt.Errorf("RetentionJob::Preview: %w", err)
+	}
+
+	return model.RetentionPreview{
+		DryRun:        j.dryRun,
+		ArchiveBefore: archiveBefore,
+		DeleteBefore:  deleteBefore,
+		ToArchive:     toArchive,
+		ToPurge:       toPurge,
+	}, nil
+}
+
+func (j *retentionJob) Stats() model.RetentionStats {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.stats
+}
+
+// cutoffs returns before when a question must have expired to be archived and purged
+func (j *retentionJob) cutoffs(now time.Time) (archiveBefore, deleteBefore time.Time) {
+	return now.Add(-j.archiveAfter), now.Add(-j.deleteAfter)
+}
+
+func (j *retentionJob) runOnce(ctx context.Context) {
+	now := time.Now()
+	archiveBefore, deleteBefore := j.cutoffs(now)
+
+	var err error
+	if j.dryRun {
+		err = j.dryRunOnce(ctx, archiveBefore, deleteBefore)
+	} else {
+		// purge before archiving, so that a question is never archived and purged by the same run
+		if err = j.purge(ctx, deleteBefore); err == nil {
+			err = j.archive(ctx, archiveBefore)
+		}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.stats.NumRuns++
+	j.stats.LastRunAt = &now
+	j.stats.LastError = nil
+	if err != nil {
+		slog.ErrorContext(ctx, "Retention job run failed", "error", err)
+		msg := err.Error()
+		j.stats.LastError = &msg
+	}
+}
+
+// dryRunOnce only counts the questions that are due, without changing anything
+func (j *retentionJob) dryRunOnce(ctx context.Context, archiveBefore, deleteBefore time.Time) error {
+	preview, err := j.Preview(ctx, j.batchSize)
+	if err != nil {
+		return fmt.Errorf("dryRunOnce: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Retention job dry run",
+		"num_would_archive", len(preview.ToArchive),
+		"num_would_purge", len(preview.ToPurge),
+		"archive_before", archiveBefore,
+		"delete_before", deleteBefore,
+	)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.stats.NumWouldArchive = len(preview.ToArchive)
+	j.stats.NumWouldPurge = len(preview.ToPurge)
+	return nil
+}
+
+func (j *retentionJob) archive(ctx context.Context, archiveBefore time.Time) error {
+	for ctx.Err() == nil {
+		candidates, err := j.questionRepo.GetQuestionsToArchive(ctx, archiveBefore, j.batchSize)
+		if err != nil {
+			return fmt.Errorf("archive: %w", err)
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+
+		questionIDs := make([]uuid.UUID, len(candidates))
+		for i, candidate := range candidates {
+			questionIDs[i] = candidate.QuestionID
+		}
+		numArchived, err := j.questionRepo.ArchiveQuestions(ctx, questionIDs, archiveBefore)
+		if err != nil {
+			return fmt.Errorf("archive: %w", err)
+		}
+
+		j.mu.Lock()
+		j.stats.NumArchived += numArchived
+		j.mu.Unlock()
+
+		if len(candidates) < j.batchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (j *retentionJob) purge(ctx context.Context, deleteBefore time.Time) error {
+	for ctx.Err() == nil {
+		candidates, err := j.questionRepo.GetQuestionsToPurge(ctx, deleteBefore, j.batchSize)
+		if err != nil {
+			return fmt.Errorf("purge: %w", err)
+		}
+
+		purged := 0
+		for _, candidate := range candidates {
+			if err := j.purgeQuestion(ctx, candidate, deleteBefore); err != nil {
+				slog.ErrorContext(ctx, "Failed to purge question", "question_id", candidate.QuestionID, "error", err)
+				j.mu.Lock()
+				j.stats.NumFailed++
+				j.mu.Unlock()
+				continue
+			}
+			purged++
+		}
+
+		// failed questions are not counted, so that a batch that keeps failing is not retried in a tight loop
+		if purged < j.batchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+// purgeQuestion deletes the media first, so that a failure leaves the question to be purged again by the next run
+func (j *retentionJob) purgeQuestion(ctx context.Context, candidate model.RetentionCandidate, deleteBefore time.Time) error {
+	if len(candidate.ImageURLs) > 0 {
+		mediaCtx, cancel := context.WithTimeout(ctx, retentionMediaDeleteTimeout)
+		err := j.mediaService.DeleteMedia(mediaCtx, candidate.ImageURLs)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("purgeQuestion: %w", err)
+		}
+	}
+
+	purged, err := j.questionRepo.PurgeQuestion(ctx, candidate.QuestionID, deleteBefore)
+	if err != nil {
+		return fmt.Errorf("purgeQuestion: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.stats.NumMediaDeleted += len(candidate.ImageURLs)
+	if purged {
+		j.stats.NumPurged++
+	}
+	return nil
+}
@@ -3,17 +3,61 @@ package service
 import (
 	"context"
 	"fmt"
-    "strings"
-    "github.com/ksha23/CS407-FactSnap/internal/core/model"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ksha23/CS407-FactSnap/internal/clerk"
+	"github.com/ksha23/CS407-FactSnap/internal/core/model"
 	"github.com/ksha23/CS407-FactSnap/internal/core/port"
+	"github.com/ksha23/CS407-FactSnap/internal/errs"
+	"github.com/ksha23/CS407-FactSnap/internal/ptr"
+	"github.com/ksha23/CS407-FactSnap/internal/validate"
 )
 
+// a username can only be changed once in this window
+const usernameChangeCooldown = 30 * 24 * time.Hour
+
+// reservedUsernames cannot be taken by users, so that they cannot impersonate the app or collide with routes
+var reservedUsernames = map[string]struct{}{
+	"admin":         {},
+	"administrator": {},
+	"api":           {},
+	"factsnap":      {},
+	"help":          {},
+	"me":            {},
+	"moderator":     {},
+	"null":          {},
+	"root":          {},
+	"staff":         {},
+	"support":       {},
+	"system":        {},
+	"undefined":     {},
+}
+
+// the uploads of a deleted account are deleted in the background, there can be many of them
+const accountMediaDeleteTimeout = 5 * time.Minute
+
 type userService struct {
-	userRepo port.UserRepository
+	userRepo       port.UserRepository
+	dataExportRepo port.DataExportRepo
+	mediaService   port.MediaService
+	clerkClient    clerk.Client
 }
 
-func NewUserService(userRepo port.UserRepository) *userService {
-	return &userService{userRepo: userRepo}
+func NewUserService(
+	userRepo port.UserRepository,
+	dataExportRepo port.DataExportRepo,
+	mediaService port.MediaService,
+	clerkClient clerk.Client,
+) *userService {
+	return &userService{
+		userRepo:       userRepo,
+		dataExportRepo: dataExportRepo,
+		mediaService:   mediaService,
+		clerkClient:    clerkClient,
+	}
 }
 
 func (s *userService) GetUserStatistics(ctx context.Context, userID string) (int, int, error) {
@@ -30,29 +74,274 @@ func (s *userService) GetUserStatistics(ctx context.Context, userID string) (int
 	return questionCount, responseCount, nil
 }
 
+func (s *userService) GetUserByID(ctx context.Context, viewerID, userID string) (model.User, error) {
+	user, err := s.userRepo.GetPublicUserByID(ctx, viewerID, userID)
+	if err != nil {
+		return model.User{}, fmt.Errorf("UserService::GetUserByID: %w", err)
+	}
+	return user, nil
+}
+
+func (s *userService) GetUserByUsername(ctx context.Context, viewerID, username string) (model.User, error) {
+	user, err := s.userRepo.GetPublicUserByUsername(ctx, viewerID, username)
+	if err != nil {
+		return model.User{}, fmt.Errorf("UserService::GetUserByUsername: %w", err)
+	}
+	return user, nil
+}
+
 func (s *userService) UpdateLocation(ctx context.Context, userID string, lat, long float64) error {
 	return s.userRepo.UpdateLocation(ctx, userID, lat, long)
 }
 
-func (s *userService) UpdatePushToken(ctx context.Context, userID, token string) error {
-	return s.userRepo.UpdatePushToken(ctx, userID, token)
+func (s *userService) UpdatePushToken(ctx context.Context, userID string, params model.RegisterDeviceParams) error {
+	return s.userRepo.UpdatePushToken(ctx, userID, params)
+}
+
+func (s *userService) DeletePushToken(ctx context.Context, userID, token string) error {
+	return s.userRepo.DeletePushToken(ctx, userID, token)
+}
+
+func (s *userService) EditUser(ctx context.Context, params model.EditUserParams) (model.AuthUser, error) {
+	user, err := s.userRepo.GetAuthUserByID(ctx, params.UserID)
+	if err != nil {
+		return model.AuthUser{}, fmt.Errorf("UserService::EditUser: %w", err)
+	}
+
+	if params.DisplayName != nil {
+		params.DisplayName = ptr.To(strings.TrimSpace(*params.DisplayName))
+		if err := validate.DisplayName(*params.DisplayName); err != nil {
+			return model.AuthUser{}, fmt.Errorf("UserService::EditUser: %w", errs.BadRequestError(err.Error(), err))
+		}
+	}
+
+	if params.AboutMe != nil {
+		params.AboutMe = ptr.To(strings.TrimSpace(*params.AboutMe))
+		if err := validate.AboutMe(*params.AboutMe); err != nil {
+			return model.AuthUser{}, fmt.Errorf("UserService::EditUser: %w", errs.BadRequestError(err.Error(), err))
+		}
+	}
+
+	// an unchanged username is not a username change
+	if params.Username != nil && strings.TrimSpace(*params.Username) == user.Username {
+		params.Username = nil
+	}
+	if params.Username != nil {
+		params.Username = ptr.To(strings.TrimSpace(*params.Username))
+		if err := s.validateUsernameChange(ctx, user, *params.Username); err != nil {
+			return model.AuthUser{}, fmt.Errorf("UserService::EditUser: %w", err)
+		}
+	}
+
+	// only avatars uploaded by the user can be set
+	if params.AvatarURL != nil && *params.AvatarURL != "" && *params.AvatarURL != ptr.Deref(user.AvatarURL) {
+		if err := validate.URL(*params.AvatarURL); err != nil {
+			return model.AuthUser{}, fmt.Errorf("UserService::EditUser: %w", errs.BadRequestError(err.Error(), err))
+		}
+		if err := s.mediaService.ValidateOwnership(ctx, params.UserID, []string{*params.AvatarURL}); err != nil {
+			return model.AuthUser{}, fmt.Errorf("UserService::EditUser: %w", err)
+		}
+	}
+
+	editedUser, err := s.userRepo.EditUser(ctx, params)
+	if err != nil {
+		return model.AuthUser{}, fmt.Errorf("UserService::EditUser: %w", err)
+	}
+
+	// the edit is committed, so the previous avatar can be deleted,
+	// unless it wasn't uploaded by the user (e.g. the avatar synced from Clerk)
+	previousAvatarURL := ptr.Deref(user.AvatarURL)
+	if previousAvatarURL != "" && previousAvatarURL != ptr.Deref(editedUser.AvatarURL) &&
+		s.mediaService.ValidateOwnership(ctx, params.UserID, []string{previousAvatarURL}) == nil {
+		deleteMediaInBackground(ctx, s.mediaService, []string{previousAvatarURL}, "UserService::EditUser")
+	}
+
+	return editedUser, nil
+}
+
+// validateUsernameChange checks the format of the new username, that it is available, and that the user didn't change their username recently
+func (s *userService) validateUsernameChange(ctx context.Context, user model.AuthUser, username string) error {
+	if err := validate.Username(username); err != nil {
+		return errs.BadRequestError(err.Error(), err)
+	}
+
+	if _, ok := reservedUsernames[strings.ToLower(username)]; ok {
+		return errs.BadRequestError("This username is reserved", fmt.Errorf("user id %s tried to take the reserved username %s", user.ID, username))
+	}
+
+	if user.UsernameChangedAt != nil {
+		nextChangeAt := user.UsernameChangedAt.Add(usernameChangeCooldown)
+		if time.Now().Before(nextChangeAt) {
+			return errs.BadRequestError(
+				fmt.Sprintf("You can change your username again on %s", nextChangeAt.Format("January 2, 2006")),
+				fmt.Errorf("user id %s changed their username at %s", user.ID, user.UsernameChangedAt),
+			)
+		}
+	}
+
+	taken, err := s.userRepo.IsUsernameTaken(ctx, user.ID, username)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errs.Error{
+			Type:     errs.TypeForbidden,
+			Message:  "This username is already taken",
+			Internal: fmt.Errorf("user id %s tried to take the username %s", user.ID, username),
+		}
+	}
+
+	return nil
+}
+
+func (s *userService) FollowUser(ctx context.Context, userID, followeeID string) error {
+	if userID == followeeID {
+		return fmt.Errorf("UserService::FollowUser: %w", errs.BadRequestError("You cannot follow yourself", fmt.Errorf("user id %s tried to follow themselves", userID)))
+	}
+
+	// ensure the followed user exists
+	if _, err := s.userRepo.GetAuthUserByID(ctx, followeeID); err != nil {
+		return fmt.Errorf("UserService::FollowUser: %w", err)
+	}
+
+	if err := s.userRepo.FollowUser(ctx, userID, followeeID); err != nil {
+		return fmt.Errorf("UserService::FollowUser: %w", err)
+	}
+	return nil
+}
+
+func (s *userService) UnfollowUser(ctx context.Context, userID, followeeID string) error {
+	if err := s.userRepo.UnfollowUser(ctx, userID, followeeID); err != nil {
+		return fmt.Errorf("UserService::UnfollowUser: %w", err)
+	}
+	return nil
 }
 
-func (s *userService) DeletePushToken(ctx context.Context, userID string) error {
-	return s.userRepo.DeletePushToken(ctx, userID)
+func (s *userService) GetFollowCounts(ctx context.Context, userID string) (int, int, error) {
+	followerCount, err := s.userRepo.GetUserFollowerCount(ctx, userID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("UserService::GetFollowCounts: %w", err)
+	}
+
+	followingCount, err := s.userRepo.GetUserFollowingCount(ctx, userID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("UserService::GetFollowCounts: %w", err)
+	}
+
+	return followerCount, followingCount, nil
 }
 
-func (s *userService) UpdateProfile(ctx context.Context, userID string, displayName string) (model.AuthUser, error) {
-    name := strings.TrimSpace(displayName)
+func (s *userService) BlockUser(ctx context.Context, userID, blockedID string) error {
+	if userID == blockedID {
+		return fmt.Errorf("UserService::BlockUser: %w", errs.BadRequestError("You cannot block yourself", fmt.Errorf("user id %s tried to block themselves", userID)))
+	}
 
-    if len(name) < 2 || len(name) > 50 {
-        return model.AuthUser{}, fmt.Errorf("display name must be between 2 and 50 characters")
-    }
+	// ensure the blocked user exists
+	if _, err := s.userRepo.GetAuthUserByID(ctx, blockedID); err != nil {
+		return fmt.Errorf("UserService::BlockUser: %w", err)
+	}
 
-    user, err := s.userRepo.UpdateDisplayName(ctx, userID, name)
-    if err != nil {
-        return model.AuthUser{}, fmt.Errorf("UserService::UpdateProfile: %w", err)
-    }
+	if err := s.userRepo.BlockUser(ctx, userID, blockedID); err != nil {
+		return fmt.Errorf("UserService::BlockUser: %w", err)
+	}
+	return nil
+}
 
-    return user, nil
-}
\ No newline at end of file
+func (s *userService) UnblockUser(ctx context.Context, userID, blockedID string) error {
+	if err := s.userRepo.UnblockUser(ctx, userID, blockedID); err != nil {
+		return fmt.Errorf("UserService::UnblockUser: %w", err)
+	}
+	return nil
+}
+
+func (s *userService) GetBlockedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.User, error) {
+	users, err := s.userRepo.GetBlockedUsers(ctx, userID, page)
+	if err != nil {
+		return nil, fmt.Errorf("UserService::GetBlockedUsers: %w", err)
+	}
+	return users, nil
+}
+
+func (s *userService) MuteUser(ctx context.Context, userID, mutedID string) error {
+	if userID == mutedID {
+		return fmt.Errorf("UserService::MuteUser: %w", errs.BadRequestError("You cannot mute yourself", fmt.Errorf("user id %s tried to mute themselves", userID)))
+	}
+
+	// ensure the muted user exists
+	if _, err := s.userRepo.GetAuthUserByID(ctx, mutedID); err != nil {
+		return fmt.Errorf("UserService::MuteUser: %w", err)
+	}
+
+	if err := s.userRepo.MuteUser(ctx, userID, mutedID); err != nil {
+		return fmt.Errorf("UserService::MuteUser: %w", err)
+	}
+	return nil
+}
+
+func (s *userService) UnmuteUser(ctx context.Context, userID, mutedID string) error {
+	if err := s.userRepo.UnmuteUser(ctx, userID, mutedID); err != nil {
+		return fmt.Errorf("UserService::UnmuteUser: %w", err)
+	}
+	return nil
+}
+
+func (s *userService) GetMutedUsers(ctx context.Context, userID string, page model.PageParams) ([]model.User, error) {
+	users, err := s.userRepo.GetMutedUsers(ctx, userID, page)
+	if err != nil {
+		return nil, fmt.Errorf("UserService::GetMutedUsers: %w", err)
+	}
+	return users, nil
+}
+
+func (s *userService) DeleteAccount(ctx context.Context, userID string) error {
+	// the rows go first, so that the account is gone even if the cleanup fails.
+	// every step is idempotent, so a failed request can be repeated.
+	orphanedMediaURLs, err := s.userRepo.DeleteUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("UserService::DeleteAccount: %w", err)
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), accountMediaDeleteTimeout)
+		defer cancel()
+		if err := s.mediaService.DeleteUploads(ctx, userID); err != nil {
+			slog.ErrorContext(ctx, "UserService::DeleteAccount: error while deleting uploads", "error", err, "user_id", userID)
+		}
+		if err := s.mediaService.DeleteMedia(ctx, orphanedMediaURLs); err != nil {
+			slog.ErrorContext(ctx, "UserService::DeleteAccount: error while deleting images", "error", err, "image_urls", orphanedMediaURLs)
+		}
+	}()
+
+	// otherwise signing in again would sync the user back
+	if err := s.clerkClient.DeleteUser(ctx, userID); err != nil {
+		return fmt.Errorf("UserService::DeleteAccount: %w", err)
+	}
+
+	return nil
+}
+
+func (s *userService) RequestDataExport(ctx context.Context, userID string) (model.DataExport, error) {
+	export, err := s.dataExportRepo.CreateDataExport(ctx, userID)
+	if err != nil {
+		return model.DataExport{}, fmt.Errorf("UserService::RequestDataExport: %w", err)
+	}
+	return export, nil
+}
+
+func (s *userService) GetDataExport(ctx context.Context, userID string, exportID uuid.UUID) (model.DataExport, error) {
+	export, err := s.dataExportRepo.GetDataExportByID(ctx, userID, exportID)
+	if err != nil {
+		return model.DataExport{}, fmt.Errorf("UserService::GetDataExport: %w", err)
+	}
+
+	if export.Status == model.DataExportStatusReady && export.ObjectKey != nil {
+		url, expiresAt, err := s.mediaService.GetDownloadURL(ctx, *export.ObjectKey)
+		if err != nil {
+			return model.DataExport{}, fmt.Errorf("UserService::GetDataExport: %w", err)
+		}
+		export.URL = &url
+		export.URLExpiresAt = &expiresAt
+	}
+
+	return export, nil
+}
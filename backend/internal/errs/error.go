@@ -64,3 +64,14 @@ func UnauthorizedError(msg string, err error) error {
 		Internal: err,
 	}
 }
+
+func BadRequestError(msg string, err error) error {
+	if err == nil {
+		err = errors.New(msg)
+	}
+	return Error{
+		Type:     TypeBadRequest,
+		Message:  msg,
+		Internal: err,
+	}
+}
@@ -3,13 +3,20 @@ package validate
 import (
 	"fmt"
 	"github.com/asaskevich/govalidator"
+	"regexp"
 	"strconv"
 	"time"
 )
 
 const (
+	MinUsernameLength = 3
 	MaxUsernameLength = 30
 
+	MinDisplayNameLength = 2
+	MaxDisplayNameLength = 50
+
+	MaxAboutMeLength = 300
+
 	MinTitleLength = 3
 	MaxTitleLength = 120
 
@@ -20,8 +27,49 @@ const (
 	MaxPollOptions      = 10
 	MinPollOptionLength = 1
 	MaxPollOptionLength = 100
+	MaxPollOtherLength  = 100
+
+	MinRatingValue       = 0
+	MaxRatingValue       = 10
+	MaxRatingLabelLength = 30
 )
 
+// usernamePattern keeps usernames mentionable, see model.ParseMentions
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+func Username(str string) error {
+	// 3-30 chars
+	if len(str) < MinUsernameLength {
+		return fmt.Errorf("username must be at least %d characters long", MinUsernameLength)
+	}
+	if len(str) > MaxUsernameLength {
+		return fmt.Errorf("username cannot exceed %d characters", MaxUsernameLength)
+	}
+
+	// letters, digits and underscores, with single dots in between
+	if !usernamePattern.MatchString(str) {
+		return fmt.Errorf("username can only contain letters, digits, underscores and dots")
+	}
+
+	return nil
+}
+
+func DisplayName(str string) error {
+	// 2-50 chars
+	if len(str) < MinDisplayNameLength || len(str) > MaxDisplayNameLength {
+		return fmt.Errorf("display name must be between %d and %d characters", MinDisplayNameLength, MaxDisplayNameLength)
+	}
+	return nil
+}
+
+func AboutMe(str string) error {
+	// max 300 chars
+	if len(str) > MaxAboutMeLength {
+		return fmt.Errorf("about me cannot exceed %d characters", MaxAboutMeLength)
+	}
+	return nil
+}
+
 func Title(str string) error {
 	// min 3 chars
 	if len(str) < MinTitleLength {
@@ -102,6 +150,39 @@ func PollOptionLabels(optionLabels []string) error {
 	return nil
 }
 
+func PollOtherText(str string) error {
+	// each "other" answer must be between 1-100 chars (inclusive), just like option labels
+	if len(str) < MinPollOptionLength {
+		return fmt.Errorf("other answer must be at least %d characters long", MinPollOptionLength)
+	}
+	if len(str) > MaxPollOtherLength {
+		return fmt.Errorf("other answer cannot exceed %d characters", MaxPollOtherLength)
+	}
+
+	return nil
+}
+
+func RatingScale(minValue, maxValue int) error {
+	// the scale must be within 0-10 (inclusive) and have at least 2 values
+	if minValue < MinRatingValue || maxValue > MaxRatingValue {
+		return fmt.Errorf("scale %d-%d must be within %d-%d", minValue, maxValue, MinRatingValue, MaxRatingValue)
+	}
+	if minValue >= maxValue {
+		return fmt.Errorf("min value %d must be less than max value %d", minValue, maxValue)
+	}
+
+	return nil
+}
+
+func RatingLabel(str string) error {
+	// max 30 chars
+	if len(str) > MaxRatingLabelLength {
+		return fmt.Errorf("label cannot exceed %d characters", MaxRatingLabelLength)
+	}
+
+	return nil
+}
+
 func PageLimit(limit int) error {
 	if limit < 0 {
 		return fmt.Errorf("limit %d must be >= 0", limit)
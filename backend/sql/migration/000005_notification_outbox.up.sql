@@ -0,0 +1,15 @@
+CREATE TABLE "notification_outbox" (
+    "id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    "type" text NOT NULL,
+    "payload" jsonb NOT NULL,
+    "status" text NOT NULL DEFAULT 'Pending', -- Pending, Processing, Delivered or Failed
+    "attempts" integer NOT NULL DEFAULT 0,
+    "last_error" text NULL,
+    "available_at" timestamptz NOT NULL DEFAULT current_timestamp, -- message will not be claimed before this time (used for retry backoff)
+    "locked_until" timestamptz NULL, -- claim lease, so messages of a crashed worker can be reclaimed
+    "delivered_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX "notification_outbox_claimable_idx" ON "notification_outbox" (available_at)
+    WHERE status IN ('Pending', 'Processing');
@@ -0,0 +1,9 @@
+-- Expo push tickets awaiting a delivery receipt
+CREATE TABLE "push_tickets" (
+    "ticket_id" text PRIMARY KEY,
+    "user_id" text NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "token" text NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX "push_tickets_created_at_idx" ON "push_tickets" (created_at);
@@ -0,0 +1,13 @@
+ALTER TABLE "users" ADD COLUMN "expo_push_token" text;
+
+-- keep the most recently seen device of each user
+UPDATE "users" u
+SET expo_push_token = d.token
+FROM (
+    SELECT DISTINCT ON (user_id) user_id, token
+    FROM "user_devices"
+    ORDER BY user_id, last_seen_at DESC
+) d
+WHERE u.id = d.user_id;
+
+DROP TABLE IF EXISTS "user_devices";
@@ -0,0 +1,21 @@
+-- a user may receive push notifications on several devices
+CREATE TABLE "user_devices" (
+    "id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    "user_id" text NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "token" text NOT NULL UNIQUE, -- a device belongs to whoever registered it last
+    "platform" text NULL,
+    "app_version" text NULL,
+    "last_seen_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX "user_devices_user_id_idx" ON "user_devices" (user_id);
+
+-- carry over the single token each user had so far
+INSERT INTO "user_devices" (user_id, token)
+SELECT id, expo_push_token
+FROM "users"
+WHERE expo_push_token IS NOT NULL
+ON CONFLICT (token) DO NOTHING;
+
+ALTER TABLE "users" DROP COLUMN "expo_push_token";
@@ -0,0 +1,16 @@
+-- in-app notification inbox
+CREATE TABLE "notifications" (
+    "id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    "user_id" text NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "type" text NOT NULL,
+    "title" text NOT NULL,
+    "body" text NOT NULL,
+    "data" jsonb NOT NULL DEFAULT '{}',
+    "dedupe_key" text NULL, -- prevents duplicates when the same notification is delivered again (e.g. outbox retry)
+    "read_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    UNIQUE ("user_id", "dedupe_key")
+);
+
+CREATE INDEX "notifications_user_id_created_at_idx" ON "notifications" (user_id, created_at DESC);
+CREATE INDEX "notifications_unread_idx" ON "notifications" (user_id) WHERE read_at IS NULL;
@@ -0,0 +1,23 @@
+DROP INDEX IF EXISTS "poll_votes_user_rank_idx";
+DROP INDEX IF EXISTS "poll_votes_user_other_idx";
+DROP INDEX IF EXISTS "poll_votes_user_option_idx";
+
+-- only a single option vote per user can be kept
+DELETE FROM "poll_votes" WHERE option_id IS NULL;
+DELETE FROM "poll_votes" pv
+USING (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY poll_id, user_id ORDER BY rank NULLS LAST, created_at) AS n
+    FROM "poll_votes"
+) ranked
+WHERE pv.id = ranked.id AND ranked.n > 1;
+
+ALTER TABLE "poll_votes" DROP CONSTRAINT "poll_votes_option_or_other_check";
+ALTER TABLE "poll_votes" ALTER COLUMN "option_id" SET NOT NULL;
+ALTER TABLE "poll_votes" DROP COLUMN "other_text";
+ALTER TABLE "poll_votes" DROP COLUMN "rank";
+ALTER TABLE "poll_votes" DROP COLUMN "id";
+ALTER TABLE "poll_votes" ADD PRIMARY KEY (poll_id, user_id);
+
+ALTER TABLE "polls" DROP COLUMN "allow_other";
+ALTER TABLE "polls" DROP COLUMN "max_selections";
+ALTER TABLE "polls" DROP COLUMN "type";
@@ -0,0 +1,16 @@
+ALTER TABLE "polls" ADD COLUMN "type" text NOT NULL DEFAULT 'SingleChoice'; -- SingleChoice, MultiSelect or RankedChoice
+ALTER TABLE "polls" ADD COLUMN "max_selections" int NOT NULL DEFAULT 1; -- max picks of MultiSelect, max ranks of RankedChoice
+ALTER TABLE "polls" ADD COLUMN "allow_other" boolean NOT NULL DEFAULT false; -- voters may write in their own answer
+
+-- a user may now have several votes per poll (one row per picked option)
+ALTER TABLE "poll_votes" DROP CONSTRAINT "poll_votes_pkey";
+ALTER TABLE "poll_votes" ADD COLUMN "id" uuid DEFAULT gen_random_uuid() PRIMARY KEY;
+ALTER TABLE "poll_votes" ADD COLUMN "rank" int NULL; -- 1-based preference of a RankedChoice vote
+ALTER TABLE "poll_votes" ADD COLUMN "other_text" text NULL; -- write-in answer, option_id is NULL in that case
+ALTER TABLE "poll_votes" ALTER COLUMN "option_id" DROP NOT NULL;
+ALTER TABLE "poll_votes" ADD CONSTRAINT "poll_votes_option_or_other_check"
+    CHECK ((option_id IS NULL) <> (other_text IS NULL));
+
+CREATE UNIQUE INDEX "poll_votes_user_option_idx" ON "poll_votes" (poll_id, user_id, option_id);
+CREATE UNIQUE INDEX "poll_votes_user_other_idx" ON "poll_votes" (poll_id, user_id) WHERE option_id IS NULL;
+CREATE UNIQUE INDEX "poll_votes_user_rank_idx" ON "poll_votes" (poll_id, user_id, rank);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "poll_votes_option_id_idx";
+
+ALTER TABLE "polls" DROP COLUMN "is_anonymous";
+ALTER TABLE "polls" DROP COLUMN "results_visibility";
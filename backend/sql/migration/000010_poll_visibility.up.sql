@@ -0,0 +1,4 @@
+ALTER TABLE "polls" ADD COLUMN "results_visibility" text NOT NULL DEFAULT 'Always'; -- Always, AfterVote or AfterExpiry
+ALTER TABLE "polls" ADD COLUMN "is_anonymous" boolean NOT NULL DEFAULT true; -- public polls list who voted for each option
+
+CREATE INDEX "poll_votes_option_id_idx" ON "poll_votes" (option_id, created_at DESC);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "polls_unfinalized_idx";
+
+ALTER TABLE "polls" DROP COLUMN "final_results";
+ALTER TABLE "polls" DROP COLUMN "closed_at";
+ALTER TABLE "polls" DROP COLUMN "closes_at";
@@ -0,0 +1,11 @@
+ALTER TABLE "polls" ADD COLUMN "closes_at" timestamptz NULL; -- the poll closes with its question if NULL
+ALTER TABLE "polls" ADD COLUMN "closed_at" timestamptz NULL;
+ALTER TABLE "polls" ADD COLUMN "final_results" jsonb NULL; -- snapshot of the results, taken once the poll closed
+
+-- polls of already expired questions are closed, their results are snapshotted by the poll closer
+UPDATE "polls" p
+SET closed_at = q.expired_at
+FROM "questions" q
+WHERE q.id = p.question_id AND q.expired_at <= now();
+
+CREATE INDEX "polls_unfinalized_idx" ON "polls" (closes_at) WHERE final_results IS NULL;
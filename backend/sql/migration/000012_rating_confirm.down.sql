@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS confirm_answers;
+DROP TABLE IF EXISTS confirms;
+DROP TABLE IF EXISTS rating_answers;
+DROP TABLE IF EXISTS ratings;
@@ -0,0 +1,42 @@
+CREATE TABLE ratings (
+    "id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    "question_id" uuid NOT NULL,
+    "min_value" int NOT NULL DEFAULT 1,
+    "max_value" int NOT NULL DEFAULT 5,
+    "min_label" text NULL, -- e.g. "Empty"
+    "max_label" text NULL, -- e.g. "Packed"
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    UNIQUE (question_id),
+    CHECK (min_value < max_value),
+    FOREIGN KEY (question_id) REFERENCES "questions" (id) ON DELETE CASCADE
+);
+
+CREATE TABLE rating_answers (
+    "rating_id" uuid NOT NULL,
+    "user_id" text NOT NULL,
+    "value" int NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    "updated_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (rating_id, user_id),
+    FOREIGN KEY (rating_id) REFERENCES "ratings" (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+CREATE TABLE confirms (
+    "id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    "question_id" uuid NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    UNIQUE (question_id),
+    FOREIGN KEY (question_id) REFERENCES "questions" (id) ON DELETE CASCADE
+);
+
+CREATE TABLE confirm_answers (
+    "confirm_id" uuid NOT NULL,
+    "user_id" text NOT NULL,
+    "is_yes" boolean NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    "updated_at" timestamptz NOT NULL DEFAULT current_timestamp, -- answering again refreshes the weight of the answer
+    PRIMARY KEY (confirm_id, user_id),
+    FOREIGN KEY (confirm_id) REFERENCES "confirms" (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
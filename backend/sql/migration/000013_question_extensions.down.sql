@@ -0,0 +1,2 @@
+ALTER TABLE "questions" DROP COLUMN "reopened_at";
+ALTER TABLE "questions" DROP COLUMN "num_extensions";
@@ -0,0 +1,2 @@
+ALTER TABLE "questions" ADD COLUMN "num_extensions" int NOT NULL DEFAULT 0; -- how many times the owner pushed expired_at out
+ALTER TABLE "questions" ADD COLUMN "reopened_at" timestamptz NULL; -- a question can only be reopened once
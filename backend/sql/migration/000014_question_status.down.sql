@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "questions_active_expired_at_idx";
+ALTER TABLE "questions" DROP COLUMN "summary";
+ALTER TABLE "questions" DROP COLUMN "status";
@@ -0,0 +1,7 @@
+ALTER TABLE "questions" ADD COLUMN "status" text NOT NULL DEFAULT 'Active'; -- Active, Expired or Archived
+ALTER TABLE "questions" ADD COLUMN "summary" text NULL; -- final summary of the responses, stored when the question expires
+
+-- questions that expired before the lifecycle scheduler existed are not processed again
+UPDATE "questions" SET "status" = 'Expired' WHERE "expired_at" <= now();
+
+CREATE INDEX "questions_active_expired_at_idx" ON "questions" ("expired_at") WHERE "status" = 'Active';
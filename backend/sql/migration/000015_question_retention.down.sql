@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "questions_status_expired_at_idx";
@@ -0,0 +1,2 @@
+-- the retention job scans expired and archived questions by how long ago they expired
+CREATE INDEX "questions_status_expired_at_idx" ON "questions" ("status", "expired_at");
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "responses_deleted_at_idx";
+DROP INDEX IF EXISTS "questions_deleted_at_idx";
+ALTER TABLE "responses" DROP COLUMN "deleted_at";
+ALTER TABLE "questions" DROP COLUMN "deleted_at";
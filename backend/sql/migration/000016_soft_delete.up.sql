@@ -0,0 +1,5 @@
+ALTER TABLE "questions" ADD COLUMN "deleted_at" timestamptz NULL; -- soft-deleted, hard-deleted by the purger after the grace period
+ALTER TABLE "responses" ADD COLUMN "deleted_at" timestamptz NULL;
+
+CREATE INDEX "questions_deleted_at_idx" ON "questions" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
+CREATE INDEX "responses_deleted_at_idx" ON "responses" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "response_revisions";
+DROP TABLE IF EXISTS "question_revisions";
+ALTER TABLE "responses" DROP COLUMN "edit_count";
+ALTER TABLE "questions" DROP COLUMN "edit_count";
@@ -0,0 +1,29 @@
+ALTER TABLE "questions" ADD COLUMN "edit_count" int NOT NULL DEFAULT 0;
+ALTER TABLE "responses" ADD COLUMN "edit_count" int NOT NULL DEFAULT 0;
+
+-- a revision is a snapshot of the content as it was before an edit replaced it
+CREATE TABLE "question_revisions" (
+    "id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    "question_id" uuid NOT NULL,
+    "title" text NOT NULL,
+    "body" text NULL,
+    "category" text NOT NULL,
+    "image_urls" text[] NULL,
+    "edited_at" timestamptz NOT NULL, -- when this content was written
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp, -- when this content was replaced
+    FOREIGN KEY (question_id) REFERENCES "questions" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX "question_revisions_question_id_idx" ON "question_revisions" ("question_id", "created_at" DESC);
+
+CREATE TABLE "response_revisions" (
+    "id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    "response_id" uuid NOT NULL,
+    "body" text NOT NULL,
+    "image_urls" text[] NULL,
+    "edited_at" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    FOREIGN KEY (response_id) REFERENCES "responses" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX "response_revisions_response_id_idx" ON "response_revisions" ("response_id", "created_at" DESC);
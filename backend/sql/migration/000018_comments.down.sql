@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "comments";
+ALTER TABLE "responses" DROP COLUMN "num_replies";
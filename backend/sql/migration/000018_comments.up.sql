@@ -0,0 +1,14 @@
+ALTER TABLE "responses" ADD COLUMN "num_replies" integer NOT NULL DEFAULT 0;
+
+CREATE TABLE "comments" (
+    "id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    "author_id" text NOT NULL,
+    "response_id" uuid NOT NULL,
+    "body" text NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    "edited_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    FOREIGN KEY (author_id) REFERENCES "users" (id) ON DELETE CASCADE,
+    FOREIGN KEY (response_id) REFERENCES "responses" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX "comments_response_id_idx" ON "comments" ("response_id", "created_at");
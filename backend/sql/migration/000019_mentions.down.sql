@@ -0,0 +1,2 @@
+ALTER TABLE "responses" DROP COLUMN "mentions";
+ALTER TABLE "questions" DROP COLUMN "mentions";
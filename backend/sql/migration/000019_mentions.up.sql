@@ -0,0 +1,3 @@
+-- the resolved mentions of the body, with their ranges, see model.Mention
+ALTER TABLE "questions" ADD COLUMN "mentions" jsonb NOT NULL DEFAULT '[]';
+ALTER TABLE "responses" ADD COLUMN "mentions" jsonb NOT NULL DEFAULT '[]';
@@ -0,0 +1,11 @@
+CREATE TABLE "follows" (
+    "follower_id" text NOT NULL,
+    "followee_id" text NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id),
+    FOREIGN KEY (follower_id) REFERENCES "users" (id) ON DELETE CASCADE,
+    FOREIGN KEY (followee_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX "follows_followee_id_idx" ON "follows" ("followee_id");
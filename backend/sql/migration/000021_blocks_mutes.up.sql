@@ -0,0 +1,21 @@
+CREATE TABLE "user_blocks" (
+    "blocker_id" text NOT NULL,
+    "blocked_id" text NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id),
+    FOREIGN KEY (blocker_id) REFERENCES "users" (id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX "user_blocks_blocked_id_idx" ON "user_blocks" ("blocked_id");
+
+CREATE TABLE "user_mutes" (
+    "muter_id" text NOT NULL,
+    "muted_id" text NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (muter_id, muted_id),
+    CHECK (muter_id <> muted_id),
+    FOREIGN KEY (muter_id) REFERENCES "users" (id) ON DELETE CASCADE,
+    FOREIGN KEY (muted_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
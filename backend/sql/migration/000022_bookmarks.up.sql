@@ -0,0 +1,11 @@
+CREATE TABLE "bookmarks" (
+    "user_id" text NOT NULL,
+    "question_id" uuid NOT NULL,
+    "notify" boolean NOT NULL DEFAULT false, -- push the new responses and the expiry of the question to the user
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (user_id, question_id),
+    FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE,
+    FOREIGN KEY (question_id) REFERENCES "questions" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX "bookmarks_question_id_idx" ON "bookmarks" ("question_id");
@@ -0,0 +1,14 @@
+ALTER TABLE "users" ADD COLUMN "username_changed_at" timestamptz NULL; -- last time the user changed their username, used to rate limit changes
+
+-- usernames that only differ by case were allowed before, the oldest account keeps its username
+-- and the others get a suffix derived from their id (still within 30 characters), which they can change right away
+UPDATE "users" u
+SET "username" = left(u.username, 23) || '_' || left(md5(u.id), 6)
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY lower(username) ORDER BY created_at, id) AS rank
+    FROM "users"
+) d
+WHERE d.id = u.id AND d.rank > 1;
+
+-- usernames are unique regardless of case, like IsUsernameTaken checks
+CREATE UNIQUE INDEX "users_username_lower_idx" ON "users" (lower(username));
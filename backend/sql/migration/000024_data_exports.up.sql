@@ -0,0 +1,15 @@
+CREATE TABLE "data_exports" (
+    "id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    "user_id" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'Pending', -- Pending, Processing, Ready or Failed
+    "object_key" text NULL, -- key of the export archive in the media bucket, once it is Ready
+    "last_error" text NULL,
+    "claimed_at" timestamptz NULL,
+    "completed_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT current_timestamp,
+    FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+-- a user can only have one export in progress
+CREATE UNIQUE INDEX "data_exports_user_in_progress_idx" ON "data_exports" ("user_id") WHERE status IN ('Pending', 'Processing');
+CREATE INDEX "data_exports_status_idx" ON "data_exports" ("status", "created_at");
@@ -0,0 +1,79 @@
+-- name: CreateComment :one
+WITH new_comment AS (
+    INSERT INTO comments (
+        author_id,
+        response_id,
+        body
+    )
+    VALUES ($1, $2, $3)
+    RETURNING *
+)
+SELECT
+    nc.*,
+    sqlc.embed(u),
+    TRUE AS is_owned
+FROM
+    new_comment nc
+    JOIN users u ON nc.author_id = u.id;
+
+-- name: EditComment :one
+WITH edited_comment AS (
+    UPDATE comments
+    SET
+        body = sqlc.arg(body),
+        edited_at = current_timestamp
+    WHERE comments.id = sqlc.arg(id)
+    RETURNING *
+)
+SELECT
+    ec.*,
+    sqlc.embed(u),
+    TRUE AS is_owned
+FROM
+    edited_comment ec
+    JOIN users u ON ec.author_id = u.id;
+
+-- name: DeleteComment :execrows
+DELETE FROM comments
+WHERE id = $1;
+
+-- name: GetCommentByID :one
+SELECT
+    sqlc.embed(c),
+    sqlc.embed(u),
+    c.author_id = sqlc.arg(user_id) AS is_owned
+FROM
+    comments c
+    JOIN users u ON c.author_id = u.id
+WHERE
+    c.id = sqlc.arg(id)
+    LIMIT 1;
+
+-- name: GetCommentsByResponseID :many
+-- comments read as a conversation, so the oldest come first
+SELECT
+    sqlc.embed(c),
+    sqlc.embed(u),
+    c.author_id = sqlc.arg(user_id) AS is_owned
+FROM
+    comments c
+    JOIN users u ON c.author_id = u.id
+WHERE
+    c.response_id = sqlc.arg(response_id) AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = sqlc.arg(user_id) AND b.blocked_id = c.author_id)
+           OR (b.blocker_id = c.author_id AND b.blocked_id = sqlc.arg(user_id))
+    )
+ORDER BY c.created_at, c.id
+LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
+
+-- name: IncrementReplyCount :exec
+UPDATE responses
+SET num_replies = num_replies + 1
+WHERE id = $1;
+
+-- name: DecrementReplyCount :exec
+UPDATE responses
+SET num_replies = GREATEST(num_replies - 1, 0)
+WHERE id = $1;
@@ -0,0 +1,2 @@
+-- name: PublishEvent :exec
+SELECT pg_notify(sqlc.arg(channel)::text, sqlc.arg(payload)::text);
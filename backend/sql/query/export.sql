@@ -0,0 +1,85 @@
+-- name: CreateDataExport :one
+-- returns no rows if the user already has an export in progress
+INSERT INTO data_exports (user_id)
+VALUES ($1)
+ON CONFLICT (user_id) WHERE status IN ('Pending', 'Processing') DO NOTHING
+RETURNING *;
+
+-- name: GetDataExportByID :one
+SELECT * FROM data_exports
+WHERE id = sqlc.arg(export_id) AND user_id = sqlc.arg(user_id);
+
+-- name: ClaimDataExport :one
+-- claims the oldest pending export, or an export whose processing was abandoned before stale_before
+WITH claimable AS (
+    SELECT id
+    FROM data_exports
+    WHERE
+        status = 'Pending' OR
+        (status = 'Processing' AND claimed_at < sqlc.arg(stale_before)::timestamptz)
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE data_exports e
+SET
+    status = 'Processing',
+    claimed_at = now()
+FROM claimable c
+WHERE e.id = c.id
+RETURNING e.*;
+
+-- name: CompleteDataExport :execrows
+UPDATE data_exports
+SET
+    status = 'Ready',
+    object_key = sqlc.arg(object_key),
+    completed_at = now()
+WHERE id = sqlc.arg(export_id) AND status = 'Processing';
+
+-- name: FailDataExport :exec
+UPDATE data_exports
+SET
+    status = 'Failed',
+    last_error = sqlc.arg(last_error),
+    completed_at = now()
+WHERE id = sqlc.arg(export_id);
+
+-- name: GetPollVotesByUserID :many
+SELECT
+    q.id AS question_id,
+    q.title AS question_title,
+    po.label AS option_label,
+    pv.other_text,
+    pv.rank,
+    pv.created_at
+FROM poll_votes pv
+    JOIN polls p ON pv.poll_id = p.id
+    JOIN questions q ON p.question_id = q.id
+    LEFT JOIN poll_options po ON pv.option_id = po.id
+WHERE pv.user_id = $1
+ORDER BY pv.created_at, pv.rank;
+
+-- name: GetRatingAnswersByUserID :many
+SELECT
+    q.id AS question_id,
+    q.title AS question_title,
+    ra.value,
+    ra.updated_at
+FROM rating_answers ra
+    JOIN ratings r ON ra.rating_id = r.id
+    JOIN questions q ON r.question_id = q.id
+WHERE ra.user_id = $1
+ORDER BY ra.updated_at;
+
+-- name: GetConfirmAnswersByUserID :many
+SELECT
+    q.id AS question_id,
+    q.title AS question_title,
+    ca.is_yes,
+    ca.updated_at
+FROM confirm_answers ca
+    JOIN confirms c ON ca.confirm_id = c.id
+    JOIN questions q ON c.question_id = q.id
+WHERE ca.user_id = $1
+ORDER BY ca.updated_at;
@@ -0,0 +1,104 @@
+-- name: CreateOutboxMessage :exec
+INSERT INTO notification_outbox (type, payload)
+VALUES (sqlc.arg(message_type), sqlc.arg(payload));
+
+-- name: ClaimOutboxMessages :many
+WITH claimable AS (
+    SELECT id
+    FROM notification_outbox
+    WHERE
+        (status = 'Pending' AND available_at <= now()) OR
+        (status = 'Processing' AND locked_until < now())
+    ORDER BY available_at
+    LIMIT sqlc.arg(limit_num)
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE notification_outbox o
+SET
+    status = 'Processing',
+    attempts = o.attempts + 1,
+    locked_until = sqlc.arg(locked_until)::timestamptz
+FROM claimable c
+WHERE o.id = c.id
+RETURNING o.*;
+
+-- name: MarkOutboxMessageDelivered :exec
+UPDATE notification_outbox
+SET
+    status = 'Delivered',
+    delivered_at = now(),
+    locked_until = NULL
+WHERE id = $1;
+
+-- name: RetryOutboxMessage :exec
+UPDATE notification_outbox
+SET
+    status = 'Pending',
+    last_error = sqlc.arg(last_error)::text,
+    available_at = sqlc.arg(available_at),
+    locked_until = NULL
+WHERE id = sqlc.arg(id);
+
+-- name: FailOutboxMessage :exec
+UPDATE notification_outbox
+SET
+    status = 'Failed',
+    last_error = sqlc.arg(last_error)::text,
+    locked_until = NULL
+WHERE id = sqlc.arg(id);
+
+-- name: CreatePushTickets :copyfrom
+INSERT INTO push_tickets (ticket_id, user_id, token)
+VALUES ($1, $2, $3);
+
+-- name: GetPushTicketsCreatedBefore :many
+SELECT *
+FROM push_tickets
+WHERE created_at <= sqlc.arg(created_before)
+ORDER BY created_at
+LIMIT sqlc.arg(limit_num);
+
+-- name: DeletePushTickets :exec
+DELETE FROM push_tickets
+WHERE ticket_id = ANY(sqlc.arg(ticket_ids)::text[]);
+
+-- name: CreateNotifications :many
+-- returns the users that did not have the notification yet
+INSERT INTO notifications (user_id, type, title, body, data, dedupe_key)
+SELECT
+    unnest(sqlc.arg(user_ids)::text[]),
+    sqlc.arg(notification_type),
+    sqlc.arg(title),
+    sqlc.arg(body),
+    sqlc.arg(data),
+    sqlc.narg(dedupe_key)
+ON CONFLICT (user_id, dedupe_key) DO NOTHING
+RETURNING user_id;
+
+-- name: GetNotificationsByUserID :many
+SELECT *
+FROM notifications
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
+
+-- name: GetUnreadNotificationCount :one
+SELECT COUNT(*)
+FROM notifications
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: MarkNotificationRead :one
+UPDATE notifications
+SET read_at = COALESCE(read_at, now())
+WHERE user_id = $1 AND id = $2
+RETURNING id;
+
+-- name: MarkAllNotificationsRead :exec
+UPDATE notifications
+SET read_at = now()
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: DeleteNotification :one
+DELETE FROM notifications
+WHERE user_id = $1 AND id = $2
+RETURNING id;
@@ -27,7 +27,10 @@ WITH edited_question AS (
         title = sqlc.arg(title),
         body = sqlc.arg(body),
         category = sqlc.arg(category),
-        edited_at = current_timestamp
+        -- a null list keeps the current images
+        image_urls = coalesce(sqlc.narg(image_urls)::text[], image_urls),
+        edited_at = current_timestamp,
+        edit_count = edit_count + 1
     WHERE questions.id = sqlc.arg(id)
     RETURNING *
 )
@@ -35,33 +38,169 @@ SELECT
     eq.*,
     sqlc.embed(l),
     sqlc.embed(u),
-    TRUE AS is_owned
+    TRUE AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = eq.id AND bm.user_id = eq.author_id
+    ) AS is_bookmarked
 FROM
     edited_question eq
         JOIN users u ON eq.author_id = u.id
         JOIN locations l ON eq.id = l.question_id;
 
--- name: DeleteQuestion :exec
-DELETE FROM questions
-WHERE questions.id = sqlc.arg(id);
+-- name: CreateQuestionRevision :one
+-- snapshots the current content before it is edited, the row stays locked until the end of the transaction
+INSERT INTO question_revisions (question_id, title, body, category, image_urls, edited_at)
+SELECT id, title, body, category, image_urls, edited_at
+FROM questions
+WHERE questions.id = $1
+FOR UPDATE
+RETURNING image_urls;
 
--- name: SetQuestionContentType :exec
+-- name: DropRemovedQuestionRevisionImages :exec
+-- the images removed from the question are deleted, so its revisions only keep the images the question still has
+UPDATE question_revisions r
+SET image_urls = ARRAY(SELECT url FROM unnest(r.image_urls) AS url WHERE url = ANY(q.image_urls))
+FROM questions q
+WHERE q.id = r.question_id AND r.question_id = $1 AND NOT (r.image_urls <@ COALESCE(q.image_urls, '{}'));
+
+-- name: GetQuestionMentions :one
+SELECT mentions
+FROM questions
+WHERE id = $1;
+
+-- name: SetQuestionMentions :exec
 UPDATE questions
-SET content_type = $2
+SET mentions = $2
 WHERE id = $1;
 
+-- name: GetQuestionRevisions :many
+SELECT *
+FROM question_revisions
+WHERE question_id = sqlc.arg(question_id)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
+
+-- name: SoftDeleteQuestion :execrows
+UPDATE questions
+SET deleted_at = current_timestamp
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetDeletedQuestionByID :one
+SELECT id, author_id, image_urls, deleted_at::timestamptz AS deleted_at
+FROM questions
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: RestoreQuestion :execrows
+UPDATE questions
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at > sqlc.arg(deleted_after)::timestamptz;
+
+-- name: GetQuestionsDeletedBefore :many
+SELECT id, author_id, image_urls, deleted_at::timestamptz AS deleted_at
+FROM questions
+WHERE deleted_at <= sqlc.arg(deleted_before)::timestamptz
+ORDER BY deleted_at
+LIMIT sqlc.arg(limit_num);
+
+-- name: HardDeleteQuestion :execrows
+-- responses, locations and question content are deleted along with the question
+DELETE FROM questions
+WHERE id = $1 AND deleted_at <= sqlc.arg(deleted_before)::timestamptz;
+
+-- name: ExtendQuestion :execrows
+-- the limits are checked by the update itself, so that concurrent extensions cannot exceed them
+UPDATE questions
+SET
+    expired_at = expired_at + make_interval(secs => sqlc.arg(duration_secs)::float8),
+    num_extensions = num_extensions + 1
+WHERE
+    id = sqlc.arg(question_id) AND
+    num_extensions < sqlc.arg(max_extensions)::int AND
+    expired_at > now() AND
+    deleted_at IS NULL AND
+    expired_at + make_interval(secs => sqlc.arg(duration_secs)::float8) <=
+        COALESCE(reopened_at, created_at) + make_interval(secs => sqlc.arg(max_lifetime_secs)::float8);
+
+-- name: ReopenQuestion :execrows
+-- the summary is generated again when the reopened question expires.
+-- a question can only be reopened once, which is checked by the update itself, so that concurrent requests cannot both reopen it
+UPDATE questions
+SET
+    expired_at = now() + make_interval(secs => sqlc.arg(duration_secs)::float8),
+    reopened_at = now(),
+    status = 'Active',
+    summary = NULL
+WHERE
+    id = sqlc.arg(question_id) AND
+    reopened_at IS NULL AND
+    expired_at <= now() AND
+    status <> 'Archived' AND
+    deleted_at IS NULL;
+
+-- name: ReopenExpiredPoll :exec
+-- opens the poll of a question that is about to be reopened again, if it only closed because the question expired.
+-- a poll closed by its owner, or by its own closing time, stays closed
+UPDATE polls p
+SET
+    closed_at = NULL,
+    final_results = NULL
+FROM questions q
+WHERE
+    q.id = p.question_id AND
+    p.question_id = sqlc.arg(question_id) AND
+    (p.closed_at IS NULL OR p.closed_at >= q.expired_at) AND
+    (p.closes_at IS NULL OR p.closes_at > now());
+
+-- name: GetQuestionIDsToExpire :many
+SELECT id
+FROM questions
+WHERE status = 'Active' AND expired_at <= now() AND deleted_at IS NULL
+ORDER BY expired_at
+LIMIT $1;
+
+-- name: ExpireQuestion :execrows
+-- does nothing if another instance already expired the question, or it was extended or reopened meanwhile
+UPDATE questions
+SET
+    status = 'Expired',
+    summary = $2
+WHERE id = $1 AND status = 'Active' AND expired_at <= now();
+
+-- name: GetQuestionResponderIDs :many
+SELECT DISTINCT r.author_id
+FROM
+    responses r
+    JOIN questions q ON q.id = r.question_id
+WHERE r.question_id = $1 AND r.author_id <> q.author_id AND r.deleted_at IS NULL;
+
+-- name: SetQuestionContentType :execrows
+-- only a question without content can get content, so that concurrent requests cannot both add content
+UPDATE questions
+SET content_type = sqlc.arg(content_type)
+WHERE id = sqlc.arg(question_id) AND content_type = 'None';
+
 -- name: GetQuestionByID :one
 SELECT
     sqlc.embed(q),
     sqlc.embed(u),
     sqlc.embed(l),
-    q.author_id = $2 AS is_owned
+    q.author_id = $2 AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = $2
+    ) AS is_bookmarked
 FROM
     questions q
     JOIN users u ON q.author_id = u.id
     JOIN locations l ON q.id = l.question_id
 WHERE
-    q.id = $1
+    q.id = $1 AND q.deleted_at IS NULL AND
+    -- the question is hidden from users its author blocked
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = q.author_id AND b.blocked_id = $2
+    )
     LIMIT 1;
 
 -- name: GetQuestionsInRadiusFeed :many
@@ -69,11 +208,21 @@ SELECT
     sqlc.embed(q),
     sqlc.embed(l),
     sqlc.embed(u),
-    q.author_id = sqlc.arg(user_id) AS is_owned
+    q.author_id = sqlc.arg(user_id) AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = sqlc.arg(user_id)
+    ) AS is_bookmarked
 FROM questions q
          JOIN users u ON q.author_id = u.id
          JOIN locations l ON q.id = l.question_id
-WHERE q.expired_at > now() AND
+-- questions of users blocked by or blocking the viewer are hidden
+WHERE q.expired_at > now() AND q.deleted_at IS NULL AND
+      NOT EXISTS (
+          SELECT 1 FROM user_blocks b
+          WHERE (b.blocker_id = sqlc.arg(user_id) AND b.blocked_id = q.author_id)
+             OR (b.blocker_id = q.author_id AND b.blocked_id = sqlc.arg(user_id))
+      ) AND
       ST_DWithin(
               l.location::geography,
               ST_SetSRID(
@@ -93,13 +242,23 @@ SELECT
     sqlc.embed(q),
     sqlc.embed(l),
     sqlc.embed(u),
-    q.author_id = sqlc.arg(user_id) AS is_owned
+    q.author_id = sqlc.arg(user_id) AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = sqlc.arg(user_id)
+    ) AS is_bookmarked
 FROM questions q
          JOIN users u ON q.author_id = u.id
          JOIN locations l ON q.id = l.question_id
 WHERE
     q.category = sqlc.arg(category) AND
     q.expired_at > now() AND
+    q.deleted_at IS NULL AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = sqlc.arg(user_id) AND b.blocked_id = q.author_id)
+           OR (b.blocker_id = q.author_id AND b.blocked_id = sqlc.arg(user_id))
+    ) AND
     ST_DWithin(
               l.location::geography,
               ST_SetSRID(
@@ -116,8 +275,16 @@ LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
 
 
 -- name: CreatePoll :one
-INSERT INTO polls (question_id)
-VALUES ($1)
+INSERT INTO polls (question_id, type, max_selections, allow_other, results_visibility, is_anonymous, closes_at)
+VALUES (
+    sqlc.arg(question_id),
+    sqlc.arg(type),
+    sqlc.arg(max_selections),
+    sqlc.arg(allow_other),
+    sqlc.arg(results_visibility),
+    sqlc.arg(is_anonymous),
+    sqlc.narg(closes_at)
+)
 RETURNING *;
 
 -- name: CreatePollOptions :copyfrom
@@ -139,29 +306,124 @@ GROUP BY po.id
 ORDER BY po.index;
 
 -- name: GetPollVotes :many
+-- for RankedChoice polls, only first preferences are counted as votes
 SELECT
     po.index,
-    COUNT(pv.user_id)         AS num_votes,
-    BOOL_OR(pv.user_id = $2)  AS is_selected
+    COUNT(pv.user_id) FILTER (WHERE pv.rank IS NULL OR pv.rank = 1) AS num_votes,
+    BOOL_OR(pv.user_id = $2)                                        AS is_selected,
+    COALESCE(MIN(pv.rank) FILTER (WHERE pv.user_id = $2), 0)::int   AS user_rank
 FROM poll_options po
     JOIN poll_votes pv ON pv.option_id = po.id
 WHERE po.poll_id = $1
 GROUP BY po.id;
 
+-- name: GetPollOtherVotes :many
+SELECT
+    other_text::text       AS other_text,
+    user_id = $2           AS is_own
+FROM poll_votes
+WHERE poll_id = $1 AND option_id IS NULL
+ORDER BY created_at DESC;
+
+-- name: GetPollNumVoters :one
+SELECT COUNT(DISTINCT user_id)
+FROM poll_votes
+WHERE poll_id = $1;
+
+-- name: GetPollOptionVoters :many
+SELECT
+    sqlc.embed(u),
+    pv.rank
+FROM poll_votes pv
+    JOIN users u ON pv.user_id = u.id
+WHERE pv.option_id = $1
+ORDER BY pv.created_at DESC, u.id
+LIMIT $2 OFFSET $3;
+
+-- name: GetPollBallots :many
+-- the ranked options of every voter, in order of preference
+SELECT user_id, option_id::uuid AS option_id
+FROM poll_votes
+WHERE poll_id = $1 AND option_id IS NOT NULL
+ORDER BY user_id, rank;
+
 -- name: IsPollExpired :one
-SELECT q.expired_at < now() AS is_expired
+-- a poll closes early if it was closed by its owner or its closes_at is before the question's expiry
+SELECT (p.closed_at IS NOT NULL OR LEAST(p.closes_at, q.expired_at) < now())::boolean AS is_expired
 FROM
     polls p
     JOIN questions q ON q.id = p.question_id
 WHERE p.id = $1;
 
+-- name: LockOpenPoll :one
+-- keeps the poll from being edited or closed until the end of the transaction
+SELECT id
+FROM polls
+WHERE id = $1 AND closed_at IS NULL
+FOR SHARE;
+
+-- name: LockPollForEdit :one
+SELECT
+    sqlc.embed(p),
+    (SELECT COUNT(*) FROM poll_votes pv WHERE pv.poll_id = p.id)::int AS num_votes
+FROM polls p
+WHERE p.id = $1
+FOR UPDATE;
+
+-- name: GetPollMaxBallotSize :one
+-- the most selections any single voter made on the poll
+SELECT COALESCE(MAX(b.num_selections), 0)::int AS max_ballot_size
+FROM (
+    SELECT COUNT(*) AS num_selections
+    FROM poll_votes
+    WHERE poll_id = $1
+    GROUP BY user_id
+) b;
+
+-- name: DeletePollOptions :exec
+DELETE FROM poll_options
+WHERE poll_id = $1;
+
+-- name: UpdatePollSettings :exec
+UPDATE polls
+SET
+    max_selections = sqlc.arg(max_selections),
+    closes_at = sqlc.narg(closes_at)
+WHERE id = sqlc.arg(id);
+
+-- name: ClosePoll :exec
+-- polls closed by the poll closer are closed at the time they were due
+UPDATE polls p
+SET closed_at = LEAST(now(), p.closes_at, q.expired_at)
+FROM questions q
+WHERE q.id = p.question_id AND p.id = $1 AND p.closed_at IS NULL;
+
+-- name: GetPollIDsToFinalize :many
+SELECT p.id
+FROM
+    polls p
+    JOIN questions q ON q.id = p.question_id
+WHERE p.final_results IS NULL AND q.deleted_at IS NULL AND (p.closed_at IS NOT NULL OR LEAST(p.closes_at, q.expired_at) <= now())
+ORDER BY p.closes_at NULLS LAST
+LIMIT $1;
+
+-- name: SetPollFinalResults :execrows
+UPDATE polls
+SET final_results = $2
+WHERE id = $1 AND final_results IS NULL;
+
+-- name: GetPollVoterIDs :many
+SELECT DISTINCT user_id
+FROM poll_votes
+WHERE poll_id = $1;
+
 -- name: DeletePollVote :exec
 DELETE FROM poll_votes
 WHERE user_id = $1 AND poll_id = $2;
 
 -- name: CreatePollVote :exec
-INSERT INTO poll_votes (poll_id, option_id, user_id)
-VALUES ($1, $2, $3);
+INSERT INTO poll_votes (poll_id, option_id, user_id, rank, other_text)
+VALUES ($1, $2, $3, $4, $5);
 
 -- name: IncrementResponseAmount :exec
 UPDATE questions
@@ -184,11 +446,238 @@ SELECT
     sqlc.embed(q),
     sqlc.embed(l),
     sqlc.embed(u),
-    q.author_id = sqlc.arg(user_id) AS is_owned
+    q.author_id = sqlc.arg(user_id) AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = sqlc.arg(user_id)
+    ) AS is_bookmarked
 FROM questions q
          JOIN users u ON q.author_id = u.id
          JOIN locations l ON q.id = l.question_id
 WHERE
-    q.author_id = sqlc.arg(user_id)
+    q.author_id = sqlc.arg(user_id) AND q.deleted_at IS NULL
+ORDER BY q.created_at DESC, q.id DESC
+LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
+
+-- name: GetPublicQuestionsByAuthorID :many
+-- the questions of another user, hidden if either of them blocks the other
+SELECT
+    sqlc.embed(q),
+    sqlc.embed(l),
+    sqlc.embed(u),
+    q.author_id = sqlc.arg(user_id) AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = sqlc.arg(user_id)
+    ) AS is_bookmarked
+FROM questions q
+    JOIN users u ON q.author_id = u.id
+    JOIN locations l ON q.id = l.question_id
+WHERE
+    q.author_id = sqlc.arg(author_id) AND q.deleted_at IS NULL AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = sqlc.arg(user_id) AND b.blocked_id = q.author_id)
+           OR (b.blocker_id = q.author_id AND b.blocked_id = sqlc.arg(user_id))
+    )
+ORDER BY q.created_at DESC, q.id DESC
+LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
+
+-- name: GetQuestionsFromFollowedUsers :many
+SELECT
+    sqlc.embed(q),
+    sqlc.embed(l),
+    sqlc.embed(u),
+    FALSE AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = sqlc.arg(user_id)
+    ) AS is_bookmarked
+FROM questions q
+    JOIN follows f ON f.followee_id = q.author_id
+    JOIN users u ON q.author_id = u.id
+    JOIN locations l ON q.id = l.question_id
+WHERE
+    f.follower_id = sqlc.arg(user_id) AND q.deleted_at IS NULL AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = sqlc.arg(user_id) AND b.blocked_id = q.author_id)
+           OR (b.blocker_id = q.author_id AND b.blocked_id = sqlc.arg(user_id))
+    )
 ORDER BY q.created_at DESC, q.id DESC
-LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
\ No newline at end of file
+LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
+
+-- name: GetQuestionIDByPollID :one
+SELECT question_id
+FROM polls
+WHERE id = $1;
+
+-- name: GetQuestionsExpiredBetween :many
+SELECT q.id, q.expired_at, sqlc.embed(l)
+FROM
+    questions q
+    JOIN locations l ON q.id = l.question_id
+WHERE q.expired_at > sqlc.arg(expired_after) AND q.expired_at <= sqlc.arg(expired_until) AND q.deleted_at IS NULL;
+
+-- name: GetQuestionsToArchive :many
+SELECT id, status, expired_at, num_responses, image_urls
+FROM questions
+WHERE status = 'Expired' AND expired_at <= sqlc.arg(expired_before) AND deleted_at IS NULL
+ORDER BY expired_at
+LIMIT sqlc.arg(limit_num);
+
+-- name: ArchiveQuestions :execrows
+-- the conditions are checked again, in case a question was reopened meanwhile
+UPDATE questions
+SET status = 'Archived'
+WHERE id = ANY(sqlc.arg(ids)::uuid[]) AND status = 'Expired' AND expired_at <= sqlc.arg(expired_before);
+
+-- name: GetQuestionsToPurge :many
+SELECT id, status, expired_at, num_responses, image_urls
+FROM questions
+WHERE status = 'Archived' AND expired_at <= sqlc.arg(expired_before) AND deleted_at IS NULL
+ORDER BY expired_at
+LIMIT sqlc.arg(limit_num);
+
+-- name: GetQuestionResponseImageURLs :many
+SELECT unnest(image_urls)::text AS image_url
+FROM responses
+WHERE question_id = $1;
+
+-- name: PurgeQuestion :execrows
+-- responses, locations and question content are deleted along with the question
+DELETE FROM questions
+WHERE id = $1 AND status = 'Archived' AND expired_at <= sqlc.arg(expired_before);
+
+-- name: CreateRating :one
+INSERT INTO ratings (question_id, min_value, max_value, min_label, max_label)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetRatingByQuestionID :one
+SELECT sqlc.embed(r)
+FROM ratings r
+WHERE r.question_id = $1;
+
+-- name: GetQuestionIDByRatingID :one
+SELECT question_id
+FROM ratings
+WHERE id = $1;
+
+-- name: GetRatingHistogram :many
+SELECT
+    value,
+    COUNT(user_id)           AS num_answers,
+    BOOL_OR(user_id = $2)    AS is_selected
+FROM rating_answers
+WHERE rating_id = $1
+GROUP BY value
+ORDER BY value;
+
+-- name: UpsertRatingAnswer :execrows
+-- nothing is inserted if the question's author blocked the user
+INSERT INTO rating_answers (rating_id, user_id, value)
+SELECT $1, $2, $3
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM ratings r
+        JOIN questions q ON q.id = r.question_id
+        JOIN user_blocks b ON b.blocker_id = q.author_id
+    WHERE r.id = $1 AND b.blocked_id = $2
+)
+ON CONFLICT (rating_id, user_id) DO UPDATE
+SET
+    value = EXCLUDED.value,
+    updated_at = now();
+
+-- name: DeleteRatingAnswer :exec
+DELETE FROM rating_answers
+WHERE rating_id = $1 AND user_id = $2;
+
+-- name: CreateConfirm :one
+INSERT INTO confirms (question_id)
+VALUES ($1)
+RETURNING *;
+
+-- name: GetConfirmByQuestionID :one
+SELECT sqlc.embed(c)
+FROM confirms c
+WHERE c.question_id = $1;
+
+-- name: GetQuestionIDByConfirmID :one
+SELECT question_id
+FROM confirms
+WHERE id = $1;
+
+-- name: GetConfirmTally :one
+-- every answer weighs 0.5^(age / half life), so that recent answers count more
+SELECT
+    COUNT(*) FILTER (WHERE is_yes)     AS num_yes,
+    COUNT(*) FILTER (WHERE NOT is_yes) AS num_no,
+    COALESCE(SUM(power(0.5::float8, EXTRACT(EPOCH FROM now() - updated_at)::float8 / sqlc.arg(half_life_seconds)::float8)) FILTER (WHERE is_yes), 0)::float8     AS yes_weight,
+    COALESCE(SUM(power(0.5::float8, EXTRACT(EPOCH FROM now() - updated_at)::float8 / sqlc.arg(half_life_seconds)::float8)) FILTER (WHERE NOT is_yes), 0)::float8 AS no_weight
+FROM confirm_answers
+WHERE confirm_id = sqlc.arg(confirm_id);
+
+-- name: GetConfirmAnswer :one
+SELECT is_yes
+FROM confirm_answers
+WHERE confirm_id = $1 AND user_id = $2;
+
+-- name: UpsertConfirmAnswer :execrows
+-- nothing is inserted if the question's author blocked the user
+INSERT INTO confirm_answers (confirm_id, user_id, is_yes)
+SELECT $1, $2, $3
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM confirms c
+        JOIN questions q ON q.id = c.question_id
+        JOIN user_blocks b ON b.blocker_id = q.author_id
+    WHERE c.id = $1 AND b.blocked_id = $2
+)
+ON CONFLICT (confirm_id, user_id) DO UPDATE
+SET
+    is_yes = EXCLUDED.is_yes,
+    updated_at = now();
+
+-- name: DeleteConfirmAnswer :exec
+DELETE FROM confirm_answers
+WHERE confirm_id = $1 AND user_id = $2;
+
+-- name: BookmarkQuestion :exec
+INSERT INTO bookmarks (user_id, question_id, notify)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, question_id) DO UPDATE
+SET notify = excluded.notify;
+
+-- name: UnbookmarkQuestion :exec
+DELETE FROM bookmarks
+WHERE user_id = $1 AND question_id = $2;
+
+-- name: GetBookmarkedQuestions :many
+-- bookmarks outlive the feed, so expired questions are included
+SELECT
+    sqlc.embed(q),
+    sqlc.embed(l),
+    sqlc.embed(u),
+    q.author_id = sqlc.arg(user_id) AS is_owned,
+    TRUE AS is_bookmarked
+FROM bookmarks bm
+    JOIN questions q ON bm.question_id = q.id
+    JOIN users u ON q.author_id = u.id
+    JOIN locations l ON q.id = l.question_id
+WHERE
+    bm.user_id = sqlc.arg(user_id) AND q.deleted_at IS NULL AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = sqlc.arg(user_id) AND b.blocked_id = q.author_id)
+           OR (b.blocker_id = q.author_id AND b.blocked_id = sqlc.arg(user_id))
+    )
+ORDER BY bm.created_at DESC, q.id DESC
+LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
+
+-- name: GetBookmarkSubscriberIDs :many
+-- the users that bookmarked the question and asked to be notified about it
+SELECT user_id
+FROM bookmarks
+WHERE question_id = $1 AND notify;
@@ -6,7 +6,14 @@ WITH new_response AS (
         body,
         image_urls
     )
-    VALUES ($1, $2, $3, $4)
+    -- nothing is inserted if the question's author blocked the responder
+    SELECT $1, $2, $3, $4
+    WHERE NOT EXISTS (
+        SELECT 1
+        FROM questions q
+            JOIN user_blocks b ON b.blocker_id = q.author_id
+        WHERE q.id = $2 AND b.blocked_id = $1
+    )
     RETURNING *
 )
 SELECT
@@ -22,7 +29,10 @@ WITH edited_response AS (
     UPDATE responses
     SET
         body = sqlc.arg(body),
-        edited_at = current_timestamp
+        -- a null list keeps the current images
+        image_urls = coalesce(sqlc.narg(image_urls)::text[], image_urls),
+        edited_at = current_timestamp,
+        edit_count = edit_count + 1
     WHERE responses.id = sqlc.arg(id)
     RETURNING *
 )
@@ -34,8 +44,64 @@ FROM
     edited_response er
         JOIN users u ON er.author_id = u.id;
 
--- name: DeleteResponse :exec
-DELETE FROM responses WHERE id = $1;
+-- name: CreateResponseRevision :one
+-- snapshots the current content before it is edited, the row stays locked until the end of the transaction
+INSERT INTO response_revisions (response_id, body, image_urls, edited_at)
+SELECT id, body, image_urls, edited_at
+FROM responses
+WHERE responses.id = $1
+FOR UPDATE
+RETURNING image_urls;
+
+-- name: DropRemovedResponseRevisionImages :exec
+-- the images removed from the response are deleted, so its revisions only keep the images the response still has
+UPDATE response_revisions rr
+SET image_urls = ARRAY(SELECT url FROM unnest(rr.image_urls) AS url WHERE url = ANY(r.image_urls))
+FROM responses r
+WHERE r.id = rr.response_id AND rr.response_id = $1 AND NOT (rr.image_urls <@ COALESCE(r.image_urls, '{}'));
+
+-- name: GetResponseMentions :one
+SELECT mentions
+FROM responses
+WHERE id = $1;
+
+-- name: SetResponseMentions :exec
+UPDATE responses
+SET mentions = $2
+WHERE id = $1;
+
+-- name: GetResponseRevisions :many
+SELECT *
+FROM response_revisions
+WHERE response_id = sqlc.arg(response_id)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
+
+-- name: SoftDeleteResponse :execrows
+UPDATE responses
+SET deleted_at = current_timestamp
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetDeletedResponseByID :one
+SELECT id, author_id, question_id, image_urls, deleted_at::timestamptz AS deleted_at
+FROM responses
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: RestoreResponse :execrows
+UPDATE responses
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at > sqlc.arg(deleted_after)::timestamptz;
+
+-- name: GetResponsesDeletedBefore :many
+SELECT id, author_id, question_id, image_urls, deleted_at::timestamptz AS deleted_at
+FROM responses
+WHERE deleted_at <= sqlc.arg(deleted_before)::timestamptz
+ORDER BY deleted_at
+LIMIT sqlc.arg(limit_num);
+
+-- name: HardDeleteResponse :execrows
+DELETE FROM responses
+WHERE id = $1 AND deleted_at <= sqlc.arg(deleted_before)::timestamptz;
 
 -- name: GetResponseByID :one
 SELECT
@@ -46,7 +112,7 @@ FROM
     responses r
     JOIN users u ON r.author_id = u.id
 WHERE
-    r.id = sqlc.arg(id)
+    r.id = sqlc.arg(id) AND r.deleted_at IS NULL
     LIMIT 1;
 
 -- name: GetResponsesByQuestionID :many
@@ -57,23 +123,55 @@ SELECT
 FROM
     responses r
     JOIN users u ON r.author_id = u.id
+    JOIN questions q ON r.question_id = q.id
 WHERE
-    r.question_id = sqlc.arg(question_id)
+    r.question_id = sqlc.arg(question_id) AND r.deleted_at IS NULL AND q.deleted_at IS NULL AND
+    -- responses of users blocked by or blocking the viewer are hidden
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = sqlc.arg(user_id) AND b.blocked_id = r.author_id)
+           OR (b.blocker_id = r.author_id AND b.blocked_id = sqlc.arg(user_id))
+    )
 ORDER BY r.created_at DESC
 LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
 
 
+-- name: GetPublicResponsesByAuthorID :many
+-- the responses of another user, hidden if either of them blocks the other, or if the question's author blocks the viewer
+SELECT
+    sqlc.embed(r),
+    sqlc.embed(u),
+    r.author_id = sqlc.arg(user_id) AS is_owned
+FROM
+    responses r
+    JOIN users u ON r.author_id = u.id
+    JOIN questions q ON r.question_id = q.id
+WHERE
+    r.author_id = sqlc.arg(author_id) AND r.deleted_at IS NULL AND q.deleted_at IS NULL AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE (b.blocker_id = sqlc.arg(user_id) AND b.blocked_id = r.author_id)
+           OR (b.blocker_id = r.author_id AND b.blocked_id = sqlc.arg(user_id))
+           OR (b.blocker_id = q.author_id AND b.blocked_id = sqlc.arg(user_id))
+    )
+ORDER BY r.created_at DESC, r.id DESC
+LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
+
 -- name: GetQuestionsRespondedByUserID :many
 SELECT
     sqlc.embed(q),
     sqlc.embed(l),
     sqlc.embed(u),
-    q.author_id = sqlc.arg(user_id) AS is_owned
+    q.author_id = sqlc.arg(user_id) AS is_owned,
+    EXISTS (
+        SELECT 1 FROM bookmarks bm
+        WHERE bm.question_id = q.id AND bm.user_id = sqlc.arg(user_id)
+    ) AS is_bookmarked
 FROM questions q
     JOIN responses r ON r.question_id = q.id
     JOIN users u ON q.author_id = u.id
     JOIN locations l ON q.id = l.question_id
 WHERE
-    r.author_id = sqlc.arg(user_id)
+    r.author_id = sqlc.arg(user_id) AND r.deleted_at IS NULL AND q.deleted_at IS NULL
 ORDER BY r.created_at DESC, q.created_at DESC, q.id DESC
 LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
\ No newline at end of file
@@ -8,42 +8,224 @@ SELECT *
 FROM users
 WHERE id = $1 LIMIT 1;
 
+-- name: GetPublicUserByID :one
+-- users that blocked the viewer cannot be looked up by them
+SELECT *
+FROM users
+WHERE id = sqlc.arg(user_id) AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = users.id AND b.blocked_id = sqlc.arg(viewer_id)
+    )
+LIMIT 1;
+
+-- name: GetPublicUserByUsername :one
+SELECT *
+FROM users
+WHERE lower(username) = lower(sqlc.arg(username)) AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = users.id AND b.blocked_id = sqlc.arg(viewer_id)
+    )
+LIMIT 1;
+
 -- name: UpdateUserLocation :exec
 UPDATE users
 SET last_known_location = ST_SetSRID(ST_MakePoint(sqlc.arg(longitude)::float8, sqlc.arg(latitude)::float8), 4326)
 WHERE id = $1;
 
--- name: UpdateUserPushToken :exec
-UPDATE users
-SET expo_push_token = $2
-WHERE id = $1;
+-- name: UpsertUserDevice :exec
+INSERT INTO user_devices (user_id, token, platform, app_version)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (token) DO UPDATE
+SET
+    user_id = EXCLUDED.user_id,
+    platform = EXCLUDED.platform,
+    app_version = EXCLUDED.app_version,
+    last_seen_at = now();
 
--- name: DeleteUserPushToken :exec
-UPDATE users
-SET expo_push_token = NULL
-WHERE id = $1;
+-- name: DeleteUserDevice :exec
+DELETE FROM user_devices
+WHERE user_id = $1 AND token = $2;
 
--- name: GetUsersInRadius :many
-SELECT id, expo_push_token
+-- name: DeleteAllUserDevices :exec
+DELETE FROM user_devices
+WHERE user_id = $1;
+
+-- name: GetUserIDsInRadius :many
+SELECT id
 FROM users
 WHERE ST_DWithin(
     last_known_location,
     ST_SetSRID(ST_MakePoint(sqlc.arg(longitude)::float8, sqlc.arg(latitude)::float8), 4326)::geography,
     sqlc.arg(radius_meters)::float8
-)
-AND expo_push_token IS NOT NULL;
+);
+
+-- name: GetPushRecipients :many
+SELECT user_id, token
+FROM user_devices
+WHERE user_id = ANY(sqlc.arg(user_ids)::text[])
+AND last_seen_at >= sqlc.arg(active_since);
 
 -- name: GetUserQuestionCount :one
 SELECT COUNT(*) FROM questions
-WHERE author_id = $1;
+WHERE author_id = $1 AND deleted_at IS NULL;
 
 -- name: GetUserResponseCount :one
 SELECT COUNT(*) FROM responses
-WHERE author_id = $1;
+WHERE author_id = $1 AND deleted_at IS NULL;
 
 
--- name: UpdateUserDisplayName :one
+-- name: EditUser :one
+-- null fields are left unchanged, an empty about me or avatar url clears it
 UPDATE users
-SET display_name = $1
-WHERE id = $2
-RETURNING id, username, email, display_name, role, about_me, avatar_url, created_at, last_known_location, expo_push_token;
\ No newline at end of file
+SET
+    display_name = coalesce(sqlc.narg(display_name)::text, display_name),
+    username = coalesce(sqlc.narg(username)::text, username),
+    username_changed_at = CASE
+        WHEN sqlc.narg(username)::text <> username THEN current_timestamp
+        ELSE username_changed_at
+    END,
+    about_me = CASE
+        WHEN sqlc.narg(about_me)::text IS NULL THEN about_me
+        ELSE nullif(sqlc.narg(about_me)::text, '')
+    END,
+    avatar_url = CASE
+        WHEN sqlc.narg(avatar_url)::text IS NULL THEN avatar_url
+        ELSE nullif(sqlc.narg(avatar_url)::text, '')
+    END
+WHERE id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: IsUsernameTaken :one
+-- usernames are compared case-insensitively, so that mentions are not ambiguous to readers
+SELECT EXISTS (
+    SELECT 1 FROM users
+    WHERE lower(username) = lower(sqlc.arg(username)) AND id <> sqlc.arg(user_id)
+);
+
+-- name: GetUsersByUsernames :many
+-- users that blocked the author cannot be mentioned by them, usernames are expected in lower case
+SELECT id, username
+FROM users
+WHERE lower(username) = ANY(sqlc.arg(usernames)::text[]) AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = users.id AND b.blocked_id = sqlc.arg(author_id)
+    );
+
+-- name: FollowUser :exec
+-- users cannot follow each other while either of them blocks the other
+INSERT INTO follows (follower_id, followee_id)
+SELECT $1, $2
+WHERE NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE (b.blocker_id = $1 AND b.blocked_id = $2)
+       OR (b.blocker_id = $2 AND b.blocked_id = $1)
+)
+ON CONFLICT DO NOTHING;
+
+-- name: UnfollowUser :exec
+DELETE FROM follows
+WHERE follower_id = $1 AND followee_id = $2;
+
+-- name: GetUserFollowerCount :one
+SELECT COUNT(*) FROM follows
+WHERE followee_id = $1;
+
+-- name: GetUserFollowingCount :one
+SELECT COUNT(*) FROM follows
+WHERE follower_id = $1;
+
+-- name: GetFollowerIDs :many
+SELECT follower_id FROM follows
+WHERE followee_id = $1;
+
+-- name: BlockUser :exec
+INSERT INTO user_blocks (blocker_id, blocked_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: UnblockUser :exec
+DELETE FROM user_blocks
+WHERE blocker_id = $1 AND blocked_id = $2;
+
+-- name: DeleteFollowsBetween :exec
+DELETE FROM follows
+WHERE (follower_id = $1 AND followee_id = $2)
+   OR (follower_id = $2 AND followee_id = $1);
+
+-- name: GetBlockedUsers :many
+SELECT sqlc.embed(u)
+FROM user_blocks b
+    JOIN users u ON b.blocked_id = u.id
+WHERE b.blocker_id = sqlc.arg(user_id)
+ORDER BY b.created_at DESC
+LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
+
+-- name: MuteUser :exec
+INSERT INTO user_mutes (muter_id, muted_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: UnmuteUser :exec
+DELETE FROM user_mutes
+WHERE muter_id = $1 AND muted_id = $2;
+
+-- name: GetMutedUsers :many
+SELECT sqlc.embed(u)
+FROM user_mutes m
+    JOIN users u ON m.muted_id = u.id
+WHERE m.muter_id = sqlc.arg(user_id)
+ORDER BY m.created_at DESC
+LIMIT sqlc.arg(limit_num) OFFSET sqlc.arg(offset_num);
+
+-- name: GetNotifiableUserIDs :many
+-- drops the users that muted or blocked the actor
+SELECT id
+FROM users
+WHERE id = ANY(sqlc.arg(user_ids)::text[]) AND
+    NOT EXISTS (
+        SELECT 1 FROM user_mutes m
+        WHERE m.muter_id = users.id AND m.muted_id = sqlc.arg(actor_id)
+    ) AND
+    NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = users.id AND b.blocked_id = sqlc.arg(actor_id)
+    );
+
+-- name: DecrementResponseCountsByAuthorID :exec
+-- the responses of the author to other users' questions are deleted along with the author
+UPDATE questions q
+SET num_responses = GREATEST(q.num_responses - r.num, 0)
+FROM (
+    SELECT question_id, COUNT(*) AS num
+    FROM responses
+    WHERE author_id = $1 AND deleted_at IS NULL
+    GROUP BY question_id
+) r
+WHERE q.id = r.question_id AND q.author_id <> $1;
+
+-- name: DecrementReplyCountsByAuthorID :exec
+-- the comments of the author on other users' responses are deleted along with the author
+UPDATE responses r
+SET num_replies = GREATEST(r.num_replies - c.num, 0)
+FROM (
+    SELECT response_id, COUNT(*) AS num
+    FROM comments
+    WHERE author_id = $1
+    GROUP BY response_id
+) c
+WHERE r.id = c.response_id AND r.author_id <> $1;
+
+-- name: GetOtherUsersResponseImageURLs :many
+-- the images of other users' responses to the user's questions, these responses are deleted along with the user
+SELECT url::text
+FROM responses r
+    JOIN questions q ON r.question_id = q.id,
+    unnest(r.image_urls) AS url
+WHERE q.author_id = $1 AND r.author_id <> $1;
+
+-- name: DeleteUser :execrows
+DELETE FROM users
+WHERE id = $1;